@@ -1,6 +1,10 @@
 package cmd
 
 import (
+	"encoding/json"
+	"fmt"
+	"os"
+
 	"github.com/loft-sh/devspace/cmd/flags"
 	"github.com/loft-sh/devspace/pkg/devspace/build"
 	"github.com/loft-sh/devspace/pkg/devspace/dependency"
@@ -12,6 +16,7 @@ import (
 	"strings"
 
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
 
@@ -30,6 +35,8 @@ type BuildCmd struct {
 	BuildSequential     bool
 	MaxConcurrentBuilds int
 	ForceDependencies   bool
+
+	Output string
 }
 
 // NewBuildCmd creates a new devspace build command
@@ -63,6 +70,8 @@ Builds all defined images and pushes them
 	buildCmd.Flags().BoolVar(&cmd.SkipPush, "skip-push", false, "Skips image pushing, useful for minikube deployment")
 	buildCmd.Flags().BoolVar(&cmd.SkipPushLocalKubernetes, "skip-push-local-kube", false, "Skips image pushing, if a local kubernetes environment is detected")
 
+	buildCmd.Flags().StringVarP(&cmd.Output, "output", "o", "", "The output format of the build report. Can be either empty or json")
+
 	return buildCmd
 }
 
@@ -70,6 +79,13 @@ Builds all defined images and pushes them
 func (cmd *BuildCmd) Run(f factory.Factory, plugins []plugin.Metadata, cobraCmd *cobra.Command, args []string) error {
 	// Set config root
 	log := f.GetLog()
+	if cmd.Output == "json" {
+		// make sure stdout only contains the report
+		log = logpkg.NewStreamLogger(os.Stderr, logrus.InfoLevel)
+	} else if cmd.Output != "" {
+		return errors.Errorf("unsupported value for flag --output: %s", cmd.Output)
+	}
+
 	configOptions := cmd.ToConfigOptions()
 	configLoader := f.NewConfigLoader(cmd.ConfigPath)
 	configExists, err := configLoader.SetDevSpaceRoot(log)
@@ -145,13 +161,19 @@ func (cmd *BuildCmd) Run(f factory.Factory, plugins []plugin.Metadata, cobraCmd
 
 	// Build images if necessary
 	if len(cmd.Dependency) == 0 {
+		report := &build.Report{}
 		builtImages, err := f.NewBuildController(configInterface, dependencies, client).Build(&build.Options{
 			SkipPush:                  cmd.SkipPush,
 			SkipPushOnLocalKubernetes: cmd.SkipPushLocalKubernetes,
 			ForceRebuild:              cmd.ForceBuild,
 			Sequential:                cmd.BuildSequential,
 			MaxConcurrentBuilds:       cmd.MaxConcurrentBuilds,
+			Report:                    report,
 		}, log)
+		printErr := cmd.printReport(report, log)
+		if printErr != nil {
+			return printErr
+		}
 		if err != nil {
 			if strings.Index(err.Error(), "no space left on device") != -1 {
 				return errors.Errorf("Error building image: %v\n\n Try running `%s` to free docker daemon space and retry", err, ansi.Color("devspace cleanup images", "white+b"))
@@ -177,3 +199,18 @@ func (cmd *BuildCmd) Run(f factory.Factory, plugins []plugin.Metadata, cobraCmd
 
 	return nil
 }
+
+func (cmd *BuildCmd) printReport(report *build.Report, log logpkg.Logger) error {
+	if cmd.Output == "json" {
+		out, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(string(out))
+		return nil
+	}
+
+	report.Print(log)
+	return nil
+}
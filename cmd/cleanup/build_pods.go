@@ -0,0 +1,85 @@
+package cleanup
+
+import (
+	"github.com/loft-sh/devspace/cmd/flags"
+	"github.com/loft-sh/devspace/pkg/devspace/build/builder/kaniko"
+	"github.com/loft-sh/devspace/pkg/util/factory"
+	"github.com/loft-sh/devspace/pkg/util/message"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+type buildPodsCmd struct {
+	*flags.GlobalFlags
+
+	KeepCache bool
+}
+
+func newBuildPodsCmd(f factory.Factory, globalFlags *flags.GlobalFlags) *cobra.Command {
+	cmd := &buildPodsCmd{GlobalFlags: globalFlags}
+
+	buildPodsCmd := &cobra.Command{
+		Use:   "build-pods",
+		Short: "Deletes all reusable kaniko build pods",
+		Long: `
+#######################################################
+########### devspace cleanup build-pods ###############
+#######################################################
+Deletes all reusable kaniko build pods and their cache
+volumes in the namespaces used by the images
+#######################################################
+	`,
+		Args: cobra.NoArgs,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.RunCleanupBuildPods(f, cobraCmd, args)
+		}}
+
+	buildPodsCmd.Flags().BoolVar(&cmd.KeepCache, "keep-cache", false, "If enabled will not delete the cache volumes of the build pods")
+	return buildPodsCmd
+}
+
+// RunCleanupBuildPods executes the cleanup build-pods command logic
+func (cmd *buildPodsCmd) RunCleanupBuildPods(f factory.Factory, cobraCmd *cobra.Command, args []string) error {
+	// Set config root
+	log := f.GetLog()
+	configOptions := cmd.ToConfigOptions()
+	configLoader := f.NewConfigLoader(cmd.ConfigPath)
+	configExists, err := configLoader.SetDevSpaceRoot(log)
+	if err != nil {
+		return err
+	}
+	if !configExists {
+		return errors.New(message.ConfigNotFound)
+	}
+
+	client, err := f.NewKubeClientFromContext(cmd.KubeContext, cmd.Namespace, cmd.SwitchContext)
+	if err != nil {
+		return errors.Wrap(err, "create kube client")
+	}
+	configOptions.KubeClient = client
+
+	// Load config
+	configInterface, err := configLoader.Load(configOptions, log)
+	if err != nil {
+		return err
+	}
+
+	// Collect the build namespaces
+	namespaces := map[string]bool{client.Namespace(): true}
+	for _, imageConfig := range configInterface.Config().Images {
+		if imageConfig.Build != nil && imageConfig.Build.Kaniko != nil && imageConfig.Build.Kaniko.Namespace != "" {
+			namespaces[imageConfig.Build.Kaniko.Namespace] = true
+		}
+	}
+
+	for namespace := range namespaces {
+		err = kaniko.CleanupReusableBuildPods(client, namespace, !cmd.KeepCache, log)
+		if err != nil {
+			return err
+		}
+	}
+
+	log.Donef("Successfully cleaned up build pods")
+	return nil
+}
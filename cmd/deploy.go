@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/loft-sh/devspace/pkg/devspace/plugin"
 	"github.com/loft-sh/devspace/pkg/devspace/upgrade"
+	"os"
 	"strconv"
 	"strings"
 
@@ -17,6 +18,7 @@ import (
 	"github.com/loft-sh/devspace/pkg/util/factory"
 	logpkg "github.com/loft-sh/devspace/pkg/util/log"
 	"github.com/loft-sh/devspace/pkg/util/message"
+	"github.com/loft-sh/devspace/pkg/util/survey"
 	"github.com/mgutz/ansi"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -32,11 +34,15 @@ type DeployCmd struct {
 	BuildSequential     bool
 	MaxConcurrentBuilds int
 
-	ForceDeploy         bool
-	SkipDeploy          bool
-	Deployments         string
-	ForceDependencies   bool
-	VerboseDependencies bool
+	ForceDeploy          bool
+	SkipDeploy           bool
+	Deployments          string
+	ParallelDeploy       bool
+	MaxConcurrentDeploys int
+	Diff                 bool
+	ConfirmDiff          bool
+	ForceDependencies    bool
+	VerboseDependencies  bool
 
 	SkipPush                bool
 	SkipPushLocalKubernetes bool
@@ -91,6 +97,10 @@ devspace deploy --kube-context=deploy-context
 	deployCmd.Flags().BoolVarP(&cmd.ForceDeploy, "force-deploy", "d", false, "Forces to (re-)deploy every deployment")
 	deployCmd.Flags().BoolVar(&cmd.SkipDeploy, "skip-deploy", false, "Skips deploying and only builds images")
 	deployCmd.Flags().StringVar(&cmd.Deployments, "deployments", "", "Only deploy a specifc deployment (You can specify multiple deployments comma-separated")
+	deployCmd.Flags().BoolVar(&cmd.ParallelDeploy, "parallel-deploy", false, "Deploys deployments that do not depend on each other in parallel")
+	deployCmd.Flags().IntVar(&cmd.MaxConcurrentDeploys, "max-concurrent-deploys", 0, "The maximum number of deployments deployed in parallel (0 for infinite)")
+	deployCmd.Flags().BoolVar(&cmd.Diff, "diff", false, "Shows the changes to the objects in the cluster before deploying")
+	deployCmd.Flags().BoolVar(&cmd.ConfirmDiff, "confirm-diff", false, "Shows the changes to the objects in the cluster and asks for confirmation before deploying")
 
 	deployCmd.Flags().StringSliceVar(&cmd.Dependency, "dependency", []string{}, "Deploys only the specific named dependencies")
 
@@ -204,6 +214,18 @@ func (cmd *DeployCmd) Run(f factory.Factory, plugins []plugin.Metadata, cobraCmd
 		return errors.Wrap(err, "deploy dependencies")
 	}
 
+	// create namespaces, quotas and service accounts
+	err = f.NewBootstrapClient(configInterface, dependencies, client, cmd.log).EnsureNamespaces()
+	if err != nil {
+		return err
+	}
+
+	// create the secrets of the encrypted secret files
+	err = f.NewSecretsClient(configInterface, client, cmd.log).ApplySecrets()
+	if err != nil {
+		return err
+	}
+
 	// create pull secrets if necessary
 	err = f.NewPullSecretClient(configInterface, dependencies, client, dockerClient, cmd.log).CreatePullSecrets()
 	if err != nil {
@@ -249,12 +271,27 @@ func (cmd *DeployCmd) Run(f factory.Factory, plugins []plugin.Metadata, cobraCmd
 				}
 			}
 
+			deployController := f.NewDeployController(configInterface, dependencies, client)
+			deployOptions := &deploy.Options{
+				ForceDeploy:          cmd.ForceDeploy,
+				BuiltImages:          builtImages,
+				Deployments:          deployments,
+				Parallel:             cmd.ParallelDeploy,
+				MaxConcurrentDeploys: cmd.MaxConcurrentDeploys,
+			}
+
+			// show the changes before deploying
+			if cmd.Diff || cmd.ConfirmDiff {
+				proceed, err := confirmDiff(deployController, deployOptions, cmd.ConfirmDiff, cmd.log)
+				if err != nil {
+					return err
+				} else if proceed == false {
+					return nil
+				}
+			}
+
 			// deploy all defined deployments
-			err = f.NewDeployController(configInterface, dependencies, client).Deploy(&deploy.Options{
-				ForceDeploy: cmd.ForceDeploy,
-				BuiltImages: builtImages,
-				Deployments: deployments,
-			}, cmd.log)
+			err = deployController.Deploy(deployOptions, cmd.log)
 			if err != nil {
 				return err
 			}
@@ -292,6 +329,34 @@ func (cmd *DeployCmd) validateFlags() error {
 	return nil
 }
 
+// confirmDiff prints the changes the deployments would make to the cluster and, if confirm is true, asks
+// the user if the deployments should be deployed
+func confirmDiff(deployController deploy.Controller, options *deploy.Options, confirm bool, log logpkg.Logger) (bool, error) {
+	changed, err := deployController.Diff(options, os.Stdout, log)
+	if err != nil {
+		return false, errors.Wrap(err, "diff deployments")
+	} else if changed == false {
+		log.Info("Deploying will not change any objects in the cluster")
+		return true, nil
+	} else if confirm == false {
+		return true, nil
+	}
+
+	answer, err := log.Question(&survey.QuestionOptions{
+		Question:     "Do you want to deploy these changes?",
+		DefaultValue: "no",
+		Options:      []string{"yes", "no"},
+	})
+	if err != nil {
+		return false, err
+	} else if answer != "yes" {
+		log.Info("Deployment cancelled")
+		return false, nil
+	}
+
+	return true, nil
+}
+
 func fillDevSpaceDomainVars(client kubectl.Client, generatedConfig *generated.Config) error {
 	namespace, err := client.KubeClient().CoreV1().Namespaces().Get(context.TODO(), client.Namespace(), metav1.GetOptions{})
 	if err != nil {
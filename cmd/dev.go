@@ -56,9 +56,11 @@ type DevCmd struct {
 	BuildSequential     bool
 	MaxConcurrentBuilds int
 
-	ForceDeploy       bool
-	Deployments       string
-	ForceDependencies bool
+	ForceDeploy          bool
+	Deployments          string
+	ParallelDeploy       bool
+	MaxConcurrentDeploys int
+	ForceDependencies    bool
 
 	Sync            bool
 	ExitAfterDeploy bool
@@ -123,6 +125,8 @@ Open terminal instead of logs:
 
 	devCmd.Flags().BoolVarP(&cmd.ForceDeploy, "force-deploy", "d", false, "Forces to deploy every deployment")
 	devCmd.Flags().StringVar(&cmd.Deployments, "deployments", "", "Only deploy a specifc deployment (You can specify multiple deployments comma-separated")
+	devCmd.Flags().BoolVar(&cmd.ParallelDeploy, "parallel-deploy", false, "Deploys deployments that do not depend on each other in parallel")
+	devCmd.Flags().IntVar(&cmd.MaxConcurrentDeploys, "max-concurrent-deploys", 0, "The maximum number of deployments deployed in parallel (0 for infinite)")
 
 	devCmd.Flags().BoolVarP(&cmd.SkipPipeline, "skip-pipeline", "x", false, "Skips build & deployment and only starts sync, portforwarding & terminal")
 	devCmd.Flags().BoolVar(&cmd.SkipPush, "skip-push", false, "Skips image pushing, useful for minikube deployment")
@@ -279,6 +283,18 @@ func (cmd *DevCmd) buildAndDeploy(f factory.Factory, configInterface config.Conf
 		// add dev config from dependencies
 		addDependenciesDevConfig(config, dependencies)
 
+		// Create namespaces, quotas and service accounts
+		err = f.NewBootstrapClient(configInterface, dependencies, client, cmd.log).EnsureNamespaces()
+		if err != nil {
+			return 0, err
+		}
+
+		// Create the secrets of the encrypted secret files
+		err = f.NewSecretsClient(configInterface, client, cmd.log).ApplySecrets()
+		if err != nil {
+			return 0, err
+		}
+
 		// Create Pull Secrets
 		err = pullsecrets.NewClient(configInterface, dependencies, client, dockerClient, cmd.log).CreatePullSecrets()
 		if err != nil {
@@ -288,13 +304,18 @@ func (cmd *DevCmd) buildAndDeploy(f factory.Factory, configInterface config.Conf
 		// Build image if necessary
 		builtImages := make(map[string]string)
 		if cmd.SkipBuild == false {
+			report := &build.Report{}
 			builtImages, err = f.NewBuildController(configInterface, dependencies, client).Build(&build.Options{
 				SkipPush:                  cmd.SkipPush,
 				SkipPushOnLocalKubernetes: cmd.SkipPushLocalKubernetes,
 				ForceRebuild:              cmd.ForceBuild,
 				Sequential:                cmd.BuildSequential,
 				MaxConcurrentBuilds:       cmd.MaxConcurrentBuilds,
+				Report:                    report,
 			}, cmd.log)
+			if err != nil || len(builtImages) > 0 {
+				report.Print(cmd.log)
+			}
 			if err != nil {
 				if strings.Index(err.Error(), "no space left on device") != -1 {
 					return 0, errors.Errorf("Error building image: %v\n\n Try running `%s` to free docker daemon space and retry", err, ansi.Color("devspace cleanup images", "white+b"))
@@ -325,10 +346,12 @@ func (cmd *DevCmd) buildAndDeploy(f factory.Factory, configInterface config.Conf
 
 			// Deploy all
 			err = f.NewDeployController(configInterface, dependencies, client).Deploy(&deploy.Options{
-				IsDev:       true,
-				ForceDeploy: cmd.ForceDeploy,
-				BuiltImages: builtImages,
-				Deployments: deployments,
+				IsDev:                true,
+				ForceDeploy:          cmd.ForceDeploy,
+				BuiltImages:          builtImages,
+				Deployments:          deployments,
+				Parallel:             cmd.ParallelDeploy,
+				MaxConcurrentDeploys: cmd.MaxConcurrentDeploys,
 			}, cmd.log)
 			if err != nil {
 				return 0, errors.Errorf("error deploying: %v", err)
@@ -625,6 +648,8 @@ func GetPaths(config *latest.Config) []string {
 									paths = append(paths, manifestPath)
 								}
 							}
+						} else if deployConf.Command != nil {
+							paths = append(paths, deployConf.Command.Files...)
 						}
 					}
 				}
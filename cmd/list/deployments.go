@@ -1,10 +1,17 @@
 package list
 
 import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
 	"github.com/loft-sh/devspace/cmd/flags"
 	"github.com/loft-sh/devspace/pkg/devspace/dependency"
 	"github.com/loft-sh/devspace/pkg/devspace/deploy"
 	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer"
+	deployCommand "github.com/loft-sh/devspace/pkg/devspace/deploy/deployer/command"
+	deployComponent "github.com/loft-sh/devspace/pkg/devspace/deploy/deployer/component"
 	deployHelm "github.com/loft-sh/devspace/pkg/devspace/deploy/deployer/helm"
 	deployKubectl "github.com/loft-sh/devspace/pkg/devspace/deploy/deployer/kubectl"
 	helmtypes "github.com/loft-sh/devspace/pkg/devspace/helm/types"
@@ -13,36 +20,62 @@ import (
 	"github.com/loft-sh/devspace/pkg/util/message"
 
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
 
 type deploymentsCmd struct {
 	*flags.GlobalFlags
+
+	Drift  bool
+	Output string
+}
+
+// deploymentStatus is the json output of a single deployment
+type deploymentStatus struct {
+	*deployer.StatusResult
+
+	Drift      []*deployer.ResourceDrift `json:"drift,omitempty"`
+	DriftError string                    `json:"driftError,omitempty"`
 }
 
 func newDeploymentsCmd(f factory.Factory, globalFlags *flags.GlobalFlags) *cobra.Command {
 	cmd := &deploymentsCmd{GlobalFlags: globalFlags}
 
-	return &cobra.Command{
+	deploymentsCmd := &cobra.Command{
 		Use:   "deployments",
 		Short: "Lists and shows the status of all deployments",
 		Long: `
 #######################################################
 ############# devspace list deployments ###############
 #######################################################
-Shows the status of all deployments
+Shows the status of all deployments. With --drift the
+last deployed manifests are compared with the objects in
+the cluster to find resources that were changed manually,
+deleted or are unhealthy
 #######################################################
 	`,
 		Args: cobra.NoArgs,
 		RunE: func(cobraCmd *cobra.Command, args []string) error {
 			return cmd.RunDeploymentsStatus(f, cobraCmd, args)
 		}}
+
+	deploymentsCmd.Flags().BoolVar(&cmd.Drift, "drift", false, "Compare the last deployed manifests with the objects in the cluster")
+	deploymentsCmd.Flags().StringVarP(&cmd.Output, "output", "o", "", "The output format of the command. Can be either empty or json")
+	return deploymentsCmd
 }
 
 // RunDeploymentsStatus executes the devspace status deployments command logic
 func (cmd *deploymentsCmd) RunDeploymentsStatus(f factory.Factory, cobraCmd *cobra.Command, args []string) error {
 	// Set config root
 	logger := f.GetLog()
+	if cmd.Output == "json" {
+		// make sure stdout only contains the json output
+		logger = logpkg.NewStreamLogger(os.Stderr, logrus.InfoLevel)
+	} else if cmd.Output != "" {
+		return errors.Errorf("unsupported value for flag --output: %s", cmd.Output)
+	}
+
 	configOptions := cmd.ToConfigOptions()
 	configLoader := f.NewConfigLoader(cmd.ConfigPath)
 	configExists, err := configLoader.SetDevSpaceRoot(logger)
@@ -60,6 +93,19 @@ func (cmd *deploymentsCmd) RunDeploymentsStatus(f factory.Factory, cobraCmd *cob
 		"DEPLOY",
 		"STATUS",
 	}
+	if cmd.Drift {
+		headerValues = append(headerValues, "DRIFT")
+	}
+
+	var driftValues [][]string
+	var driftHeaderValues = []string{
+		"DEPLOYMENT",
+		"RESOURCE",
+		"NAMESPACE",
+		"DRIFT",
+	}
+
+	statuses := []*deploymentStatus{}
 
 	// Load generated
 	generatedConfig, err := configLoader.LoadGenerated(configOptions)
@@ -124,6 +170,18 @@ func (cmd *deploymentsCmd) RunDeploymentsStatus(f factory.Factory, cobraCmd *cob
 					logger.Warnf("Unable to create helm deploy config for %s: %v", deployConfig.Name, err)
 					continue
 				}
+			} else if deployConfig.Command != nil {
+				deployClient, err = deployCommand.New(configInterface, dependencies, client, deployConfig, logger)
+				if err != nil {
+					logger.Warnf("Unable to create command deploy config for %s: %v", deployConfig.Name, err)
+					continue
+				}
+			} else if deployConfig.Component != nil {
+				deployClient, err = deployComponent.New(configInterface, dependencies, client, deployConfig, logger)
+				if err != nil {
+					logger.Warnf("Unable to create component deploy config for %s: %v", deployConfig.Name, err)
+					continue
+				}
 			} else {
 				logger.Warnf("No deployment method defined for deployment %s", deployConfig.Name)
 				continue
@@ -135,15 +193,108 @@ func (cmd *deploymentsCmd) RunDeploymentsStatus(f factory.Factory, cobraCmd *cob
 				continue
 			}
 
-			values = append(values, []string{
+			row := []string{
 				status.Name,
 				status.Type,
 				status.Target,
 				status.Status,
-			})
+			}
+
+			deployment := &deploymentStatus{StatusResult: status}
+			if cmd.Drift {
+				deployment.Drift, err = deployClient.Drift()
+				if err != nil {
+					logger.Warnf("Error detecting drift of deployment %s: %v", deployConfig.Name, err)
+					deployment.DriftError = err.Error()
+					row = append(row, "Unknown")
+				} else {
+					row = append(row, driftSummary(deployment.Drift))
+				}
+
+				for _, drift := range deployment.Drift {
+					driftValues = append(driftValues, []string{
+						deployConfig.Name,
+						drift.Kind + "/" + drift.Name,
+						drift.Namespace,
+						describeDrift(drift),
+					})
+				}
+			}
+
+			values = append(values, row)
+			statuses = append(statuses, deployment)
 		}
 	}
 
+	if cmd.Output == "json" {
+		out, err := json.MarshalIndent(statuses, "", "  ")
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(string(out))
+		return nil
+	}
+
 	logpkg.PrintTable(logger, headerValues, values)
+	if len(driftValues) > 0 {
+		logger.WriteString("\n")
+		logpkg.PrintTable(logger, driftHeaderValues, driftValues)
+	}
+
 	return nil
 }
+
+// driftSummary counts the changed, deleted and unhealthy resources
+func driftSummary(drifts []*deployer.ResourceDrift) string {
+	changed, deleted, unhealthy := 0, 0, 0
+	for _, drift := range drifts {
+		if drift.Deleted {
+			deleted++
+		}
+		if len(drift.Changed) > 0 {
+			changed++
+		}
+		if drift.Unhealthy != "" {
+			unhealthy++
+		}
+	}
+
+	summary := []string{}
+	if changed > 0 {
+		summary = append(summary, fmt.Sprintf("%d changed", changed))
+	}
+	if deleted > 0 {
+		summary = append(summary, fmt.Sprintf("%d deleted", deleted))
+	}
+	if unhealthy > 0 {
+		summary = append(summary, fmt.Sprintf("%d unhealthy", unhealthy))
+	}
+	if len(summary) == 0 {
+		return "None"
+	}
+
+	return strings.Join(summary, ", ")
+}
+
+// describeDrift returns a short description of the drift of a single resource
+func describeDrift(drift *deployer.ResourceDrift) string {
+	if drift.Deleted {
+		return "Deleted"
+	}
+
+	description := []string{}
+	if len(drift.Changed) > 0 {
+		fields := drift.Changed
+		if len(fields) > 3 {
+			fields = append(fields[:3:3], fmt.Sprintf("%d more", len(drift.Changed)-3))
+		}
+
+		description = append(description, "Changed "+strings.Join(fields, ", "))
+	}
+	if drift.Unhealthy != "" {
+		description = append(description, "Unhealthy: "+drift.Unhealthy)
+	}
+
+	return strings.Join(description, "; ")
+}
@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/loft-sh/devspace/pkg/devspace/helm/postrender"
+	"github.com/spf13/cobra"
+)
+
+// NewHelmPostRenderCmd creates a new helm post-render command. This command is called by helm itself
+// and is therefore hidden
+func NewHelmPostRenderCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:    "helm-post-render [spec]",
+		Short:  "Post-renders helm manifests from stdin",
+		Hidden: true,
+		Args:   cobra.ExactArgs(1),
+		// helm reads the output of this command, so we skip the extra flags of the root command
+		DisableFlagParsing: true,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			spec, err := postrender.ReadSpec(args[0])
+			if err != nil {
+				return err
+			}
+
+			return postrender.Run(os.Stdin, os.Stdout, spec)
+		},
+	}
+}
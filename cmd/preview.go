@@ -0,0 +1,473 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/loft-sh/devspace/cmd/flags"
+	"github.com/loft-sh/devspace/pkg/devspace/config/generated"
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
+	"github.com/loft-sh/devspace/pkg/devspace/plugin"
+	"github.com/loft-sh/devspace/pkg/devspace/preview"
+	"github.com/loft-sh/devspace/pkg/util/factory"
+	"github.com/loft-sh/devspace/pkg/util/git"
+	logpkg "github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/loft-sh/devspace/pkg/util/message"
+
+	"github.com/mgutz/ansi"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+// gitBranchEnv is the env variable that overrides the git branch of the preview
+const gitBranchEnv = "DEVSPACE_GIT_BRANCH"
+
+// PreviewCmd holds the flags that identify a preview
+type PreviewCmd struct {
+	*flags.GlobalFlags
+
+	Branch  string
+	Project string
+}
+
+// NewPreviewCmd creates a new preview command
+func NewPreviewCmd(f factory.Factory, globalFlags *flags.GlobalFlags, plugins []plugin.Metadata) *cobra.Command {
+	previewCmd := &cobra.Command{
+		Use:   "preview",
+		Short: "Manages preview namespaces per git branch",
+		Long: `
+#######################################################
+################## devspace preview ###################
+#######################################################
+Deploys the project into a namespace per git branch,
+e.g. to review pull requests:
+
+devspace preview up
+devspace preview list
+devspace preview down
+#######################################################`,
+		Args: cobra.NoArgs,
+	}
+
+	previewCmd.AddCommand(newPreviewUpCmd(f, globalFlags, plugins))
+	previewCmd.AddCommand(newPreviewDownCmd(f, globalFlags))
+	previewCmd.AddCommand(newPreviewListCmd(f, globalFlags))
+
+	// Add plugin commands
+	plugin.AddPluginCommands(previewCmd, plugins, "preview")
+	return previewCmd
+}
+
+func (cmd *PreviewCmd) addFlags(command *cobra.Command) {
+	command.Flags().StringVar(&cmd.Branch, "branch", "", "The git branch of the preview (Default: $"+gitBranchEnv+" or the current git branch)")
+	command.Flags().StringVar(&cmd.Project, "project", "", "The project the preview belongs to (Default: the name of the project folder)")
+}
+
+// loadProject sets the devspace root and returns the project of the previews
+func (cmd *PreviewCmd) loadProject(f factory.Factory, log logpkg.Logger) (string, error) {
+	configExists, err := f.NewConfigLoader(cmd.ConfigPath).SetDevSpaceRoot(log)
+	if err != nil {
+		return "", err
+	} else if !configExists {
+		return "", errors.New(message.ConfigNotFound)
+	}
+
+	project := cmd.Project
+	if project == "" {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return "", err
+		}
+
+		project = filepath.Base(cwd)
+	}
+
+	project = preview.Sanitize(project)
+	if project == "" {
+		return "", errors.New("couldn't determine the project of the preview, please specify it with --project")
+	}
+
+	return project, nil
+}
+
+// getBranch returns the git branch of the preview
+func (cmd *PreviewCmd) getBranch() (string, error) {
+	if cmd.Branch != "" {
+		return cmd.Branch, nil
+	} else if branch := os.Getenv(gitBranchEnv); branch != "" {
+		return branch, nil
+	}
+
+	branch, err := git.GetBranch(".")
+	if err != nil {
+		return "", errors.Errorf("error retrieving git branch: %v, please specify the branch with --branch", err)
+	}
+
+	return branch, nil
+}
+
+// previewCachePath returns the path of the generated config of a preview namespace
+func previewCachePath(namespace string) string {
+	return filepath.Join(filepath.Dir(generated.ConfigPath), "previews", namespace+".yaml")
+}
+
+// usePreviewCache points the generated config to the cache of the preview namespace. A new
+// preview cache starts with the variables of the project, but without any deployment or
+// dependency cache, so everything is deployed into the preview namespace. The returned
+// function restores the generated config path of the project.
+func usePreviewCache(namespace string) (func(), error) {
+	projectConfigPath := generated.ConfigPath
+	cachePath := previewCachePath(namespace)
+	_, err := os.Stat(cachePath)
+	if os.IsNotExist(err) {
+		projectCache, err := generated.NewConfigLoader("").Load()
+		if err != nil {
+			return nil, errors.Wrap(err, "load generated config")
+		}
+
+		previewCache := generated.New()
+		previewCache.ActiveProfile = projectCache.ActiveProfile
+		previewCache.Vars = projectCache.Vars
+
+		generated.ConfigPath = cachePath
+		err = generated.NewConfigLoader("").Save(previewCache)
+		if err != nil {
+			generated.ConfigPath = projectConfigPath
+			return nil, errors.Wrap(err, "save preview cache")
+		}
+	} else if err != nil {
+		return nil, err
+	}
+
+	generated.ConfigPath = cachePath
+	return func() {
+		generated.ConfigPath = projectConfigPath
+	}, nil
+}
+
+// removePreviewCache removes the generated config of a deleted preview namespace
+func removePreviewCache(namespace string) {
+	_ = os.Remove(previewCachePath(namespace))
+}
+
+func (cmd *PreviewCmd) newKubeClient(f factory.Factory) (kubectl.Client, error) {
+	client, err := f.NewKubeClientFromContext(cmd.KubeContext, cmd.Namespace, false)
+	if err != nil {
+		return nil, errors.Errorf("unable to create new kubectl client: %v", err)
+	}
+
+	return client, nil
+}
+
+// PreviewUpCmd holds the flags of the preview up command
+type PreviewUpCmd struct {
+	PreviewCmd
+
+	TTL             time.Duration
+	SkipCleanup     bool
+	CopyPullSecrets bool
+
+	ForceBuild  bool
+	SkipBuild   bool
+	ForceDeploy bool
+	SkipPush    bool
+}
+
+func newPreviewUpCmd(f factory.Factory, globalFlags *flags.GlobalFlags, plugins []plugin.Metadata) *cobra.Command {
+	cmd := &PreviewUpCmd{PreviewCmd: PreviewCmd{GlobalFlags: globalFlags}}
+	upCmd := &cobra.Command{
+		Use:   "up",
+		Short: "Deploys the project into the preview namespace of the current git branch",
+		Long: `
+#######################################################
+################ devspace preview up ##################
+#######################################################
+Creates the preview namespace of the current git branch,
+deletes expired previews of the project and deploys the
+project and its dependencies into the preview namespace:
+
+devspace preview up
+devspace preview up --ttl 24h --copy-pull-secrets
+#######################################################`,
+		Args: cobra.NoArgs,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(f, plugins, cobraCmd, args)
+		},
+	}
+
+	cmd.addFlags(upCmd)
+	upCmd.Flags().DurationVar(&cmd.TTL, "ttl", 72*time.Hour, "Deletes the preview if it was not deployed within this duration (0 to keep it until it is deleted with devspace preview down)")
+	upCmd.Flags().BoolVar(&cmd.SkipCleanup, "skip-cleanup", false, "Skips deleting expired previews of the project")
+	upCmd.Flags().BoolVar(&cmd.CopyPullSecrets, "copy-pull-secrets", false, "Copies the image pull secrets of the current namespace into the preview namespace")
+
+	upCmd.Flags().BoolVarP(&cmd.ForceBuild, "force-build", "b", false, "Forces to (re-)build every image")
+	upCmd.Flags().BoolVar(&cmd.SkipBuild, "skip-build", false, "Skips building of images")
+	upCmd.Flags().BoolVarP(&cmd.ForceDeploy, "force-deploy", "d", false, "Forces to (re-)deploy every deployment")
+	upCmd.Flags().BoolVar(&cmd.SkipPush, "skip-push", false, "Skips image pushing, useful for minikube deployment")
+	return upCmd
+}
+
+// Run executes the preview up command logic
+func (cmd *PreviewUpCmd) Run(f factory.Factory, plugins []plugin.Metadata, cobraCmd *cobra.Command, args []string) error {
+	log := f.GetLog()
+	project, err := cmd.loadProject(f, log)
+	if err != nil {
+		return err
+	}
+
+	branch, err := cmd.getBranch()
+	if err != nil {
+		return err
+	}
+
+	client, err := cmd.newKubeClient(f)
+	if err != nil {
+		return err
+	}
+
+	// create or refresh the preview namespace
+	namespace := preview.NamespaceName(project, branch)
+	err = preview.Ensure(client.KubeClient(), &preview.Preview{
+		Namespace:    namespace,
+		Project:      project,
+		Branch:       branch,
+		LastDeployed: time.Now(),
+		TTL:          cmd.TTL,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "create preview namespace %s", namespace)
+	}
+
+	log.Donef("Using preview namespace %s for branch %s", ansi.Color(namespace, "white+b"), branch)
+
+	// delete expired previews of this project
+	if cmd.SkipCleanup == false {
+		deleted, err := preview.DeleteExpired(client.KubeClient(), project, time.Now())
+		for _, expired := range deleted {
+			log.Donef("Deleted expired preview namespace %s", expired)
+		}
+		if err != nil {
+			log.Warnf("Error deleting expired previews: %v", err)
+		}
+	}
+
+	// copy the pull secrets of the current namespace
+	if cmd.CopyPullSecrets {
+		copied, err := preview.CopyPullSecrets(client.KubeClient(), client.Namespace(), namespace)
+		if err != nil {
+			return errors.Wrap(err, "copy pull secrets")
+		}
+
+		log.Donef("Copied pull secrets %s from namespace %s", strings.Join(copied, ", "), client.Namespace())
+	}
+
+	// deploy with the cache of the preview, so the cache of the project stays untouched
+	restore, err := usePreviewCache(namespace)
+	if err != nil {
+		return err
+	}
+	defer restore()
+
+	// deploy the project and its dependencies into the preview namespace
+	globalFlags := *cmd.GlobalFlags
+	globalFlags.Namespace = namespace
+	globalFlags.SwitchContext = false
+	globalFlags.NoWarn = true
+
+	deployCmd := &DeployCmd{
+		GlobalFlags:             &globalFlags,
+		ForceBuild:              cmd.ForceBuild,
+		SkipBuild:               cmd.SkipBuild,
+		ForceDeploy:             cmd.ForceDeploy,
+		SkipPush:                cmd.SkipPush,
+		SkipPushLocalKubernetes: true,
+		ForceDependencies:       true,
+		VerboseDependencies:     true,
+		Timeout:                 120,
+	}
+
+	return deployCmd.Run(f, plugins, cobraCmd, args)
+}
+
+// PreviewDownCmd holds the flags of the preview down command
+type PreviewDownCmd struct {
+	PreviewCmd
+
+	Expired bool
+	All     bool
+}
+
+func newPreviewDownCmd(f factory.Factory, globalFlags *flags.GlobalFlags) *cobra.Command {
+	cmd := &PreviewDownCmd{PreviewCmd: PreviewCmd{GlobalFlags: globalFlags}}
+	downCmd := &cobra.Command{
+		Use:   "down",
+		Short: "Deletes the preview namespace of the current git branch",
+		Long: `
+#######################################################
+############### devspace preview down #################
+#######################################################
+Deletes the preview namespace of the current git branch
+or the previews of all branches of the project:
+
+devspace preview down
+devspace preview down --branch feature/login
+devspace preview down --expired
+devspace preview down --all
+#######################################################`,
+		Args: cobra.NoArgs,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(f)
+		},
+	}
+
+	cmd.addFlags(downCmd)
+	downCmd.Flags().BoolVar(&cmd.Expired, "expired", false, "Deletes all expired previews of the project instead")
+	downCmd.Flags().BoolVar(&cmd.All, "all", false, "Deletes all previews of the project instead")
+	return downCmd
+}
+
+// Run executes the preview down command logic
+func (cmd *PreviewDownCmd) Run(f factory.Factory) error {
+	log := f.GetLog()
+	if cmd.Expired && cmd.All {
+		return errors.New("flags --expired & --all cannot be used together")
+	}
+
+	project, err := cmd.loadProject(f, log)
+	if err != nil {
+		return err
+	}
+
+	client, err := cmd.newKubeClient(f)
+	if err != nil {
+		return err
+	}
+
+	namespaces := []string{}
+	if cmd.Expired {
+		namespaces, err = preview.DeleteExpired(client.KubeClient(), project, time.Now())
+		for _, namespace := range namespaces {
+			removePreviewCache(namespace)
+			log.Donef("Deleted expired preview namespace %s", namespace)
+		}
+		if err != nil {
+			return err
+		} else if len(namespaces) == 0 {
+			log.Info("No expired previews found")
+		}
+
+		return nil
+	} else if cmd.All {
+		previews, err := preview.List(client.KubeClient(), project)
+		if err != nil {
+			return err
+		}
+
+		for _, p := range previews {
+			namespaces = append(namespaces, p.Namespace)
+		}
+	} else {
+		branch, err := cmd.getBranch()
+		if err != nil {
+			return err
+		}
+
+		namespaces = append(namespaces, preview.NamespaceName(project, branch))
+	}
+
+	for _, namespace := range namespaces {
+		err = preview.Delete(client.KubeClient(), namespace)
+		if err != nil {
+			return errors.Wrapf(err, "delete preview %s", namespace)
+		}
+
+		removePreviewCache(namespace)
+		log.Donef("Deleted preview namespace %s", namespace)
+	}
+
+	return nil
+}
+
+// PreviewListCmd holds the flags of the preview list command
+type PreviewListCmd struct {
+	PreviewCmd
+
+	AllProjects bool
+}
+
+func newPreviewListCmd(f factory.Factory, globalFlags *flags.GlobalFlags) *cobra.Command {
+	cmd := &PreviewListCmd{PreviewCmd: PreviewCmd{GlobalFlags: globalFlags}}
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "Lists the preview namespaces of the project",
+		Long: `
+#######################################################
+############### devspace preview list #################
+#######################################################
+Lists the preview namespaces of all branches of the
+project:
+
+devspace preview list
+devspace preview list --all-projects
+#######################################################`,
+		Args: cobra.NoArgs,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(f)
+		},
+	}
+
+	listCmd.Flags().StringVar(&cmd.Project, "project", "", "The project the previews belong to (Default: the name of the project folder)")
+	listCmd.Flags().BoolVar(&cmd.AllProjects, "all-projects", false, "Lists the previews of all projects")
+	return listCmd
+}
+
+// Run executes the preview list command logic
+func (cmd *PreviewListCmd) Run(f factory.Factory) error {
+	log := f.GetLog()
+	project := ""
+	if cmd.AllProjects == false {
+		var err error
+		project, err = cmd.loadProject(f, log)
+		if err != nil {
+			return err
+		}
+	}
+
+	client, err := cmd.newKubeClient(f)
+	if err != nil {
+		return err
+	}
+
+	previews, err := preview.List(client.KubeClient(), project)
+	if err != nil {
+		return err
+	} else if len(previews) == 0 {
+		log.Info("No previews found")
+		return nil
+	}
+
+	now := time.Now()
+	rows := [][]string{}
+	for _, p := range previews {
+		expires := "Never"
+		if p.Expired(now) {
+			expires = "Expired"
+		} else if p.TTL > 0 {
+			expires = "in " + duration.HumanDuration(p.LastDeployed.Add(p.TTL).Sub(now))
+		}
+
+		rows = append(rows, []string{
+			p.Namespace,
+			p.Project,
+			p.Branch,
+			duration.HumanDuration(now.Sub(p.LastDeployed)) + " ago",
+			expires,
+		})
+	}
+
+	logpkg.PrintTable(log, []string{"Namespace", "Project", "Branch", "Last Deployed", "Expires"}, rows)
+	return nil
+}
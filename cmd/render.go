@@ -37,6 +37,7 @@ type RenderCmd struct {
 	MaxConcurrentBuilds int
 
 	Deployments string
+	Diff        bool
 
 	SkipDependencies bool
 	Dependency       []string
@@ -75,6 +76,7 @@ deployment.
 	renderCmd.Flags().BoolVar(&cmd.SkipPush, "skip-push", false, "Skips image pushing, useful for minikube deployment")
 	renderCmd.Flags().BoolVar(&cmd.SkipPushLocalKubernetes, "skip-push-local-kube", true, "Skips image pushing, if a local kubernetes environment is detected")
 	renderCmd.Flags().BoolVar(&cmd.SkipBuild, "skip-build", false, "Skips image building")
+	renderCmd.Flags().BoolVar(&cmd.Diff, "diff", false, "Shows the changes to the objects in the cluster instead of the rendered yamls")
 	renderCmd.Flags().StringVar(&cmd.Deployments, "deployments", "", "Only deploy a specifc deployment (You can specify multiple deployments comma-separated")
 
 	renderCmd.Flags().BoolVar(&cmd.SkipDependencies, "skip-dependencies", false, "Skips rendering the dependencies")
@@ -208,11 +210,24 @@ func (cmd *RenderCmd) Run(f factory.Factory, plugins []plugin.Metadata, cobraCmd
 		}
 	}
 
-	// Deploy all defined deployments
-	err = f.NewDeployController(configInterface, dependencies, client).Render(&deploy.Options{
+	// Show the changes to the live objects
+	deployOptions := &deploy.Options{
 		BuiltImages: builtImages,
 		Deployments: deployments,
-	}, cmd.Writer, log)
+	}
+	if cmd.Diff {
+		changed, err := f.NewDeployController(configInterface, dependencies, client).Diff(deployOptions, cmd.Writer, log)
+		if err != nil {
+			return err
+		} else if changed == false {
+			log.Info("Deploying will not change any objects in the cluster")
+		}
+
+		return nil
+	}
+
+	// Deploy all defined deployments
+	err = f.NewDeployController(configInterface, dependencies, client).Render(deployOptions, cmd.Writer, log)
 	if err != nil {
 		return err
 	}
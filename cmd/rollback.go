@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/loft-sh/devspace/cmd/flags"
+	"github.com/loft-sh/devspace/pkg/devspace/dependency"
+	"github.com/loft-sh/devspace/pkg/devspace/plugin"
+	"github.com/loft-sh/devspace/pkg/util/factory"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/loft-sh/devspace/pkg/util/message"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// RollbackCmd holds the required data for the rollback cmd
+type RollbackCmd struct {
+	*flags.GlobalFlags
+
+	Deployments string
+	Revision    int
+
+	log log.Logger
+}
+
+// NewRollbackCmd creates a new rollback command
+func NewRollbackCmd(f factory.Factory, globalFlags *flags.GlobalFlags, plugins []plugin.Metadata) *cobra.Command {
+	cmd := &RollbackCmd{
+		GlobalFlags: globalFlags,
+		log:         log.GetInstance(),
+	}
+
+	rollbackCmd := &cobra.Command{
+		Use:   "rollback",
+		Short: "Rolls back deployments to a previous revision",
+		Long: `
+#######################################################
+################## devspace rollback ##################
+#######################################################
+Rolls back the deployments to the previous revision or
+to the given revision. Helm deployments are rolled back
+with helm rollback, kubectl deployments re-apply the
+manifests that were applied in the revision:
+
+devspace rollback
+devspace rollback -d my-deployment
+devspace rollback -d my-deployment --revision 3
+#######################################################`,
+		Args: cobra.NoArgs,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(f, plugins, cobraCmd, args)
+		},
+	}
+
+	rollbackCmd.Flags().StringVarP(&cmd.Deployments, "deployments", "d", "", "The deployment to roll back (You can specify multiple deployments comma-separated, e.g. devspace-default,devspace-database etc.)")
+	rollbackCmd.Flags().IntVar(&cmd.Revision, "revision", 0, "The revision to roll back to (0 for the previous revision)")
+
+	return rollbackCmd
+}
+
+// Run executes the rollback command logic
+func (cmd *RollbackCmd) Run(f factory.Factory, plugins []plugin.Metadata, cobraCmd *cobra.Command, args []string) error {
+	// Set config root
+	cmd.log = f.GetLog()
+	configOptions := cmd.ToConfigOptions()
+	configLoader := f.NewConfigLoader(cmd.ConfigPath)
+	configExists, err := configLoader.SetDevSpaceRoot(cmd.log)
+	if err != nil {
+		return err
+	}
+	if !configExists {
+		return errors.New(message.ConfigNotFound)
+	}
+	if cmd.Revision < 0 {
+		return errors.New("flag --revision cannot be negative")
+	}
+
+	log.StartFileLogging()
+
+	// Get config with adjusted cluster config
+	generatedConfig, err := configLoader.LoadGenerated(configOptions)
+	if err != nil {
+		return err
+	}
+	configOptions.GeneratedConfig = generatedConfig
+
+	// Use last context if specified
+	err = cmd.UseLastContext(generatedConfig, cmd.log)
+	if err != nil {
+		return err
+	}
+
+	client, err := f.NewKubeClientFromContext(cmd.KubeContext, cmd.Namespace, cmd.SwitchContext)
+	if err != nil {
+		return errors.Wrap(err, "create kube client")
+	}
+	configOptions.KubeClient = client
+
+	err = client.PrintWarning(generatedConfig, cmd.NoWarn, true, cmd.log)
+	if err != nil {
+		return err
+	}
+
+	// Execute plugin hook
+	err = plugin.ExecutePluginHook(plugins, cobraCmd, args, "rollback", client.CurrentContext(), client.Namespace(), nil)
+	if err != nil {
+		return err
+	}
+
+	// Get config with adjusted cluster config
+	configInterface, err := configLoader.Load(configOptions, cmd.log)
+	if err != nil {
+		return err
+	}
+
+	// Resolve dependencies
+	dependencies, err := f.NewDependencyManager(configInterface, client, configOptions, cmd.log).ResolveAll(dependency.ResolveOptions{
+		UpdateDependencies: false,
+		Verbose:            false,
+	})
+	if err != nil {
+		cmd.log.Warnf("Error resolving dependencies: %v", err)
+	}
+
+	deployments := []string{}
+	if cmd.Deployments != "" {
+		deployments = strings.Split(cmd.Deployments, ",")
+		for index := range deployments {
+			deployments[index] = strings.TrimSpace(deployments[index])
+		}
+	}
+
+	// Roll back deployments
+	err = f.NewDeployController(configInterface, dependencies, client).Rollback(deployments, cmd.Revision, cmd.log)
+	if err != nil {
+		return err
+	}
+
+	err = configLoader.SaveGenerated(generatedConfig)
+	if err != nil {
+		return errors.Errorf("error saving generated config: %v", err)
+	}
+
+	return nil
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/loft-sh/devspace/cmd/reset"
 	"github.com/loft-sh/devspace/cmd/restore"
 	"github.com/loft-sh/devspace/cmd/save"
+	"github.com/loft-sh/devspace/cmd/secret"
 	"github.com/loft-sh/devspace/cmd/set"
 	"github.com/loft-sh/devspace/cmd/update"
 	"github.com/loft-sh/devspace/cmd/use"
@@ -147,6 +148,7 @@ func BuildRoot(f factory.Factory) *cobra.Command {
 	rootCmd.AddCommand(update.NewUpdateCmd(f, globalFlags, plugins))
 	rootCmd.AddCommand(save.NewSaveCmd(f, globalFlags, plugins))
 	rootCmd.AddCommand(restore.NewRestoreCmd(f, globalFlags, plugins))
+	rootCmd.AddCommand(secret.NewSecretCmd(f, globalFlags, plugins))
 
 	// Add main commands
 	rootCmd.AddCommand(NewInitCmd(f, plugins))
@@ -156,8 +158,10 @@ func BuildRoot(f factory.Factory) *cobra.Command {
 	rootCmd.AddCommand(NewSyncCmd(f, globalFlags, plugins))
 	rootCmd.AddCommand(NewRenderCmd(f, globalFlags, plugins))
 	rootCmd.AddCommand(NewPurgeCmd(f, globalFlags, plugins))
+	rootCmd.AddCommand(NewRollbackCmd(f, globalFlags, plugins))
 	rootCmd.AddCommand(NewUpgradeCmd(plugins))
 	rootCmd.AddCommand(NewDeployCmd(f, globalFlags, plugins))
+	rootCmd.AddCommand(NewPreviewCmd(f, globalFlags, plugins))
 	rootCmd.AddCommand(NewEnterCmd(f, globalFlags, plugins))
 	rootCmd.AddCommand(NewAnalyzeCmd(f, globalFlags, plugins))
 	rootCmd.AddCommand(NewLogsCmd(f, globalFlags, plugins))
@@ -166,6 +170,7 @@ func BuildRoot(f factory.Factory) *cobra.Command {
 	rootCmd.AddCommand(NewRunCmd(f, globalFlags))
 	rootCmd.AddCommand(NewAttachCmd(f, globalFlags, plugins))
 	rootCmd.AddCommand(NewPrintCmd(f, globalFlags, plugins))
+	rootCmd.AddCommand(NewHelmPostRenderCmd())
 
 	// Add plugin commands
 	plugin.AddPluginCommands(rootCmd, plugins, "")
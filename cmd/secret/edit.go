@@ -0,0 +1,148 @@
+package secret
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/loft-sh/devspace/pkg/devspace/secrets"
+	"github.com/loft-sh/devspace/pkg/util/factory"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// secretTemplate is used if the edited secret file does not exist yet
+const secretTemplate = `apiVersion: v1
+kind: Secret
+metadata:
+  name: my-secret
+type: Opaque
+stringData:
+  key: value
+`
+
+type editCmd struct{}
+
+func newEditCmd(f factory.Factory) *cobra.Command {
+	cmd := &editCmd{}
+	editCmd := &cobra.Command{
+		Use:   "edit",
+		Short: "Edits an encrypted secret file",
+		Long: `
+#######################################################
+################ devspace secret edit #################
+#######################################################
+Decrypts the given secret file, opens it in $EDITOR
+and encrypts it again after the editor was closed.
+Creates a new secret file if it does not exist yet.
+
+Examples:
+devspace secret edit secrets/database.yaml
+#######################################################
+	`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.RunEdit(f, cobraCmd, args)
+		}}
+
+	return editCmd
+}
+
+// RunEdit executes the secret edit command logic
+func (cmd *editCmd) RunEdit(f factory.Factory, cobraCmd *cobra.Command, args []string) error {
+	log := f.GetLog()
+	key, err := secrets.GetKey()
+	if err != nil {
+		return err
+	}
+
+	var (
+		encrypted []byte
+		decrypted = []byte(secretTemplate)
+	)
+	if _, err := os.Stat(args[0]); err == nil {
+		encrypted, err = ioutil.ReadFile(args[0])
+		if err != nil {
+			return err
+		}
+
+		decrypted, err = secrets.Decrypt(encrypted, key)
+		if err != nil {
+			return err
+		}
+	}
+
+	// write the decrypted secrets into a temporary file only the current user can read
+	tempDir, err := ioutil.TempDir("", "devspace-secret-")
+	if err != nil {
+		return err
+	}
+	keepTempDir := false
+	defer func() {
+		if keepTempDir == false {
+			_ = os.RemoveAll(tempDir)
+		}
+	}()
+
+	tempFile := filepath.Join(tempDir, filepath.Base(args[0]))
+	err = ioutil.WriteFile(tempFile, decrypted, 0600)
+	if err != nil {
+		return err
+	}
+
+	err = openEditor(tempFile)
+	if err != nil {
+		return errors.Wrap(err, "open editor")
+	}
+
+	edited, err := ioutil.ReadFile(tempFile)
+	if err != nil {
+		return err
+	} else if bytes.Equal(edited, decrypted) {
+		log.Info("Secret file was not changed")
+		return nil
+	}
+
+	// keep the edited file if it cannot be saved, so the changes are not lost
+	newEncrypted, err := secrets.Encrypt(edited, encrypted, key)
+	if err != nil {
+		keepTempDir = true
+		return errors.Wrapf(err, "encrypt secrets (your changes were kept unencrypted in %s, please delete the file after you have recovered them)", tempFile)
+	}
+
+	err = ioutil.WriteFile(args[0], newEncrypted, 0644)
+	if err != nil {
+		keepTempDir = true
+		return errors.Wrapf(err, "save secrets (your changes were kept unencrypted in %s, please delete the file after you have recovered them)", tempFile)
+	}
+
+	log.Donef("Successfully saved encrypted secrets to %s", args[0])
+	return nil
+}
+
+// openEditor opens the file in the editor of the user and waits until the editor is closed
+func openEditor(file string) error {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+		if runtime.GOOS == "windows" {
+			editor = "notepad"
+		}
+	}
+
+	// the editor might contain arguments, e.g. code --wait
+	command := strings.Fields(editor)
+	cmd := exec.Command(command[0], append(command[1:], file)...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
@@ -0,0 +1,72 @@
+package secret
+
+import (
+	"io/ioutil"
+
+	"github.com/loft-sh/devspace/pkg/devspace/secrets"
+	"github.com/loft-sh/devspace/pkg/util/factory"
+
+	"github.com/spf13/cobra"
+)
+
+type encryptCmd struct {
+	Output string
+}
+
+func newEncryptCmd(f factory.Factory) *cobra.Command {
+	cmd := &encryptCmd{}
+	encryptCmd := &cobra.Command{
+		Use:   "encrypt",
+		Short: "Encrypts the values of a secret file",
+		Long: `
+#######################################################
+############### devspace secret encrypt ###############
+#######################################################
+Encrypts the data and stringData values of the
+kubernetes secrets in the given file. Values that are
+already encrypted are kept.
+
+Examples:
+devspace secret encrypt secrets/database.yaml
+devspace secret encrypt secret.yaml -o secrets/database.yaml
+#######################################################
+	`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.RunEncrypt(f, cobraCmd, args)
+		}}
+
+	encryptCmd.Flags().StringVarP(&cmd.Output, "output", "o", "", "The file to write the encrypted secrets to (Default: the given file)")
+	return encryptCmd
+}
+
+// RunEncrypt executes the secret encrypt command logic
+func (cmd *encryptCmd) RunEncrypt(f factory.Factory, cobraCmd *cobra.Command, args []string) error {
+	key, err := secrets.GetKey()
+	if err != nil {
+		return err
+	}
+
+	manifest, err := ioutil.ReadFile(args[0])
+	if err != nil {
+		return err
+	}
+
+	encrypted, err := secrets.Encrypt(manifest, nil, key)
+	if err != nil {
+		return err
+	}
+
+	output := cmd.Output
+	if output == "" {
+		output = args[0]
+	}
+
+	err = ioutil.WriteFile(output, encrypted, 0644)
+	if err != nil {
+		return err
+	}
+
+	f.GetLog().Donef("Successfully encrypted secrets into %s", output)
+	return nil
+}
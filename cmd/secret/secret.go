@@ -0,0 +1,35 @@
+package secret
+
+import (
+	"github.com/loft-sh/devspace/cmd/flags"
+	"github.com/loft-sh/devspace/pkg/devspace/plugin"
+	"github.com/loft-sh/devspace/pkg/util/factory"
+	"github.com/spf13/cobra"
+)
+
+// NewSecretCmd creates a new cobra command
+func NewSecretCmd(f factory.Factory, globalFlags *flags.GlobalFlags, plugins []plugin.Metadata) *cobra.Command {
+	secretCmd := &cobra.Command{
+		Use:   "secret",
+		Short: "Encrypts and edits secret files",
+		Long: `
+#######################################################
+################### devspace secret ###################
+#######################################################
+Encrypted secret files can be committed and are created
+as kubernetes secrets during devspace deploy & dev, if
+they are listed in the secrets section of the config.
+The key is read from $DEVSPACE_SECRETS_KEY or the os
+keychain (service devspace-secrets)
+#######################################################
+	`,
+		Args: cobra.NoArgs,
+	}
+
+	secretCmd.AddCommand(newEncryptCmd(f))
+	secretCmd.AddCommand(newEditCmd(f))
+
+	// Add plugin commands
+	plugin.AddPluginCommands(secretCmd, plugins, "secret")
+	return secretCmd
+}
@@ -60,6 +60,9 @@ module.exports = {
                 'configuration/images/buildkit',
                 'configuration/images/kaniko',
                 'configuration/images/custom',
+                'configuration/images/buildpacks',
+                'configuration/images/ko',
+                'configuration/images/jib',
                 'configuration/images/disabled',
               ],
             },
@@ -73,6 +76,8 @@ module.exports = {
             'configuration/deployments/helm-charts',
             'configuration/deployments/kubernetes-manifests',
             'configuration/deployments/kustomizations',
+            'configuration/deployments/commands',
+            'configuration/deployments/components',
             {
               type: 'link',
               label: '↗️ Component Chart',
@@ -127,6 +132,8 @@ module.exports = {
             'configuration/profiles/parents',
           ],
         },
+        'configuration/namespaces/basics',
+        'configuration/secrets/basics',
         'configuration/pullSecrets/basics',
         'configuration/commands/basics',
         'configuration/hooks/basics',
@@ -206,6 +213,7 @@ module.exports = {
         "commands/devspace_analyze",
         "commands/devspace_attach",
         "commands/devspace_build",
+        "commands/devspace_cleanup_build-pods",
         "commands/devspace_cleanup_images",
         "commands/devspace_connect_cluster",
         "commands/devspace_create_space",
@@ -234,6 +242,15 @@ module.exports = {
         "commands/devspace_login",
         "commands/devspace_logs",
         "commands/devspace_open",
+        {
+          type: "category",
+          label: "devspace preview",
+          items: [
+            "commands/devspace_preview_down",
+            "commands/devspace_preview_list",
+            "commands/devspace_preview_up"
+          ]
+        },
         "commands/devspace_print",
         "commands/devspace_purge",
         {
@@ -261,7 +278,16 @@ module.exports = {
             "commands/devspace_reset_vars"
           ]
         },
+        "commands/devspace_rollback",
         "commands/devspace_run",
+        {
+          type: "category",
+          label: "devspace secret",
+          items: [
+            "commands/devspace_secret_edit",
+            "commands/devspace_secret_encrypt"
+          ]
+        },
         {
           type: "category",
           label: "devspace set",
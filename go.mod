@@ -73,6 +73,7 @@ require (
 	github.com/theupdateframework/notary v0.6.1 // indirect
 	github.com/toqueteos/trie v1.0.0 // indirect
 	github.com/ulikunitz/xz v0.5.7 // indirect
+	golang.org/x/crypto v0.0.0-20210220033148-5ea612d1eb83
 	golang.org/x/net v0.0.0-20210224082022-3d97a244fca7
 	gomodules.xyz/jsonpatch/v2 v2.1.0 // indirect
 	google.golang.org/grpc v1.29.1
@@ -91,6 +92,7 @@ require (
 	k8s.io/klog v1.0.0
 	k8s.io/kubectl v0.21.2
 	mvdan.cc/sh/v3 v3.2.4
+	sigs.k8s.io/kustomize/api v0.8.8
 )
 
 replace github.com/agl/ed25519 => github.com/agl/ed25519 v0.0.0-20170116200512-5312a6153412
@@ -0,0 +1,37 @@
+package bootstrap
+
+import (
+	config2 "github.com/loft-sh/devspace/pkg/devspace/config"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/devspace/dependency/types"
+	"github.com/loft-sh/devspace/pkg/devspace/hook"
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
+	"github.com/loft-sh/devspace/pkg/util/log"
+)
+
+// Client creates the namespaces of the config together with their quotas, limit ranges and service accounts
+type Client interface {
+	EnsureNamespaces() error
+}
+
+// NewClient creates a new bootstrap client
+func NewClient(config config2.Config, dependencies []types.Dependency, kubeClient kubectl.Client, log log.Logger) Client {
+	var latest *latest.Config
+	if config != nil {
+		latest = config.Config()
+	}
+
+	return &client{
+		config:       latest,
+		kubeClient:   kubeClient,
+		hookExecuter: hook.NewExecuter(config, dependencies),
+		log:          log,
+	}
+}
+
+type client struct {
+	config       *latest.Config
+	kubeClient   kubectl.Client
+	hookExecuter hook.Executer
+	log          log.Logger
+}
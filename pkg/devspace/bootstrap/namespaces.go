@@ -0,0 +1,300 @@
+package bootstrap
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/devspace/hook"
+
+	yaml2 "github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// EnsureNamespaces creates or updates the configured namespaces, resource quotas, limit ranges and service accounts
+func (c *client) EnsureNamespaces() error {
+	// execute before namespaces hooks
+	err := c.hookExecuter.Execute(hook.Before, hook.StageNamespaces, hook.All, hook.Context{Client: c.kubeClient}, c.log)
+	if err != nil {
+		return err
+	}
+
+	if c.config != nil {
+		for _, namespace := range c.config.Namespaces {
+			err = c.ensureNamespace(namespace)
+			if err != nil {
+				// execute on error namespaces hooks
+				c.hookExecuter.OnError(hook.StageNamespaces, []string{hook.All}, hook.Context{Client: c.kubeClient, Error: err}, c.log)
+				return errors.Wrapf(err, "bootstrap namespace %s", namespace.Name)
+			}
+		}
+	}
+
+	// execute after namespaces hooks
+	err = c.hookExecuter.Execute(hook.After, hook.StageNamespaces, hook.All, hook.Context{Client: c.kubeClient}, c.log)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *client) ensureNamespace(namespaceConfig *latest.NamespaceConfig) error {
+	namespaces := c.kubeClient.KubeClient().CoreV1().Namespaces()
+	namespace, err := namespaces.Get(context.TODO(), namespaceConfig.Name, metav1.GetOptions{})
+	if err != nil {
+		if kerrors.IsNotFound(err) == false {
+			return errors.Wrap(err, "get namespace")
+		}
+
+		_, err = namespaces.Create(context.TODO(), &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        namespaceConfig.Name,
+				Labels:      namespaceConfig.Labels,
+				Annotations: namespaceConfig.Annotations,
+			},
+		}, metav1.CreateOptions{})
+		if err != nil {
+			return errors.Wrap(err, "create namespace")
+		}
+
+		c.log.Donef("Created namespace: %s", namespaceConfig.Name)
+	} else if mergeMetadata(&namespace.ObjectMeta, namespaceConfig.Labels, namespaceConfig.Annotations) {
+		_, err = namespaces.Update(context.TODO(), namespace, metav1.UpdateOptions{})
+		if err != nil {
+			return errors.Wrap(err, "update namespace")
+		}
+
+		c.log.Donef("Updated namespace: %s", namespaceConfig.Name)
+	}
+
+	for _, quotaConfig := range namespaceConfig.ResourceQuotas {
+		err = c.ensureResourceQuota(namespaceConfig.Name, quotaConfig)
+		if err != nil {
+			return errors.Wrapf(err, "resource quota %s", quotaConfig.Name)
+		}
+	}
+	for _, limitRangeConfig := range namespaceConfig.LimitRanges {
+		err = c.ensureLimitRange(namespaceConfig.Name, limitRangeConfig)
+		if err != nil {
+			return errors.Wrapf(err, "limit range %s", limitRangeConfig.Name)
+		}
+	}
+	for _, serviceAccountConfig := range namespaceConfig.ServiceAccounts {
+		err = c.ensureServiceAccount(namespaceConfig.Name, serviceAccountConfig)
+		if err != nil {
+			return errors.Wrapf(err, "service account %s", serviceAccountConfig.Name)
+		}
+	}
+
+	return nil
+}
+
+func (c *client) ensureResourceQuota(namespace string, quotaConfig *latest.NamespaceResourceConfig) error {
+	spec := corev1.ResourceQuotaSpec{}
+	err := ConvertSpec(quotaConfig.Spec, &spec)
+	if err != nil {
+		return err
+	}
+
+	quotas := c.kubeClient.KubeClient().CoreV1().ResourceQuotas(namespace)
+	quota, err := quotas.Get(context.TODO(), quotaConfig.Name, metav1.GetOptions{})
+	if err != nil {
+		if kerrors.IsNotFound(err) == false {
+			return err
+		}
+
+		_, err = quotas.Create(context.TODO(), &corev1.ResourceQuota{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      quotaConfig.Name,
+				Namespace: namespace,
+			},
+			Spec: spec,
+		}, metav1.CreateOptions{})
+		if err != nil {
+			return err
+		}
+
+		c.log.Donef("Created resource quota %s in namespace %s", quotaConfig.Name, namespace)
+		return nil
+	} else if specEquals(quota.Spec, spec) {
+		return nil
+	}
+
+	quota.Spec = spec
+	_, err = quotas.Update(context.TODO(), quota, metav1.UpdateOptions{})
+	if err != nil {
+		return err
+	}
+
+	c.log.Donef("Updated resource quota %s in namespace %s", quotaConfig.Name, namespace)
+	return nil
+}
+
+func (c *client) ensureLimitRange(namespace string, limitRangeConfig *latest.NamespaceResourceConfig) error {
+	spec := corev1.LimitRangeSpec{}
+	err := ConvertSpec(limitRangeConfig.Spec, &spec)
+	if err != nil {
+		return err
+	}
+
+	limitRanges := c.kubeClient.KubeClient().CoreV1().LimitRanges(namespace)
+	limitRange, err := limitRanges.Get(context.TODO(), limitRangeConfig.Name, metav1.GetOptions{})
+	if err != nil {
+		if kerrors.IsNotFound(err) == false {
+			return err
+		}
+
+		_, err = limitRanges.Create(context.TODO(), &corev1.LimitRange{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      limitRangeConfig.Name,
+				Namespace: namespace,
+			},
+			Spec: spec,
+		}, metav1.CreateOptions{})
+		if err != nil {
+			return err
+		}
+
+		c.log.Donef("Created limit range %s in namespace %s", limitRangeConfig.Name, namespace)
+		return nil
+	} else if specEquals(limitRange.Spec, spec) {
+		return nil
+	}
+
+	limitRange.Spec = spec
+	_, err = limitRanges.Update(context.TODO(), limitRange, metav1.UpdateOptions{})
+	if err != nil {
+		return err
+	}
+
+	c.log.Donef("Updated limit range %s in namespace %s", limitRangeConfig.Name, namespace)
+	return nil
+}
+
+func (c *client) ensureServiceAccount(namespace string, serviceAccountConfig *latest.ServiceAccountConfig) error {
+	serviceAccounts := c.kubeClient.KubeClient().CoreV1().ServiceAccounts(namespace)
+
+	// the default service account is created by kubernetes in the background, so creating or updating it might conflict
+	return wait.PollImmediate(time.Second, time.Second*30, func() (bool, error) {
+		serviceAccount, err := serviceAccounts.Get(context.TODO(), serviceAccountConfig.Name, metav1.GetOptions{})
+		if err != nil {
+			if kerrors.IsNotFound(err) == false {
+				return false, err
+			}
+
+			_, err = serviceAccounts.Create(context.TODO(), &corev1.ServiceAccount{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        serviceAccountConfig.Name,
+					Namespace:   namespace,
+					Labels:      serviceAccountConfig.Labels,
+					Annotations: serviceAccountConfig.Annotations,
+				},
+				AutomountServiceAccountToken: serviceAccountConfig.AutomountServiceAccountToken,
+			}, metav1.CreateOptions{})
+			if err != nil {
+				if kerrors.IsAlreadyExists(err) {
+					return false, nil
+				}
+
+				return false, err
+			}
+
+			c.log.Donef("Created service account %s in namespace %s", serviceAccountConfig.Name, namespace)
+			return true, nil
+		}
+
+		changed := mergeMetadata(&serviceAccount.ObjectMeta, serviceAccountConfig.Labels, serviceAccountConfig.Annotations)
+		if serviceAccountConfig.AutomountServiceAccountToken != nil && (serviceAccount.AutomountServiceAccountToken == nil || *serviceAccount.AutomountServiceAccountToken != *serviceAccountConfig.AutomountServiceAccountToken) {
+			serviceAccount.AutomountServiceAccountToken = serviceAccountConfig.AutomountServiceAccountToken
+			changed = true
+		}
+		if changed == false {
+			return true, nil
+		}
+
+		_, err = serviceAccounts.Update(context.TODO(), serviceAccount, metav1.UpdateOptions{})
+		if err != nil {
+			if kerrors.IsConflict(err) {
+				return false, nil
+			}
+
+			return false, err
+		}
+
+		c.log.Donef("Updated service account %s in namespace %s", serviceAccountConfig.Name, namespace)
+		return true, nil
+	})
+}
+
+// mergeMetadata adds the labels and annotations to the object meta and returns true if anything changed
+func mergeMetadata(meta *metav1.ObjectMeta, labels, annotations map[string]string) bool {
+	changed := false
+	for k, v := range labels {
+		if meta.Labels == nil {
+			meta.Labels = map[string]string{}
+		}
+		if current, ok := meta.Labels[k]; !ok || current != v {
+			meta.Labels[k] = v
+			changed = true
+		}
+	}
+	for k, v := range annotations {
+		if meta.Annotations == nil {
+			meta.Annotations = map[string]string{}
+		}
+		if current, ok := meta.Annotations[k]; !ok || current != v {
+			meta.Annotations[k] = v
+			changed = true
+		}
+	}
+
+	return changed
+}
+
+// specEquals compares two specs by their json representation, so equal quantities in different formats match
+func specEquals(a, b interface{}) bool {
+	aJSON, err := json.Marshal(a)
+	if err != nil {
+		return false
+	}
+	bJSON, err := json.Marshal(b)
+	if err != nil {
+		return false
+	}
+
+	return bytes.Equal(aJSON, bJSON)
+}
+
+// ConvertSpec converts the given config spec into the kubernetes spec type and fails on unknown fields
+func ConvertSpec(spec map[interface{}]interface{}, into interface{}) error {
+	if len(spec) == 0 {
+		return nil
+	}
+
+	out, err := yaml.Marshal(spec)
+	if err != nil {
+		return err
+	}
+
+	jsonBytes, err := yaml2.YAMLToJSON(out)
+	if err != nil {
+		return err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(jsonBytes))
+	decoder.DisallowUnknownFields()
+	err = decoder.Decode(into)
+	if err != nil {
+		return errors.Wrap(err, "spec")
+	}
+
+	return nil
+}
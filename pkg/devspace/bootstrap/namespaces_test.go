@@ -0,0 +1,110 @@
+package bootstrap
+
+import (
+	"context"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	fakekube "github.com/loft-sh/devspace/pkg/devspace/kubectl/testing"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/loft-sh/devspace/pkg/util/ptr"
+
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestEnsureNamespaces(t *testing.T) {
+	kubeClient := fake.NewSimpleClientset(
+		&corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "existing",
+				Labels: map[string]string{"team": "backend"},
+			},
+		},
+		&corev1.ServiceAccount{
+			ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "existing"},
+		},
+	)
+
+	config := config.NewConfig(nil, &latest.Config{
+		Namespaces: []*latest.NamespaceConfig{
+			{
+				Name:   "new",
+				Labels: map[string]string{"istio-injection": "enabled"},
+				ResourceQuotas: []*latest.NamespaceResourceConfig{
+					{
+						Name: "compute",
+						Spec: map[interface{}]interface{}{
+							"hard": map[interface{}]interface{}{
+								"limits.cpu": "4",
+							},
+						},
+					},
+				},
+				LimitRanges: []*latest.NamespaceResourceConfig{
+					{
+						Name: "defaults",
+						Spec: map[interface{}]interface{}{
+							"limits": []interface{}{
+								map[interface{}]interface{}{
+									"type": "Container",
+									"default": map[interface{}]interface{}{
+										"memory": "512Mi",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			{
+				Name:        "existing",
+				Annotations: map[string]string{"owner": "devspace"},
+				ServiceAccounts: []*latest.ServiceAccountConfig{
+					{
+						Name:                         "default",
+						Annotations:                  map[string]string{"eks.amazonaws.com/role-arn": "arn"},
+						AutomountServiceAccountToken: ptr.Bool(false),
+					},
+				},
+			},
+		},
+	}, nil, nil)
+
+	client := NewClient(config, nil, &fakekube.Client{Client: kubeClient}, log.Discard)
+	for i := 0; i < 2; i++ {
+		err := client.EnsureNamespaces()
+		assert.NilError(t, err)
+	}
+
+	namespace, err := kubeClient.CoreV1().Namespaces().Get(context.TODO(), "new", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, namespace.Labels, map[string]string{"istio-injection": "enabled"})
+
+	quota, err := kubeClient.CoreV1().ResourceQuotas("new").Get(context.TODO(), "compute", metav1.GetOptions{})
+	assert.NilError(t, err)
+	limitsCPU := quota.Spec.Hard[corev1.ResourceLimitsCPU]
+	assert.Equal(t, limitsCPU.String(), "4")
+
+	limitRange, err := kubeClient.CoreV1().LimitRanges("new").Get(context.TODO(), "defaults", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, limitRange.Spec.Limits[0].Default.Memory().String(), "512Mi")
+
+	namespace, err = kubeClient.CoreV1().Namespaces().Get(context.TODO(), "existing", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, namespace.Labels, map[string]string{"team": "backend"})
+	assert.DeepEqual(t, namespace.Annotations, map[string]string{"owner": "devspace"})
+
+	serviceAccount, err := kubeClient.CoreV1().ServiceAccounts("existing").Get(context.TODO(), "default", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, serviceAccount.Annotations, map[string]string{"eks.amazonaws.com/role-arn": "arn"})
+	assert.Equal(t, *serviceAccount.AutomountServiceAccountToken, false)
+}
+
+func TestConvertSpec(t *testing.T) {
+	err := ConvertSpec(map[interface{}]interface{}{"hardd": map[interface{}]interface{}{}}, &corev1.ResourceQuotaSpec{})
+	assert.Error(t, err, `spec: json: unknown field "hardd"`)
+}
@@ -0,0 +1,9 @@
+package testing
+
+// Client is a fake implementation of the Client interface
+type Client struct{}
+
+// EnsureNamespaces is a fake implementation of the function
+func (c *Client) EnsureNamespaces() error {
+	return nil
+}
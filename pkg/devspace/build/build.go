@@ -6,6 +6,7 @@ import (
 	"github.com/loft-sh/devspace/pkg/devspace/dependency/types"
 	"io"
 	"strings"
+	"time"
 
 	"github.com/loft-sh/devspace/pkg/devspace/hook"
 	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
@@ -19,6 +20,7 @@ type imageNameAndTag struct {
 	imageConfigName string
 	imageName       string
 	imageTag        string
+	imageDigest     string
 }
 
 // Options describe how images should be build
@@ -28,6 +30,9 @@ type Options struct {
 	ForceRebuild              bool
 	Sequential                bool
 	MaxConcurrentBuilds       int
+
+	// Report is filled with information about every processed image if set
+	Report *Report
 }
 
 // Controller is the main building interface
@@ -93,6 +98,12 @@ func (c *controller) Build(options *Options, log logpkg.Logger) (map[string]stri
 	for key, imageConf := range config.Images {
 		if imageConf.Build != nil && imageConf.Build.Disabled == true {
 			log.Infof("Skipping building image %s", key)
+			options.Report.Add(&ImageReport{
+				ImageConfigName: key,
+				ImageName:       imageConf.Image,
+				Skipped:         true,
+				Reason:          "build disabled",
+			})
 			continue
 		}
 
@@ -123,16 +134,33 @@ func (c *controller) Build(options *Options, log logpkg.Logger) (map[string]stri
 		}
 
 		// Check if rebuild is needed
+		cacheBefore := *c.config.Generated().GetActive().GetImageCache(imageConfigName)
 		needRebuild, err := builder.ShouldRebuild(c.config.Generated().GetActive(), options.ForceRebuild)
 		if err != nil {
 			return nil, errors.Errorf("error during shouldRebuild check: %v", err)
 		}
 
+		imageReport := &ImageReport{
+			ImageConfigName: imageConfigName,
+			ImageName:       imageName,
+			Builder:         builderName(builder),
+			Tags:            imageTags,
+		}
 		if options.ForceRebuild == false && needRebuild == false {
 			log.Infof("Skip building image '%s'", imageConfigName)
+			imageReport.Skipped = true
+			imageReport.Reason = "no changes detected"
+			options.Report.Add(imageReport)
 			continue
 		}
 
+		imageReport.Reason = rebuildReason(&cImageConf, &cacheBefore, c.config.Generated().GetActive().GetImageCache(imageConfigName), options.ForceRebuild)
+		imageReport.Pushed = c.shouldPush(&cImageConf, options)
+		err = c.prepareVerify(builder, imageConfigName, &cImageConf, imageTags[0], imageReport)
+		if err != nil {
+			return nil, err
+		}
+
 		// Execute before images build hook
 		err = c.hookExecuter.Execute(hook.Before, hook.StageImages, imageConfigName, hook.Context{Client: c.client}, log)
 		if err != nil {
@@ -142,8 +170,13 @@ func (c *controller) Build(options *Options, log logpkg.Logger) (map[string]stri
 		// Sequential or parallel build?
 		if options.Sequential {
 			// Build the image
+			start := time.Now()
 			err = builder.Build(log)
+			imageReport.Duration = time.Since(start).Seconds()
 			if err != nil {
+				imageReport.Error = err.Error()
+				imageReport.Pushed = false
+				options.Report.Add(imageReport)
 				c.hookExecuter.OnError(hook.StageImages, []string{hook.All, imageConfigName}, hook.Context{Client: c.client, Error: err}, log)
 				return nil, errors.Wrapf(err, "error building image %s:%s", imageName, imageTags[0])
 			}
@@ -156,9 +189,12 @@ func (c *controller) Build(options *Options, log logpkg.Logger) (map[string]stri
 
 			imageCache.ImageName = imageName
 			imageCache.Tag = imageTags[0]
+			imageCache.Digest = getDigest(builder)
 
 			// Track built images
 			builtImages[imageName] = imageTags[0]
+			imageReport.Digest = imageCache.Digest
+			options.Report.Add(imageReport)
 
 			// Execute before images build hook
 			err = c.hookExecuter.Execute(hook.After, hook.StageImages, imageConfigName, hook.Context{Client: c.client}, log)
@@ -192,9 +228,14 @@ func (c *controller) Build(options *Options, log logpkg.Logger) (map[string]stri
 				}()
 
 				// Build the image
+				start := time.Now()
 				err := builder.Build(streamLog)
+				imageReport.Duration = time.Since(start).Seconds()
 				_ = writer.Close()
 				if err != nil {
+					imageReport.Error = err.Error()
+					imageReport.Pushed = false
+					options.Report.Add(imageReport)
 					c.hookExecuter.OnError(hook.StageImages, []string{imageConfigName}, hook.Context{Client: c.client, Error: err}, log)
 					errChan <- errors.Errorf("error building image %s:%s: %v", imageName, imageTags[0], err)
 					return
@@ -208,10 +249,13 @@ func (c *controller) Build(options *Options, log logpkg.Logger) (map[string]stri
 				}
 
 				// Send the reponse
+				imageReport.Digest = getDigest(builder)
+				options.Report.Add(imageReport)
 				cacheChan <- imageNameAndTag{
 					imageConfigName: imageConfigName,
 					imageName:       imageName,
 					imageTag:        imageTags[0],
+					imageDigest:     imageReport.Digest,
 				}
 			}()
 		}
@@ -254,6 +298,7 @@ func (c *controller) waitForBuild(errChan <-chan error, cacheChan <-chan imageNa
 
 		imageCache.ImageName = done.imageName
 		imageCache.Tag = done.imageTag
+		imageCache.Digest = done.imageDigest
 
 		// Track built images
 		builtImages[done.imageName] = done.imageTag
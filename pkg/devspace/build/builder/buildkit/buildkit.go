@@ -38,6 +38,8 @@ type Builder struct {
 
 	skipPush                  bool
 	skipPushOnLocalKubernetes bool
+
+	digestWriter *helper.DigestWriter
 }
 
 // NewBuilder creates a new docker Builder instance
@@ -59,6 +61,15 @@ func (b *Builder) ShouldRebuild(cache *generated.CacheConfig, forceRebuild bool)
 	return b.helper.ShouldRebuild(cache, forceRebuild)
 }
 
+// Digest returns the digest of the pushed image that was found in the buildx output
+func (b *Builder) Digest() string {
+	if b.digestWriter == nil {
+		return ""
+	}
+
+	return b.digestWriter.Digest()
+}
+
 // BuildImage builds a dockerimage with the docker cli
 // contextPath is the absolute path to the context path
 // dockerfilePath is the absolute path to the dockerfile WITHIN the contextPath
@@ -107,7 +118,19 @@ func (b *Builder) BuildImage(contextPath, dockerfilePath string, entrypoint []st
 		buildKitConfig.SkipPush = b.skipPush
 	}
 
-	return buildWithCLI(body, writer, b.helper.KubeClient, builder, buildKitConfig, *buildOptions, useMinikubeDocker, log)
+	// buildx prints the digest of the pushed manifest, which we catch here
+	b.digestWriter = helper.NewDigestWriter(writer, b.helper.ImageName)
+	err = buildWithCLI(body, b.digestWriter, b.helper.KubeClient, builder, buildKitConfig, *buildOptions, useMinikubeDocker, log)
+	if err != nil {
+		return err
+	}
+
+	// Side-load the image into the nodes of a local cluster that does not share the docker daemon
+	if buildKitConfig.SkipPush && builder == "" && b.helper.KubeClient != nil && b.helper.KubeClient.IsLocalKubernetes() {
+		return helper.LoadImages(b.helper.KubeClient.CurrentContext(), buildOptions.Tags, useMinikubeDocker, log)
+	}
+
+	return nil
 }
 
 func buildWithCLI(context io.Reader, writer io.Writer, kubeClient kubectl.Client, builder string, imageConf *latest.BuildKitConfig, options types.ImageBuildOptions, useMinikubeDocker bool, log logpkg.Logger) error {
@@ -0,0 +1,172 @@
+package buildpacks
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/loft-sh/devspace/pkg/devspace/build/builder"
+	"github.com/loft-sh/devspace/pkg/devspace/build/builder/docker"
+	"github.com/loft-sh/devspace/pkg/devspace/build/builder/helper"
+	"github.com/loft-sh/devspace/pkg/devspace/config"
+	"github.com/loft-sh/devspace/pkg/devspace/config/generated"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	dockerclient "github.com/loft-sh/devspace/pkg/devspace/docker"
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
+	"github.com/loft-sh/devspace/pkg/util/command"
+	logpkg "github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/loft-sh/devspace/pkg/util/ptr"
+
+	dockerterm "github.com/moby/term"
+	"github.com/pkg/errors"
+)
+
+// EngineName is the name of the building engine
+const EngineName = "buildpacks"
+
+// DefaultBuilder is the builder image that is used if none is configured
+const DefaultBuilder = "paketobuildpacks/builder:base"
+
+// launcherPath is the entrypoint of images built with buildpacks that starts the default process
+const launcherPath = "/cnb/lifecycle/launcher"
+
+var (
+	_, stdout, _ = dockerterm.StdStreams()
+)
+
+// Builder holds the necessary information to build images with cloud native buildpacks
+type Builder struct {
+	helper *helper.BuildHelper
+
+	// dockerBuilder finishes the image built by pack (entrypoint overrides, restart helper, verify & push)
+	dockerBuilder *docker.Builder
+}
+
+// NewBuilder creates a new buildpacks Builder instance
+func NewBuilder(config config.Config, client dockerclient.Client, kubeClient kubectl.Client, imageConfigName string, imageConf *latest.ImageConfig, imageTags []string, skipPush, skipPushOnLocalKubernetes bool) (*Builder, error) {
+	// The docker builder uses the docker daemon pack builds the image with
+	dockerBuilder, err := docker.NewBuilder(config, client, kubeClient, imageConfigName, &latest.ImageConfig{
+		Image:                        imageConf.Image,
+		Tags:                         imageConf.Tags,
+		Entrypoint:                   imageConf.Entrypoint,
+		Cmd:                          imageConf.Cmd,
+		InjectRestartHelper:          imageConf.InjectRestartHelper,
+		RestartHelperPath:            imageConf.RestartHelperPath,
+		AppendDockerfileInstructions: imageConf.AppendDockerfileInstructions,
+		Build: &latest.BuildConfig{
+			Docker: &latest.DockerConfig{
+				PreferMinikube: ptr.Bool(false),
+				SkipPush:       imageConf.Build.Buildpacks.SkipPush,
+			},
+		},
+	}, imageTags, skipPush, skipPushOnLocalKubernetes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Builder{
+		helper:        helper.NewBuildHelper(config, kubeClient, EngineName, imageConfigName, imageConf, imageTags),
+		dockerBuilder: dockerBuilder,
+	}, nil
+}
+
+// Build implements the interface
+func (b *Builder) Build(log logpkg.Logger) error {
+	return b.helper.Build(b, log)
+}
+
+// ShouldRebuild determines if an image has to be rebuilt
+func (b *Builder) ShouldRebuild(cache *generated.CacheConfig, forceRebuild bool) (bool, error) {
+	return b.helper.ShouldRebuildSource(cache, forceRebuild)
+}
+
+// Digest returns the digest of the pushed image
+func (b *Builder) Digest() string {
+	return b.dockerBuilder.Digest()
+}
+
+// SetVerifyFunc sets the function that verifies the image before it is pushed
+func (b *Builder) SetVerifyFunc(verify builder.VerifyFunc) {
+	b.dockerBuilder.SetVerifyFunc(verify)
+}
+
+// BuildImage builds the image with the pack cli into the local docker daemon and then lets
+// the docker builder apply the entrypoint overrides and push the image
+func (b *Builder) BuildImage(contextPath, dockerfilePath string, entrypoint []string, cmd []string, log logpkg.Logger) error {
+	image := b.helper.ImageName + ":" + b.helper.ImageTags[0]
+
+	// Determine output writer
+	var writer io.Writer
+	if log == logpkg.GetInstance() {
+		writer = stdout
+	} else {
+		writer = log
+	}
+
+	packCommand, args := getPackCommand(b.helper.ImageConf.Build.Buildpacks, image, contextPath)
+	log.Infof("Build %s with '%s %s'", image, packCommand, strings.Join(args, " "))
+	err := command.NewStreamCommand(packCommand, args).Run(writer, writer, nil)
+	if err != nil {
+		return errors.Errorf("error building image with buildpacks: %v", err)
+	}
+
+	// Create a Dockerfile that is based on the built image, the docker builder rewrites it if needed
+	tempDir, err := ioutil.TempDir("", "devspace-buildpacks")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tempDir)
+
+	tempDockerfile := filepath.Join(tempDir, "Dockerfile")
+	err = ioutil.WriteFile(tempDockerfile, []byte(getDockerfile(image, entrypoint, b.helper.ImageConf.InjectRestartHelper)), 0666)
+	if err != nil {
+		return err
+	}
+
+	return b.dockerBuilder.BuildImage(tempDir, tempDockerfile, entrypoint, cmd, log)
+}
+
+// getPackCommand returns the command and the arguments to build the image with pack
+func getPackCommand(buildpacksConfig *latest.BuildpacksConfig, image, contextPath string) (string, []string) {
+	commandParts := []string{"pack"}
+	if len(buildpacksConfig.Command) > 0 {
+		commandParts = buildpacksConfig.Command
+	}
+
+	builderImage := DefaultBuilder
+	if buildpacksConfig.Builder != "" {
+		builderImage = buildpacksConfig.Builder
+	}
+
+	args := append([]string{}, commandParts[1:]...)
+	args = append(args, "build", image, "--path", contextPath, "--builder", builderImage)
+	for _, buildpack := range buildpacksConfig.Buildpacks {
+		args = append(args, "--buildpack", buildpack)
+	}
+
+	envKeys := []string{}
+	for key := range buildpacksConfig.Env {
+		envKeys = append(envKeys, key)
+	}
+	sort.Strings(envKeys)
+	for _, key := range envKeys {
+		args = append(args, "--env", key+"="+buildpacksConfig.Env[key])
+	}
+
+	args = append(args, buildpacksConfig.Args...)
+	return commandParts[0], args
+}
+
+// getDockerfile returns the Dockerfile that is used to finish the built image. If the restart helper
+// should be injected without an entrypoint override, the buildpacks launcher is wrapped
+func getDockerfile(image string, entrypoint []string, injectRestartHelper bool) string {
+	dockerfile := "FROM " + image + "\n"
+	if injectRestartHelper && len(entrypoint) == 0 {
+		dockerfile += "ENTRYPOINT [\"" + launcherPath + "\"]\n"
+	}
+
+	return dockerfile
+}
@@ -0,0 +1,66 @@
+package buildpacks
+
+import (
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"gotest.tools/assert"
+)
+
+type getPackCommandTestCase struct {
+	name string
+
+	buildpacksConfig *latest.BuildpacksConfig
+	image            string
+	contextPath      string
+
+	expectedCommand string
+	expectedArgs    []string
+}
+
+func TestGetPackCommand(t *testing.T) {
+	testCases := []getPackCommandTestCase{
+		{
+			name:             "Defaults",
+			buildpacksConfig: &latest.BuildpacksConfig{},
+			image:            "myimage:tag",
+			contextPath:      "/app",
+			expectedCommand:  "pack",
+			expectedArgs:     []string{"build", "myimage:tag", "--path", "/app", "--builder", DefaultBuilder},
+		},
+		{
+			name: "All options",
+			buildpacksConfig: &latest.BuildpacksConfig{
+				Builder:    "gcr.io/buildpacks/builder:v1",
+				Buildpacks: []string{"paketo-buildpacks/go", "paketo-buildpacks/procfile"},
+				Env: map[string]string{
+					"BP_GO_TARGETS": "./cmd/server",
+					"BP_ENV":        "dev",
+				},
+				Args:    []string{"--pull-policy", "if-not-present"},
+				Command: []string{"my-pack", "--verbose"},
+			},
+			image:           "myimage:tag",
+			contextPath:     "/app",
+			expectedCommand: "my-pack",
+			expectedArgs: []string{
+				"--verbose", "build", "myimage:tag", "--path", "/app", "--builder", "gcr.io/buildpacks/builder:v1",
+				"--buildpack", "paketo-buildpacks/go", "--buildpack", "paketo-buildpacks/procfile",
+				"--env", "BP_ENV=dev", "--env", "BP_GO_TARGETS=./cmd/server",
+				"--pull-policy", "if-not-present",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		command, args := getPackCommand(testCase.buildpacksConfig, testCase.image, testCase.contextPath)
+		assert.Equal(t, command, testCase.expectedCommand, "Unexpected command in testCase %s", testCase.name)
+		assert.DeepEqual(t, args, testCase.expectedArgs)
+	}
+}
+
+func TestGetDockerfile(t *testing.T) {
+	assert.Equal(t, getDockerfile("myimage:tag", nil, false), "FROM myimage:tag\n")
+	assert.Equal(t, getDockerfile("myimage:tag", []string{"/app/server"}, true), "FROM myimage:tag\n")
+	assert.Equal(t, getDockerfile("myimage:tag", nil, true), "FROM myimage:tag\nENTRYPOINT [\""+launcherPath+"\"]\n")
+}
@@ -13,6 +13,7 @@ import (
 
 	"github.com/docker/cli/cli/streams"
 
+	"github.com/loft-sh/devspace/pkg/devspace/build/builder"
 	"github.com/loft-sh/devspace/pkg/devspace/build/builder/helper"
 	"github.com/loft-sh/devspace/pkg/devspace/config/generated"
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
@@ -51,6 +52,9 @@ type Builder struct {
 	client                    dockerclient.Client
 	skipPush                  bool
 	skipPushOnLocalKubernetes bool
+
+	digest     string
+	verifyFunc builder.VerifyFunc
 }
 
 // NewBuilder creates a new docker Builder instance
@@ -73,6 +77,25 @@ func (b *Builder) ShouldRebuild(cache *generated.CacheConfig, forceRebuild bool)
 	return b.helper.ShouldRebuild(cache, forceRebuild)
 }
 
+// Digest returns the digest of the pushed image
+func (b *Builder) Digest() string {
+	return b.digest
+}
+
+// SetVerifyFunc sets the function that verifies the image before it is pushed
+func (b *Builder) SetVerifyFunc(verify builder.VerifyFunc) {
+	b.verifyFunc = verify
+}
+
+// preferMinikube returns true if the image is built with the docker daemon of minikube
+func (b *Builder) preferMinikube() bool {
+	if b.helper.ImageConf.Build != nil && b.helper.ImageConf.Build.Docker != nil && b.helper.ImageConf.Build.Docker.PreferMinikube != nil {
+		return *b.helper.ImageConf.Build.Docker.PreferMinikube
+	}
+
+	return true
+}
+
 // BuildImage builds a dockerimage with the docker cli
 // contextPath is the absolute path to the context path
 // dockerfilePath is the absolute path to the dockerfile WITHIN the contextPath
@@ -158,6 +181,19 @@ func (b *Builder) BuildImage(contextPath, dockerfilePath string, entrypoint []st
 		}
 	}
 
+	// Verify the image before pushing it
+	if b.verifyFunc != nil {
+		imageInspect, _, err := b.client.ImageInspectWithRaw(context.Background(), buildOptions.Tags[0])
+		if err != nil {
+			return errors.Errorf("error inspecting image: %v", err)
+		}
+
+		err = b.verifyFunc(imageInspect.Config, log)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Check if we skip push
 	if b.skipPush == false && (b.helper.ImageConf.Build == nil || b.helper.ImageConf.Build.Docker == nil || b.helper.ImageConf.Build.Docker.SkipPush == false) {
 		for _, tag := range buildOptions.Tags {
@@ -170,6 +206,14 @@ func (b *Builder) BuildImage(contextPath, dockerfilePath string, entrypoint []st
 		}
 	} else {
 		log.Infof("Skip image push for %s", b.helper.ImageName)
+
+		// Side-load the image into the nodes of a local cluster that does not share the docker daemon
+		if b.helper.KubeClient != nil && b.helper.KubeClient.IsLocalKubernetes() {
+			err = helper.LoadImages(b.helper.KubeClient.CurrentContext(), buildOptions.Tags, b.preferMinikube(), log)
+			if err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil
@@ -210,7 +254,16 @@ func (b *Builder) pushImage(writer io.Writer, imageName string) error {
 	}
 
 	outStream := streams.NewOut(writer)
-	err = jsonmessage.DisplayJSONMessagesStream(out, outStream, outStream.FD(), outStream.IsTerminal(), nil)
+	err = jsonmessage.DisplayJSONMessagesStream(out, outStream, outStream.FD(), outStream.IsTerminal(), func(message jsonmessage.JSONMessage) {
+		if message.Aux == nil {
+			return
+		}
+
+		pushResult := types.PushResult{}
+		if json.Unmarshal(*message.Aux, &pushResult) == nil && pushResult.Digest != "" {
+			b.digest = pushResult.Digest
+		}
+	})
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,76 @@
+package helper
+
+import (
+	"bytes"
+	"io"
+	"regexp"
+	"sync"
+
+	"github.com/loft-sh/devspace/pkg/util/imageselector"
+)
+
+var digestRegEx = regexp.MustCompile(`([^\s@"']+)@(sha256:[a-f0-9]{64})`)
+
+// DigestWriter is a writer that passes all output to the underlying writer and
+// searches the output of a build tool for the digest of a pushed image
+type DigestWriter struct {
+	out       io.Writer
+	imageName string
+
+	digest  string
+	partial []byte
+	m       sync.Mutex
+}
+
+// NewDigestWriter creates a new digest writer that searches for pushed digests of the given image name
+func NewDigestWriter(out io.Writer, imageName string) *DigestWriter {
+	strippedImageName, _, err := imageselector.GetStrippedDockerImageName(imageName)
+	if err != nil {
+		strippedImageName = imageName
+	}
+
+	return &DigestWriter{
+		out:       out,
+		imageName: strippedImageName,
+	}
+}
+
+// Write implements the io.Writer interface
+func (d *DigestWriter) Write(p []byte) (int, error) {
+	d.m.Lock()
+	data := append(d.partial, p...)
+	lastNewline := bytes.LastIndexAny(data, "\r\n")
+	if lastNewline == -1 {
+		d.partial = data
+	} else {
+		d.parse(data[:lastNewline])
+		d.partial = append([]byte{}, data[lastNewline+1:]...)
+	}
+	d.m.Unlock()
+
+	return d.out.Write(p)
+}
+
+// Digest returns the last digest found in the output for the image
+func (d *DigestWriter) Digest() string {
+	d.m.Lock()
+	defer d.m.Unlock()
+
+	if len(d.partial) > 0 {
+		d.parse(d.partial)
+		d.partial = nil
+	}
+
+	return d.digest
+}
+
+func (d *DigestWriter) parse(out []byte) {
+	for _, match := range digestRegEx.FindAllSubmatch(out, -1) {
+		strippedImageName, _, err := imageselector.GetStrippedDockerImageName(string(match[1]))
+		if err != nil || strippedImageName != d.imageName {
+			continue
+		}
+
+		d.digest = string(match[2])
+	}
+}
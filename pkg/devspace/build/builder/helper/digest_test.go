@@ -0,0 +1,32 @@
+package helper
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestDigestWriter(t *testing.T) {
+	digest := "sha256:" + strings.Repeat("a", 64)
+	cacheDigest := "sha256:" + strings.Repeat("b", 64)
+
+	out := &bytes.Buffer{}
+	writer := NewDigestWriter(out, "myuser/myimage")
+
+	// the digest of the cache repository should be ignored
+	_, err := writer.Write([]byte("INFO[0010] Pushed index.docker.io/myuser/myimage-cache@" + cacheDigest + "\n"))
+	assert.NilError(t, err)
+	assert.Equal(t, writer.Digest(), "")
+
+	// lines can be split across multiple writes
+	_, err = writer.Write([]byte("INFO[0020] Pushed index.docker.io/myuser/myi"))
+	assert.NilError(t, err)
+	_, err = writer.Write([]byte("mage@" + digest + "\nINFO[0021] done"))
+	assert.NilError(t, err)
+	assert.Equal(t, writer.Digest(), digest)
+
+	// everything is passed to the underlying writer
+	assert.Equal(t, strings.Count(out.String(), "\n"), 2)
+}
@@ -131,20 +131,7 @@ func (b *BuildHelper) ShouldRebuild(cache *generated.CacheConfig, forceRebuild b
 	imageConfigHash := hash.String(string(configStr))
 
 	// Hash entrypoint
-	entrypointHash := ""
-	if len(b.Entrypoint) > 0 {
-		for _, str := range b.Entrypoint {
-			entrypointHash += str
-		}
-	}
-	if len(b.Cmd) > 0 {
-		for _, str := range b.Cmd {
-			entrypointHash += str
-		}
-	}
-	if entrypointHash != "" {
-		entrypointHash = hash.String(entrypointHash)
-	}
+	entrypointHash := b.hashEntrypoint()
 
 	// only rebuild Docker image when Dockerfile or context has changed since latest build
 	mustRebuild := imageCache.Tag == "" || imageCache.DockerfileHash != dockerfileHash || imageCache.ImageConfigHash != imageConfigHash || imageCache.EntrypointHash != entrypointHash
@@ -194,3 +181,68 @@ func (b *BuildHelper) ShouldRebuild(cache *generated.CacheConfig, forceRebuild b
 
 	return mustRebuild, nil
 }
+
+// ShouldRebuildSource determines if an image that is built without a Dockerfile (e.g. with buildpacks)
+// should be rebuilt. Instead of the Dockerfile, the source directory (the context path) is hashed
+func (b *BuildHelper) ShouldRebuildSource(cache *generated.CacheConfig, forceRebuild bool) (bool, error) {
+	// if rebuild strategy is always, we return here
+	if b.ImageConf.RebuildStrategy == latest.RebuildStrategyAlways {
+		return true, nil
+	}
+
+	imageCache := cache.GetImageCache(b.ImageConfigName)
+
+	// Hash image config
+	configStr, err := yaml.Marshal(*b.ImageConf)
+	if err != nil {
+		return false, errors.Wrap(err, "marshal image config")
+	}
+
+	imageConfigHash := hash.String(string(configStr))
+	entrypointHash := b.hashEntrypoint()
+
+	// Hash the source directory
+	excludes, err := ReadDockerignore(b.ContextPath, "Dockerfile")
+	if err != nil {
+		return false, errors.Errorf("Error reading .dockerignore: %v", err)
+	}
+
+	contextHash, err := hash.DirectoryExcludes(b.ContextPath, excludes, false)
+	if err != nil {
+		return false, errors.Errorf("Error hashing %s: %v", b.ContextPath, err)
+	}
+
+	mustRebuild := imageCache.Tag == "" || imageCache.ContextHash != contextHash || imageCache.ImageConfigHash != imageConfigHash || imageCache.EntrypointHash != entrypointHash
+
+	// Rebuild if the previous context was a local kubernetes context where we probably didn't push the image
+	if b.KubeClient != nil && cache.LastContext != nil && cache.LastContext.Context != b.KubeClient.CurrentContext() && kubectl.IsLocalKubernetes(cache.LastContext.Context) {
+		mustRebuild = true
+	}
+
+	if forceRebuild || mustRebuild {
+		imageCache.ContextHash = contextHash
+		imageCache.ImageConfigHash = imageConfigHash
+		imageCache.EntrypointHash = entrypointHash
+	}
+
+	return mustRebuild, nil
+}
+
+func (b *BuildHelper) hashEntrypoint() string {
+	entrypointHash := ""
+	if len(b.Entrypoint) > 0 {
+		for _, str := range b.Entrypoint {
+			entrypointHash += str
+		}
+	}
+	if len(b.Cmd) > 0 {
+		for _, str := range b.Cmd {
+			entrypointHash += str
+		}
+	}
+	if entrypointHash != "" {
+		entrypointHash = hash.String(entrypointHash)
+	}
+
+	return entrypointHash
+}
@@ -0,0 +1,108 @@
+package helper
+
+import (
+	"io"
+	"strings"
+
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
+	"github.com/loft-sh/devspace/pkg/util/command"
+	logpkg "github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/pkg/errors"
+)
+
+// loadCommand is a command that loads images from the local docker daemon into the nodes of a local cluster
+type loadCommand struct {
+	// SaveImages signals that the images should be exported with docker save and piped into the command
+	SaveImages bool
+
+	Command string
+	Args    []string
+}
+
+// getLoadCommands returns the commands that are needed to load the images into the cluster the kube context belongs to.
+// If the cluster shares the local docker daemon, no commands are returned
+func getLoadCommands(kubeContext string, images []string, useMinikubeDocker bool) []*loadCommand {
+	if kubectl.IsKindContext(kubeContext) {
+		return []*loadCommand{
+			{
+				Command: "kind",
+				Args:    append(append([]string{"load", "docker-image"}, images...), "--name", kubectl.GetLocalClusterName(kubeContext)),
+			},
+		}
+	} else if kubectl.IsK3dContext(kubeContext) {
+		return []*loadCommand{
+			{
+				Command: "k3d",
+				Args:    append(append([]string{"image", "import"}, images...), "--cluster", kubectl.GetLocalClusterName(kubeContext)),
+			},
+		}
+	} else if kubectl.IsMicrok8sContext(kubeContext) {
+		return []*loadCommand{
+			{
+				SaveImages: true,
+				Command:    "microk8s",
+				Args:       []string{"ctr", "image", "import", "-"},
+			},
+		}
+	} else if kubectl.IsRancherDesktopContext(kubeContext) {
+		return []*loadCommand{
+			{
+				SaveImages: true,
+				Command:    "nerdctl",
+				Args:       []string{"--namespace", "k8s.io", "load"},
+			},
+		}
+	} else if kubectl.IsMinikubeContext(kubeContext) && !useMinikubeDocker {
+		commands := []*loadCommand{}
+		for _, image := range images {
+			commands = append(commands, &loadCommand{
+				Command: "minikube",
+				Args:    []string{"image", "load", image},
+			})
+		}
+
+		return commands
+	}
+
+	return nil
+}
+
+// LoadImages side-loads the images from the local docker daemon into the nodes of the local kubernetes cluster
+// the kube context belongs to. For clusters that share the local docker daemon (e.g. docker-desktop) this does nothing
+func LoadImages(kubeContext string, images []string, useMinikubeDocker bool, log logpkg.Logger) error {
+	commands := getLoadCommands(kubeContext, images, useMinikubeDocker)
+	if len(commands) == 0 {
+		return nil
+	}
+
+	log.Infof("Load images %s into local cluster (%s)", strings.Join(images, ", "), kubeContext)
+	for _, loadCommand := range commands {
+		err := runLoadCommand(loadCommand, images, log)
+		if err != nil {
+			return errors.Errorf("error loading images into local cluster with '%s %s': %v", loadCommand.Command, strings.Join(loadCommand.Args, " "), err)
+		}
+	}
+
+	log.Donef("Loaded images %s into local cluster (%s)", strings.Join(images, ", "), kubeContext)
+	return nil
+}
+
+func runLoadCommand(loadCommand *loadCommand, images []string, log logpkg.Logger) error {
+	if loadCommand.SaveImages == false {
+		return command.NewStreamCommand(loadCommand.Command, loadCommand.Args).Run(log, log, nil)
+	}
+
+	reader, writer := io.Pipe()
+	defer reader.Close()
+
+	go func() {
+		err := command.NewStreamCommand("docker", append([]string{"save"}, images...)).Run(writer, log, nil)
+		if err != nil {
+			err = errors.Wrap(err, "docker save")
+		}
+
+		_ = writer.CloseWithError(err)
+	}()
+
+	return command.NewStreamCommand(loadCommand.Command, loadCommand.Args).Run(log, log, reader)
+}
@@ -0,0 +1,107 @@
+package helper
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+type getLoadCommandsTestCase struct {
+	name string
+
+	kubeContext       string
+	images            []string
+	useMinikubeDocker bool
+
+	expectedCommands []*loadCommand
+}
+
+func TestGetLoadCommands(t *testing.T) {
+	testCases := []getLoadCommandsTestCase{
+		{
+			name:        "Docker desktop",
+			kubeContext: "docker-desktop",
+			images:      []string{"myimage:tag"},
+		},
+		{
+			name:        "Kind",
+			kubeContext: "kind-dev",
+			images:      []string{"myimage:tag", "myimage:latest"},
+			expectedCommands: []*loadCommand{
+				{
+					Command: "kind",
+					Args:    []string{"load", "docker-image", "myimage:tag", "myimage:latest", "--name", "dev"},
+				},
+			},
+		},
+		{
+			name:        "K3d",
+			kubeContext: "k3d-dev",
+			images:      []string{"myimage:tag"},
+			expectedCommands: []*loadCommand{
+				{
+					Command: "k3d",
+					Args:    []string{"image", "import", "myimage:tag", "--cluster", "dev"},
+				},
+			},
+		},
+		{
+			name:        "Microk8s",
+			kubeContext: "microk8s",
+			images:      []string{"myimage:tag"},
+			expectedCommands: []*loadCommand{
+				{
+					SaveImages: true,
+					Command:    "microk8s",
+					Args:       []string{"ctr", "image", "import", "-"},
+				},
+			},
+		},
+		{
+			name:        "Rancher desktop",
+			kubeContext: "rancher-desktop",
+			images:      []string{"myimage:tag"},
+			expectedCommands: []*loadCommand{
+				{
+					SaveImages: true,
+					Command:    "nerdctl",
+					Args:       []string{"--namespace", "k8s.io", "load"},
+				},
+			},
+		},
+		{
+			name:              "Minikube docker daemon",
+			kubeContext:       "minikube",
+			images:            []string{"myimage:tag"},
+			useMinikubeDocker: true,
+		},
+		{
+			name:        "Minikube without docker daemon",
+			kubeContext: "minikube",
+			images:      []string{"myimage:tag", "myimage:latest"},
+			expectedCommands: []*loadCommand{
+				{
+					Command: "minikube",
+					Args:    []string{"image", "load", "myimage:tag"},
+				},
+				{
+					Command: "minikube",
+					Args:    []string{"image", "load", "myimage:latest"},
+				},
+			},
+		},
+		{
+			name:        "Remote cluster",
+			kubeContext: "kind-",
+			images:      []string{"myimage:tag"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		commands := getLoadCommands(testCase.kubeContext, testCase.images, testCase.useMinikubeDocker)
+		assert.Equal(t, len(commands), len(testCase.expectedCommands), "Unexpected number of commands in testCase %s", testCase.name)
+		for i, command := range commands {
+			assert.DeepEqual(t, command, testCase.expectedCommands[i])
+		}
+	}
+}
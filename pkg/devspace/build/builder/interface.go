@@ -1,6 +1,7 @@
 package builder
 
 import (
+	"github.com/docker/docker/api/types/container"
 	"github.com/loft-sh/devspace/pkg/devspace/config/generated"
 	"github.com/loft-sh/devspace/pkg/util/log"
 )
@@ -10,3 +11,19 @@ type Interface interface {
 	ShouldRebuild(cache *generated.CacheConfig, forceRebuild bool) (bool, error)
 	Build(log log.Logger) error
 }
+
+// DigestReporter is implemented by builders that are able to tell the registry digest
+// of the image they have pushed
+type DigestReporter interface {
+	// Digest returns the digest of the pushed image or an empty string if unknown
+	Digest() string
+}
+
+// VerifyFunc verifies a built image before it is pushed
+type VerifyFunc func(imageConfig *container.Config, log log.Logger) error
+
+// Verifier is implemented by builders that are able to verify the image after building and before pushing it
+type Verifier interface {
+	// SetVerifyFunc sets the function that is called before the image is pushed
+	SetVerifyFunc(verify VerifyFunc)
+}
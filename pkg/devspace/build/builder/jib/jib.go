@@ -0,0 +1,214 @@
+package jib
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/loft-sh/devspace/pkg/devspace/build/builder/helper"
+	"github.com/loft-sh/devspace/pkg/devspace/config"
+	"github.com/loft-sh/devspace/pkg/devspace/config/generated"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
+	logpkg "github.com/loft-sh/devspace/pkg/util/log"
+
+	dockerterm "github.com/moby/term"
+	"github.com/pkg/errors"
+)
+
+// EngineName is the name of the building engine
+const EngineName = "jib"
+
+// mavenPlugin is the fully qualified jib maven plugin, which makes sure the plugin does not need to be configured in the pom.xml
+const mavenPlugin = "com.google.cloud.tools:jib-maven-plugin"
+
+// digestFile is the file jib writes the digest of the built image to
+const digestFile = "jib-image.digest"
+
+var (
+	_, stdout, _ = dockerterm.StdStreams()
+)
+
+// Builder holds the necessary information to build java images with jib
+type Builder struct {
+	helper *helper.BuildHelper
+
+	skipPush                  bool
+	skipPushOnLocalKubernetes bool
+
+	digest string
+}
+
+// NewBuilder creates a new jib Builder instance
+func NewBuilder(config config.Config, kubeClient kubectl.Client, imageConfigName string, imageConf *latest.ImageConfig, imageTags []string, skipPush, skipPushOnLocalKubernetes bool) *Builder {
+	return &Builder{
+		helper:                    helper.NewBuildHelper(config, kubeClient, EngineName, imageConfigName, imageConf, imageTags),
+		skipPush:                  skipPush,
+		skipPushOnLocalKubernetes: skipPushOnLocalKubernetes,
+	}
+}
+
+// Build implements the interface
+func (b *Builder) Build(log logpkg.Logger) error {
+	return b.helper.Build(b, log)
+}
+
+// ShouldRebuild determines if an image has to be rebuilt
+func (b *Builder) ShouldRebuild(cache *generated.CacheConfig, forceRebuild bool) (bool, error) {
+	return b.helper.ShouldRebuildSource(cache, forceRebuild)
+}
+
+// Digest returns the digest of the pushed image
+func (b *Builder) Digest() string {
+	return b.digest
+}
+
+// BuildImage builds the java application in the context path with jib and pushes the image. If pushing is skipped,
+// the image is built into the local docker daemon instead
+func (b *Builder) BuildImage(contextPath, dockerfilePath string, entrypoint []string, cmd []string, log logpkg.Logger) error {
+	if b.helper.ImageConf.InjectRestartHelper || len(b.helper.ImageConf.AppendDockerfileInstructions) > 0 {
+		log.Warnf("injectRestartHelper and appendDockerfileInstructions are not supported for images built with jib and will be ignored")
+	}
+
+	// We skip pushing when it is a local kubernetes cluster
+	jibConfig := b.helper.ImageConf.Build.Jib
+	if b.skipPushOnLocalKubernetes && b.helper.KubeClient != nil && b.helper.KubeClient.IsLocalKubernetes() {
+		b.skipPush = true
+	}
+	skipPush := b.skipPush || jibConfig.SkipPush
+
+	// Determine output writer
+	var writer io.Writer
+	if log == logpkg.GetInstance() {
+		writer = stdout
+	} else {
+		writer = log
+	}
+
+	images := []string{}
+	for _, tag := range b.helper.ImageTags {
+		images = append(images, b.helper.ImageName+":"+tag)
+	}
+
+	jibType := getType(jibConfig, contextPath)
+	jibCommand, args := getJibCommand(jibConfig, jibType, contextPath, b.helper.ImageName, b.helper.ImageTags, entrypoint, cmd, skipPush)
+	log.Infof("Build %s with '%s %s'", images[0], jibCommand, strings.Join(args, " "))
+
+	cmdExec := exec.Command(jibCommand, args...)
+	cmdExec.Dir = contextPath
+	cmdExec.Stdout = writer
+	cmdExec.Stderr = writer
+	err := cmdExec.Run()
+	if err != nil {
+		return errors.Errorf("error building image with jib: %v", err)
+	}
+
+	if skipPush {
+		// Side-load the image into the nodes of a local cluster that does not share the docker daemon
+		if b.helper.KubeClient != nil && b.helper.KubeClient.IsLocalKubernetes() {
+			return helper.LoadImages(b.helper.KubeClient.CurrentContext(), images, false, log)
+		}
+
+		return nil
+	}
+
+	// jib writes the digest of the pushed image into the build output directory
+	digest, err := ioutil.ReadFile(getDigestPath(jibConfig, jibType, contextPath))
+	if err != nil {
+		log.Warnf("Couldn't read digest of image %s: %v", images[0], err)
+	} else {
+		b.digest = strings.TrimSpace(string(digest))
+	}
+
+	return nil
+}
+
+// getType returns the build tool jib is used with
+func getType(jibConfig *latest.JibConfig, contextPath string) latest.JibType {
+	if jibConfig.Type != "" {
+		return jibConfig.Type
+	}
+
+	_, err := os.Stat(filepath.Join(contextPath, "pom.xml"))
+	if err == nil {
+		return latest.JibTypeMaven
+	}
+
+	return latest.JibTypeGradle
+}
+
+// getJibCommand returns the command and the arguments to build the image with jib
+func getJibCommand(jibConfig *latest.JibConfig, jibType latest.JibType, contextPath string, imageName string, imageTags []string, entrypoint []string, cmd []string, skipPush bool) (string, []string) {
+	commandParts := jibConfig.Command
+	if len(commandParts) == 0 {
+		commandParts = []string{getDefaultCommand(jibType, contextPath)}
+	}
+
+	args := append([]string{}, commandParts[1:]...)
+	if jibType == latest.JibTypeMaven {
+		if jibConfig.Project != "" {
+			args = append(args, "--projects", jibConfig.Project, "--also-make")
+		}
+
+		goal := "build"
+		if skipPush {
+			goal = "dockerBuild"
+		}
+
+		args = append(args, "compile", mavenPlugin+":"+goal)
+	} else {
+		task := "jib"
+		if skipPush {
+			task = "jibDockerBuild"
+		}
+		if jibConfig.Project != "" {
+			task = ":" + jibConfig.Project + ":" + task
+		}
+
+		args = append(args, task)
+	}
+
+	args = append(args, "-Djib.to.image="+imageName+":"+imageTags[0])
+	if len(imageTags) > 1 {
+		args = append(args, "-Djib.to.tags="+strings.Join(imageTags[1:], ","))
+	}
+	if len(entrypoint) > 0 {
+		args = append(args, "-Djib.container.entrypoint="+strings.Join(entrypoint, ","))
+	}
+	if len(cmd) > 0 {
+		args = append(args, "-Djib.container.args="+strings.Join(cmd, ","))
+	}
+
+	args = append(args, jibConfig.Args...)
+	return commandParts[0], args
+}
+
+// getDefaultCommand returns the maven or gradle wrapper if it exists in the context and mvn or gradle otherwise
+func getDefaultCommand(jibType latest.JibType, contextPath string) string {
+	command := "gradle"
+	wrapper := "gradlew"
+	if jibType == latest.JibTypeMaven {
+		command = "mvn"
+		wrapper = "mvnw"
+	}
+
+	_, err := os.Stat(filepath.Join(contextPath, wrapper))
+	if err == nil {
+		return "./" + wrapper
+	}
+
+	return command
+}
+
+// getDigestPath returns the path of the file jib writes the image digest to
+func getDigestPath(jibConfig *latest.JibConfig, jibType latest.JibType, contextPath string) string {
+	projectPath := filepath.Join(contextPath, filepath.FromSlash(strings.ReplaceAll(jibConfig.Project, ":", "/")))
+	if jibType == latest.JibTypeMaven {
+		return filepath.Join(projectPath, "target", digestFile)
+	}
+
+	return filepath.Join(projectPath, "build", digestFile)
+}
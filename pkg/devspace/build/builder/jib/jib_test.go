@@ -0,0 +1,72 @@
+package jib
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"gotest.tools/assert"
+)
+
+type getJibCommandTestCase struct {
+	name string
+
+	jibConfig  *latest.JibConfig
+	jibType    latest.JibType
+	imageTags  []string
+	entrypoint []string
+	cmd        []string
+	skipPush   bool
+
+	expectedCommand string
+	expectedArgs    []string
+}
+
+func TestGetJibCommand(t *testing.T) {
+	testCases := []getJibCommandTestCase{
+		{
+			name:            "Maven",
+			jibConfig:       &latest.JibConfig{},
+			jibType:         latest.JibTypeMaven,
+			imageTags:       []string{"tag1", "tag2"},
+			expectedCommand: "mvn",
+			expectedArgs:    []string{"compile", mavenPlugin + ":build", "-Djib.to.image=myimage:tag1", "-Djib.to.tags=tag2"},
+		},
+		{
+			name: "Maven module without push",
+			jibConfig: &latest.JibConfig{
+				Project: "services/api",
+				Args:    []string{"-DskipTests"},
+			},
+			jibType:         latest.JibTypeMaven,
+			imageTags:       []string{"tag1"},
+			entrypoint:      []string{"java", "-jar", "/app.jar"},
+			cmd:             []string{"--debug"},
+			skipPush:        true,
+			expectedCommand: "mvn",
+			expectedArgs:    []string{"--projects", "services/api", "--also-make", "compile", mavenPlugin + ":dockerBuild", "-Djib.to.image=myimage:tag1", "-Djib.container.entrypoint=java,-jar,/app.jar", "-Djib.container.args=--debug", "-DskipTests"},
+		},
+		{
+			name: "Gradle project",
+			jibConfig: &latest.JibConfig{
+				Project: "api",
+				Command: []string{"gradle", "--no-daemon"},
+			},
+			jibType:         latest.JibTypeGradle,
+			imageTags:       []string{"tag1"},
+			expectedCommand: "gradle",
+			expectedArgs:    []string{"--no-daemon", ":api:jib", "-Djib.to.image=myimage:tag1"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		command, args := getJibCommand(testCase.jibConfig, testCase.jibType, "/does/not/exist", "myimage", testCase.imageTags, testCase.entrypoint, testCase.cmd, testCase.skipPush)
+		assert.Equal(t, command, testCase.expectedCommand, "Unexpected command in testCase %s", testCase.name)
+		assert.DeepEqual(t, args, testCase.expectedArgs)
+	}
+}
+
+func TestGetDigestPath(t *testing.T) {
+	assert.Equal(t, getDigestPath(&latest.JibConfig{}, latest.JibTypeMaven, "/app"), filepath.Join("/app", "target", digestFile))
+	assert.Equal(t, getDigestPath(&latest.JibConfig{Project: "services:api"}, latest.JibTypeGradle, "/app"), filepath.Join("/app", "services", "api", "build", digestFile))
+}
@@ -52,16 +52,6 @@ var defaultResources = &availableResources{
 func (b *Builder) getBuildPod(buildID string, options *types.ImageBuildOptions, dockerfilePath string) (*k8sv1.Pod, error) {
 	kanikoOptions := b.helper.ImageConf.Build.Kaniko
 
-	registryURL, err := pullsecrets.GetRegistryFromImageName(b.FullImageName)
-	if err != nil {
-		return nil, err
-	}
-
-	pullSecretName := pullsecrets.GetRegistryAuthSecretName(registryURL)
-	if b.PullSecretName != "" {
-		pullSecretName = b.PullSecretName
-	}
-
 	kanikoImage := kanikoBuildImage
 	if kanikoOptions.Image != "" {
 		kanikoImage = kanikoOptions.Image
@@ -73,9 +63,123 @@ func (b *Builder) getBuildPod(buildID string, options *types.ImageBuildOptions,
 	}
 
 	// additional options to pass to kaniko
+	kanikoArgs, err := b.getKanikoArgs(options, kanikoContextPath, dockerfilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	// build the volumes
+	volumes := []k8sv1.Volume{
+		{
+			Name: "context",
+			VolumeSource: k8sv1.VolumeSource{
+				EmptyDir: &k8sv1.EmptyDirVolumeSource{},
+			},
+		},
+	}
+	volumeMounts := []k8sv1.VolumeMount{
+		{
+			Name:      "context",
+			MountPath: kanikoContextPath,
+		},
+	}
+	additionalVolumes, additionalVolumeMounts, err := b.getVolumes()
+	if err != nil {
+		return nil, err
+	}
+	volumes = append(volumes, additionalVolumes...)
+	volumeMounts = append(volumeMounts, additionalVolumeMounts...)
+
+	// create the build pod
+	pod := &k8sv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "devspace-build-kaniko-",
+			Annotations:  map[string]string{},
+			Labels: map[string]string{
+				"devspace-build":    "true",
+				"devspace-build-id": buildID,
+			},
+		},
+		Spec: k8sv1.PodSpec{
+			InitContainers: []k8sv1.Container{
+				{
+					Name:            "context",
+					Image:           kanikoInitImage,
+					Command:         []string{"sh"},
+					Args:            []string{"-c", "while [ ! -f " + doneFile + " ]; do sleep 2; done"},
+					ImagePullPolicy: k8sv1.PullIfNotPresent,
+					VolumeMounts: []k8sv1.VolumeMount{
+						{
+							Name:      "context",
+							MountPath: kanikoContextPath,
+						},
+					},
+				},
+			},
+			Containers: []k8sv1.Container{
+				{
+					Name:            "kaniko",
+					Image:           kanikoImage,
+					ImagePullPolicy: k8sv1.PullIfNotPresent,
+					Command:         kanikoOptions.Command,
+					Args:            kanikoArgs,
+					VolumeMounts:    volumeMounts,
+				},
+			},
+			NodeSelector:       kanikoOptions.NodeSelector,
+			ServiceAccountName: kanikoOptions.ServiceAccount,
+			Volumes:            volumes,
+			RestartPolicy:      k8sv1.RestartPolicyNever,
+		},
+	}
+
+	// add extra annotations
+	for k, v := range kanikoOptions.Annotations {
+		pod.Annotations[k] = v
+	}
+
+	// add extra labels
+	for k, v := range kanikoOptions.Labels {
+		pod.Labels[k] = v
+	}
+
+	// add extra init env vars
+	for k, v := range kanikoOptions.InitEnv {
+		if len(pod.Spec.InitContainers[0].Env) == 0 {
+			pod.Spec.InitContainers[0].Env = []k8sv1.EnvVar{}
+		}
+
+		pod.Spec.InitContainers[0].Env = append(pod.Spec.InitContainers[0].Env, k8sv1.EnvVar{
+			Name:  k,
+			Value: v,
+		})
+	}
+
+	// add extra env vars
+	pod.Spec.Containers[0].Env, err = b.getEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	// set the resources
+	resources, err := b.getResources()
+	if err != nil {
+		return nil, err
+	} else if resources != nil {
+		pod.Spec.InitContainers[0].Resources = *resources
+		pod.Spec.Containers[0].Resources = *resources
+	}
+
+	// return the build pod
+	return pod, nil
+}
+
+// getKanikoArgs returns the arguments for the kaniko executor for a build context located at contextPath
+func (b *Builder) getKanikoArgs(options *types.ImageBuildOptions, contextPath string, dockerfilePath string) ([]string, error) {
+	kanikoOptions := b.helper.ImageConf.Build.Kaniko
 	kanikoArgs := []string{
-		"--dockerfile=" + kanikoContextPath + "/" + filepath.Base(dockerfilePath),
-		"--context=dir://" + kanikoContextPath,
+		"--dockerfile=" + contextPath + "/" + filepath.Base(dockerfilePath),
+		"--context=dir://" + contextPath,
 	}
 
 	// specify destinations
@@ -118,23 +222,25 @@ func (b *Builder) getBuildPod(buildID string, options *types.ImageBuildOptions,
 
 	// extra flags
 	kanikoArgs = append(kanikoArgs, kanikoOptions.Args...)
+	return kanikoArgs, nil
+}
 
-	// build the volumes
-	volumes := []k8sv1.Volume{
-		{
-			Name: "context",
-			VolumeSource: k8sv1.VolumeSource{
-				EmptyDir: &k8sv1.EmptyDirVolumeSource{},
-			},
-		},
-	}
-	volumeMounts := []k8sv1.VolumeMount{
-		{
-			Name:      "context",
-			MountPath: kanikoContextPath,
-		},
-	}
+// getVolumes returns the pull secret and additional volumes with their mounts for the kaniko container
+func (b *Builder) getVolumes() ([]k8sv1.Volume, []k8sv1.VolumeMount, error) {
+	kanikoOptions := b.helper.ImageConf.Build.Kaniko
+	volumes := []k8sv1.Volume{}
+	volumeMounts := []k8sv1.VolumeMount{}
 	if !kanikoOptions.SkipPullSecretMount {
+		registryURL, err := pullsecrets.GetRegistryFromImageName(b.FullImageName)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		pullSecretName := pullsecrets.GetRegistryAuthSecretName(registryURL)
+		if b.PullSecretName != "" {
+			pullSecretName = b.PullSecretName
+		}
+
 		volumes = append(volumes, k8sv1.Volume{
 			Name: pullSecretName,
 			VolumeSource: k8sv1.VolumeSource{
@@ -209,87 +315,21 @@ func (b *Builder) getBuildPod(buildID string, options *types.ImageBuildOptions,
 		})
 	}
 
-	// create the build pod
-	pod := &k8sv1.Pod{
-		ObjectMeta: metav1.ObjectMeta{
-			GenerateName: "devspace-build-kaniko-",
-			Annotations:  map[string]string{},
-			Labels: map[string]string{
-				"devspace-build":    "true",
-				"devspace-build-id": buildID,
-			},
-		},
-		Spec: k8sv1.PodSpec{
-			InitContainers: []k8sv1.Container{
-				{
-					Name:            "context",
-					Image:           kanikoInitImage,
-					Command:         []string{"sh"},
-					Args:            []string{"-c", "while [ ! -f " + doneFile + " ]; do sleep 2; done"},
-					ImagePullPolicy: k8sv1.PullIfNotPresent,
-					VolumeMounts: []k8sv1.VolumeMount{
-						{
-							Name:      "context",
-							MountPath: kanikoContextPath,
-						},
-					},
-				},
-			},
-			Containers: []k8sv1.Container{
-				{
-					Name:            "kaniko",
-					Image:           kanikoImage,
-					ImagePullPolicy: k8sv1.PullIfNotPresent,
-					Command:         kanikoOptions.Command,
-					Args:            kanikoArgs,
-					VolumeMounts:    volumeMounts,
-				},
-			},
-			NodeSelector:       kanikoOptions.NodeSelector,
-			ServiceAccountName: kanikoOptions.ServiceAccount,
-			Volumes:            volumes,
-			RestartPolicy:      k8sv1.RestartPolicyNever,
-		},
-	}
-
-	// add extra annotations
-	for k, v := range kanikoOptions.Annotations {
-		pod.Annotations[k] = v
-	}
-
-	// add extra labels
-	for k, v := range kanikoOptions.Labels {
-		pod.Labels[k] = v
-	}
-
-	// add extra init env vars
-	for k, v := range kanikoOptions.InitEnv {
-		if len(pod.Spec.InitContainers[0].Env) == 0 {
-			pod.Spec.InitContainers[0].Env = []k8sv1.EnvVar{}
-		}
+	return volumes, volumeMounts, nil
+}
 
-		pod.Spec.InitContainers[0].Env = append(pod.Spec.InitContainers[0].Env, k8sv1.EnvVar{
-			Name:  k,
-			Value: v,
-		})
-	}
+// getEnv returns the extra environment variables of the kaniko container
+func (b *Builder) getEnv() ([]k8sv1.EnvVar, error) {
+	kanikoOptions := b.helper.ImageConf.Build.Kaniko
 
-	// add extra env vars
+	var env []k8sv1.EnvVar
 	for k, v := range kanikoOptions.Env {
-		if len(pod.Spec.Containers[0].Env) == 0 {
-			pod.Spec.Containers[0].Env = []k8sv1.EnvVar{}
-		}
-
-		pod.Spec.Containers[0].Env = append(pod.Spec.Containers[0].Env, k8sv1.EnvVar{
+		env = append(env, k8sv1.EnvVar{
 			Name:  k,
 			Value: v,
 		})
 	}
 	for k, v := range kanikoOptions.EnvFrom {
-		if len(pod.Spec.Containers[0].Env) == 0 {
-			pod.Spec.Containers[0].Env = []k8sv1.EnvVar{}
-		}
-
 		o, err := yaml.Marshal(v)
 		if err != nil {
 			return nil, errors.Errorf("error converting envFrom %s: %v", k, err)
@@ -301,61 +341,57 @@ func (b *Builder) getBuildPod(buildID string, options *types.ImageBuildOptions,
 			return nil, errors.Errorf("error converting envFrom %s: %v", k, err)
 		}
 
-		pod.Spec.Containers[0].Env = append(pod.Spec.Containers[0].Env, k8sv1.EnvVar{
+		env = append(env, k8sv1.EnvVar{
 			Name:      k,
 			ValueFrom: source,
 		})
 	}
 
+	return env, nil
+}
+
+// getResources returns the resources for the build containers or nil if none should be set
+func (b *Builder) getResources() (*k8sv1.ResourceRequirements, error) {
+	kanikoOptions := b.helper.ImageConf.Build.Kaniko
+
 	// check if we have specific options for the resources part
 	if kanikoOptions.Resources == nil {
 		// get available resources
 		availableResources, err := b.getAvailableResources()
 		if err != nil {
 			return nil, err
-		} else if availableResources != nil {
-			limits := k8sv1.ResourceList{
+		} else if availableResources == nil {
+			return nil, nil
+		}
+
+		return &k8sv1.ResourceRequirements{
+			Limits: k8sv1.ResourceList{
 				k8sv1.ResourceCPU:              availableResources.CPU,
 				k8sv1.ResourceMemory:           availableResources.Memory,
 				k8sv1.ResourceEphemeralStorage: availableResources.EphemeralStorage,
-			}
-			requests := k8sv1.ResourceList{
+			},
+			Requests: k8sv1.ResourceList{
 				k8sv1.ResourceCPU:              resource.MustParse("0"),
 				k8sv1.ResourceMemory:           resource.MustParse("0"),
 				k8sv1.ResourceEphemeralStorage: resource.MustParse("0"),
-			}
-			pod.Spec.InitContainers[0].Resources = k8sv1.ResourceRequirements{
-				Limits:   limits,
-				Requests: requests,
-			}
-			pod.Spec.Containers[0].Resources = k8sv1.ResourceRequirements{
-				Limits:   limits,
-				Requests: requests,
-			}
-		}
-	} else {
-		// convert resources
-		limits, err := ConvertMap(kanikoOptions.Resources.Limits)
-		if err != nil {
-			return nil, errors.Wrap(err, "limits")
-		}
-		requests, err := ConvertMap(kanikoOptions.Resources.Requests)
-		if err != nil {
-			return nil, errors.Wrap(err, "requests")
-		}
+			},
+		}, nil
+	}
 
-		pod.Spec.InitContainers[0].Resources = k8sv1.ResourceRequirements{
-			Limits:   limits,
-			Requests: requests,
-		}
-		pod.Spec.Containers[0].Resources = k8sv1.ResourceRequirements{
-			Limits:   limits,
-			Requests: requests,
-		}
+	// convert resources
+	limits, err := ConvertMap(kanikoOptions.Resources.Limits)
+	if err != nil {
+		return nil, errors.Wrap(err, "limits")
+	}
+	requests, err := ConvertMap(kanikoOptions.Resources.Requests)
+	if err != nil {
+		return nil, errors.Wrap(err, "requests")
 	}
 
-	// return the build pod
-	return pod, nil
+	return &k8sv1.ResourceRequirements{
+		Limits:   limits,
+		Requests: requests,
+	}, nil
 }
 
 func ConvertMap(m map[string]string) (map[k8sv1.ResourceName]resource.Quantity, error) {
@@ -23,6 +23,7 @@ import (
 	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
 	"github.com/loft-sh/devspace/pkg/devspace/pullsecrets"
 	"github.com/loft-sh/devspace/pkg/devspace/services"
+	"github.com/loft-sh/devspace/pkg/devspace/services/synccontroller"
 	"github.com/loft-sh/devspace/pkg/devspace/services/targetselector"
 	logpkg "github.com/loft-sh/devspace/pkg/util/log"
 	"github.com/loft-sh/devspace/pkg/util/randutil"
@@ -35,6 +36,7 @@ import (
 	"github.com/docker/docker/api/types"
 	dockerterm "github.com/moby/term"
 	"github.com/pkg/errors"
+	k8sv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/kubectl/pkg/util/interrupt"
 )
@@ -56,6 +58,8 @@ type Builder struct {
 
 	allowInsecureRegistry bool
 	dockerClient          docker.Client
+
+	digestWriter *helper.DigestWriter
 }
 
 // Wait timeout is the maximum time to wait for the kaniko init and build container to get ready
@@ -110,6 +114,15 @@ func (b *Builder) ShouldRebuild(cache *generated.CacheConfig, forceRebuild bool)
 	return b.helper.ShouldRebuild(cache, forceRebuild)
 }
 
+// Digest returns the digest of the pushed image that was found in the kaniko logs
+func (b *Builder) Digest() string {
+	if b.digestWriter == nil {
+		return ""
+	}
+
+	return b.digestWriter.Digest()
+}
+
 // Authenticate authenticates kaniko for pushing to the RegistryURL (if username == "", it will try to get login data from local docker daemon)
 func (b *Builder) createPullSecret(log logpkg.Logger) error {
 	username, password := "", ""
@@ -175,6 +188,15 @@ func (b *Builder) BuildImage(contextPath, dockerfilePath string, entrypoint []st
 		defer os.RemoveAll(filepath.Dir(dockerfilePath))
 	}
 
+	// Use the long lived builder pod if reuse is enabled and fall back to a separate build pod
+	// if another build is running in it
+	if IsReuseEnabled(b.helper.ImageConf.Build.Kaniko) {
+		err = b.buildWithReusablePod(options, contextPath, dockerfilePath, log)
+		if err != errBuildPodBusy {
+			return err
+		}
+	}
+
 	// Generate the build pod spec
 	randString := randutil.GenerateRandomString(12)
 	buildID := strings.ToLower(randString)
@@ -246,90 +268,12 @@ func (b *Builder) BuildImage(contextPath, dockerfilePath string, entrypoint []st
 			return errors.Wrap(err, "waiting for kaniko init")
 		}
 
-		// Get ignore rules from docker ignore
-		relDockerfile := archive.CanonicalTarNameForPath(dockerfilePath)
-		ignoreRules, err := helper.ReadDockerignore(contextPath, relDockerfile)
-		if err != nil {
-			return err
-		}
-		if err := build.ValidateContextDirectory(contextPath, ignoreRules); err != nil {
-			return errors.Errorf("error checking context: '%s'", err)
-		}
-
 		log.StartWait("Uploading files to build container")
-		buildCtx, err := archive.TarWithOptions(contextPath, &archive.TarOptions{
-			ExcludePatterns: ignoreRules,
-			ChownOpts:       &idtools.Identity{UID: 0, GID: 0},
-		})
+		err = b.uploadContext(buildPod, buildPod.Spec.InitContainers[0].Name, kanikoContextPath, contextPath, dockerfilePath, false, log)
 		if err != nil {
 			return err
 		}
 
-		// Copy complete context
-		_, stderr, err := b.helper.KubeClient.ExecBuffered(buildPod, buildPod.Spec.InitContainers[0].Name, []string{"tar", "xp", "-C", kanikoContextPath + "/."}, buildCtx)
-		if err != nil {
-			if stderr != nil {
-				return errors.Errorf("copy context: error executing tar: %s: %v", string(stderr), err)
-			}
-
-			return errors.Wrap(err, "copy context")
-		}
-
-		// Copy dockerfile
-		err = b.helper.KubeClient.Copy(buildPod, buildPod.Spec.InitContainers[0].Name, kanikoContextPath, dockerfilePath, []string{})
-		if err != nil {
-			return errors.Errorf("error uploading dockerfile to container: %v", err)
-		}
-
-		// Copy restart helper script
-		if b.helper.ImageConf.InjectRestartHelper {
-			tempDir, err := ioutil.TempDir("", "")
-			if err != nil {
-				return err
-			}
-
-			defer os.RemoveAll(tempDir)
-
-			scriptPath := filepath.Join(tempDir, restart.ScriptName)
-			remoteFolder := filepath.ToSlash(filepath.Join(kanikoContextPath, ".devspace", ".devspace"))
-			helperScript, err := restart.LoadRestartHelper(b.helper.ImageConf.RestartHelperPath)
-			if err != nil {
-				return errors.Wrap(err, "load restart helper")
-			}
-
-			err = ioutil.WriteFile(scriptPath, []byte(helperScript), 0777)
-			if err != nil {
-				return errors.Wrap(err, "write restart helper script")
-			}
-
-			// create the .devspace directory in the container
-			_, _, err = b.helper.KubeClient.ExecBuffered(buildPod, buildPod.Spec.InitContainers[0].Name, []string{"mkdir", "-p", remoteFolder}, nil)
-			if err != nil {
-				return errors.Errorf("error executing command 'mkdir -p %s' in init container: %v", remoteFolder, err)
-			}
-
-			// copy the helper script into the container
-			err = b.helper.KubeClient.Copy(buildPod, buildPod.Spec.InitContainers[0].Name, remoteFolder, scriptPath, []string{})
-			if err != nil {
-				return errors.Errorf("error uploading helper script to container: %v", err)
-			}
-
-			// change permissions for the execution script
-			_, _, err = b.helper.KubeClient.ExecBuffered(buildPod, buildPod.Spec.InitContainers[0].Name, []string{"chmod", "-R", "0777", remoteFolder}, nil)
-			if err != nil {
-				return errors.Errorf("error executing command 'chmod +x %s' in init container: %v", filepath.Join(kanikoContextPath, restart.ScriptName), err)
-			}
-
-			// remove the .dockerignore since .devspace is usually ignored and we want to sneak our helper script in
-			// this shouldn't be any issue since the context was already pruned in the copy step beforehand
-			_, _, err = b.helper.KubeClient.ExecBuffered(buildPod, buildPod.Spec.InitContainers[0].Name, []string{"rm", filepath.ToSlash(filepath.Join(kanikoContextPath, ".dockerignore"))}, nil)
-			if err != nil {
-				if _, ok := err.(exec.CodeExitError); !ok {
-					return errors.Errorf("error executing command 'rm .dockerignore' in init container: %v", err)
-				}
-			}
-		}
-
 		// Tell init container we are done
 		_, _, err = b.helper.KubeClient.ExecBuffered(buildPod, buildPod.Spec.InitContainers[0].Name, []string{"touch", doneFile}, nil)
 		if err != nil {
@@ -386,7 +330,9 @@ func (b *Builder) BuildImage(contextPath, dockerfilePath string, entrypoint []st
 			writer = log
 		}
 
-		stdoutLogger := kanikoLogger{out: writer}
+		// kaniko logs the digest of the pushed image, which we catch here
+		b.digestWriter = helper.NewDigestWriter(writer, b.helper.ImageName)
+		stdoutLogger := kanikoLogger{out: b.digestWriter}
 
 		// Stream the logs
 		err = services.NewClient(b.helper.Config, nil, b.helper.KubeClient, log).StartLogsWithWriter(targetselector.NewOptionsFromFlags(buildPod.Spec.Containers[0].Name, "", buildPod.Namespace, buildPod.Name, false), true, 100, false, stdoutLogger)
@@ -435,3 +381,139 @@ func (b *Builder) BuildImage(contextPath, dockerfilePath string, entrypoint []st
 
 	return nil
 }
+
+// uploadContext uploads the build context, the dockerfile and the restart helper into the given container.
+// If incremental is true, only the changes to the already existing remote context are transferred
+func (b *Builder) uploadContext(pod *k8sv1.Pod, container, remoteContextPath, contextPath, dockerfilePath string, incremental bool, log logpkg.Logger) error {
+	// Get ignore rules from docker ignore
+	relDockerfile := archive.CanonicalTarNameForPath(dockerfilePath)
+	ignoreRules, err := helper.ReadDockerignore(contextPath, relDockerfile)
+	if err != nil {
+		return err
+	}
+	if err := build.ValidateContextDirectory(contextPath, ignoreRules); err != nil {
+		return errors.Errorf("error checking context: '%s'", err)
+	}
+
+	if incremental {
+		err = synccontroller.Upload(b.helper.KubeClient, pod, container, &synccontroller.UploadOptions{
+			LocalPath:     contextPath,
+			ContainerPath: remoteContextPath,
+			ExcludePaths:  getSyncExcludePaths(ignoreRules, dockerfilePath),
+			Log:           log,
+		})
+		if err != nil {
+			log.Warnf("Error uploading changed files, falling back to full context upload: %v", err)
+
+			_, _, err = b.helper.KubeClient.ExecBuffered(pod, container, []string{"sh", "-c", fmt.Sprintf("rm -rf %s && mkdir -p %s", remoteContextPath, remoteContextPath)}, nil)
+			if err != nil {
+				return errors.Errorf("error clearing remote context: %v", err)
+			}
+
+			incremental = false
+		}
+	}
+
+	if !incremental {
+		buildCtx, err := archive.TarWithOptions(contextPath, &archive.TarOptions{
+			ExcludePatterns: ignoreRules,
+			ChownOpts:       &idtools.Identity{UID: 0, GID: 0},
+		})
+		if err != nil {
+			return err
+		}
+
+		// Copy complete context
+		_, stderr, err := b.helper.KubeClient.ExecBuffered(pod, container, []string{"tar", "xp", "-C", remoteContextPath + "/."}, buildCtx)
+		if err != nil {
+			if stderr != nil {
+				return errors.Errorf("copy context: error executing tar: %s: %v", string(stderr), err)
+			}
+
+			return errors.Wrap(err, "copy context")
+		}
+	}
+
+	// Copy dockerfile
+	err = b.helper.KubeClient.Copy(pod, container, remoteContextPath, dockerfilePath, []string{})
+	if err != nil {
+		return errors.Errorf("error uploading dockerfile to container: %v", err)
+	}
+
+	// Copy restart helper script
+	if b.helper.ImageConf.InjectRestartHelper {
+		tempDir, err := ioutil.TempDir("", "")
+		if err != nil {
+			return err
+		}
+
+		defer os.RemoveAll(tempDir)
+
+		scriptPath := filepath.Join(tempDir, restart.ScriptName)
+		remoteFolder := filepath.ToSlash(filepath.Join(remoteContextPath, ".devspace", ".devspace"))
+		helperScript, err := restart.LoadRestartHelper(b.helper.ImageConf.RestartHelperPath)
+		if err != nil {
+			return errors.Wrap(err, "load restart helper")
+		}
+
+		err = ioutil.WriteFile(scriptPath, []byte(helperScript), 0777)
+		if err != nil {
+			return errors.Wrap(err, "write restart helper script")
+		}
+
+		// create the .devspace directory in the container
+		_, _, err = b.helper.KubeClient.ExecBuffered(pod, container, []string{"mkdir", "-p", remoteFolder}, nil)
+		if err != nil {
+			return errors.Errorf("error executing command 'mkdir -p %s' in build container: %v", remoteFolder, err)
+		}
+
+		// copy the helper script into the container
+		err = b.helper.KubeClient.Copy(pod, container, remoteFolder, scriptPath, []string{})
+		if err != nil {
+			return errors.Errorf("error uploading helper script to container: %v", err)
+		}
+
+		// change permissions for the execution script
+		_, _, err = b.helper.KubeClient.ExecBuffered(pod, container, []string{"chmod", "-R", "0777", remoteFolder}, nil)
+		if err != nil {
+			return errors.Errorf("error executing command 'chmod +x %s' in build container: %v", filepath.Join(remoteContextPath, restart.ScriptName), err)
+		}
+
+		// remove the .dockerignore since .devspace is usually ignored and we want to sneak our helper script in
+		// this shouldn't be any issue since the context was already pruned in the copy step beforehand
+		_, _, err = b.helper.KubeClient.ExecBuffered(pod, container, []string{"rm", filepath.ToSlash(filepath.Join(remoteContextPath, ".dockerignore"))}, nil)
+		if err != nil {
+			if _, ok := err.(exec.CodeExitError); !ok {
+				return errors.Errorf("error executing command 'rm .dockerignore' in build container: %v", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// getSyncExcludePaths converts the dockerignore rules into sync exclude paths. Docker matches
+// patterns from the context root, so the paths are anchored there. The dockerfile is excluded,
+// because it is copied separately and might be rewritten
+func getSyncExcludePaths(ignoreRules []string, dockerfilePath string) []string {
+	excludePaths := []string{}
+	for _, rule := range ignoreRules {
+		rule = strings.TrimSpace(rule)
+		if rule == "" || strings.HasPrefix(rule, "#") {
+			continue
+		}
+
+		prefix := ""
+		if strings.HasPrefix(rule, "!") {
+			prefix = "!"
+			rule = rule[1:]
+		}
+		if !strings.HasPrefix(rule, "/") {
+			rule = "/" + rule
+		}
+
+		excludePaths = append(excludePaths, prefix+rule)
+	}
+
+	return append(excludePaths, "/"+filepath.Base(dockerfilePath))
+}
@@ -0,0 +1,734 @@
+package kaniko
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/loft-sh/devspace/pkg/devspace/build/builder/helper"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
+	"github.com/loft-sh/devspace/pkg/util/hash"
+	logpkg "github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/loft-sh/devspace/pkg/util/randutil"
+	"github.com/pkg/errors"
+	k8sv1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+// The kaniko image we use by default for the reusable builder pod, which needs a shell
+const kanikoReuseBuildImage = kanikoBuildImage + "-debug"
+
+// shellStartErrorReasons are the reasons of a container that could not start its command
+var shellStartErrorReasons = map[string]bool{
+	"StartError":           true,
+	"ContainerCannotRun":   true,
+	"RunContainerError":    true,
+	"CreateContainerError": true,
+}
+
+// ReuseLabel is the label that is set on reusable builder pods and their cache volumes
+const ReuseLabel = "devspace-build-reuse"
+
+// The path of the kaniko base image cache within the reusable builder pod
+const kanikoCachePath = "/cache"
+
+// The directory where running builds create a marker file to keep the builder pod alive. This
+// needs to be on a volume, because kaniko cleans up the container filesystem after every build
+const buildMarkerPath = kanikoContextPath + "/.builds"
+
+// The file that is touched after every build to reset the idle timeout
+const lastBuildFile = kanikoContextPath + "/.last-build"
+
+// The default idle timeout in seconds after which the builder pod terminates itself
+const defaultIdleTimeout = int64(1800)
+
+// The default size of the cache volume
+const defaultCacheVolumeSize = "10Gi"
+
+// The name of the reusable builder pod, there is a single builder pod per namespace
+const reusableBuildPodName = "devspace-build-kaniko"
+
+// The annotation that holds the hash of the builder pod spec, a builder pod with different
+// settings is recreated
+const buildPodHashAnnotation = "devspace.sh/build-pod-hash"
+
+// The annotations that lock the builder pod for a single build across devspace processes
+const buildLockOwnerAnnotation = "devspace.sh/build-lock-owner"
+const buildLockExpiryAnnotation = "devspace.sh/build-lock-expiry"
+
+// The time a build lock is valid and the interval in which a running build renews it
+const buildLockDuration = 2 * time.Minute
+const buildLockRenewInterval = 30 * time.Second
+
+// errBuildPodBusy is returned if another build holds the lock of the builder pod
+var errBuildPodBusy = errors.New("build pod is busy")
+
+// reuseLocks makes sure that only a single build of this process at a time runs in a reusable
+// builder pod, because kaniko modifies the filesystem of the container. Builds of other
+// processes are excluded by the lock annotations of the pod
+var reuseLocks = map[string]*sync.Mutex{}
+var reuseLocksMutex sync.Mutex
+
+func getReuseLock(namespace, name string) *sync.Mutex {
+	reuseLocksMutex.Lock()
+	defer reuseLocksMutex.Unlock()
+
+	key := namespace + "/" + name
+	if _, ok := reuseLocks[key]; !ok {
+		reuseLocks[key] = &sync.Mutex{}
+	}
+
+	return reuseLocks[key]
+}
+
+// isBuildPodLocked returns true if another owner holds a lock of the builder pod that is not expired
+func isBuildPodLocked(pod *k8sv1.Pod, owner string, now time.Time) bool {
+	lockOwner := pod.Annotations[buildLockOwnerAnnotation]
+	if lockOwner == "" || lockOwner == owner {
+		return false
+	}
+
+	expiry, err := time.Parse(time.RFC3339, pod.Annotations[buildLockExpiryAnnotation])
+	return err == nil && now.Before(expiry)
+}
+
+// setBuildPodLock sets the lock annotations of the owner on the builder pod
+func setBuildPodLock(pod *k8sv1.Pod, owner string, now time.Time) {
+	if pod.Annotations == nil {
+		pod.Annotations = map[string]string{}
+	}
+
+	pod.Annotations[buildLockOwnerAnnotation] = owner
+	pod.Annotations[buildLockExpiryAnnotation] = now.Add(buildLockDuration).Format(time.RFC3339)
+}
+
+// lockBuildPod locks the existing builder pod for the owner. If another process updates the
+// pod in between, the update fails with a conflict and the pod counts as busy
+func lockBuildPod(pods corev1.PodInterface, pod *k8sv1.Pod, owner string) (*k8sv1.Pod, error) {
+	if isBuildPodLocked(pod, owner, time.Now()) {
+		return nil, errBuildPodBusy
+	}
+
+	pod = pod.DeepCopy()
+	setBuildPodLock(pod, owner, time.Now())
+	updated, err := pods.Update(context.TODO(), pod, metav1.UpdateOptions{})
+	if err != nil {
+		if kerrors.IsConflict(err) {
+			return nil, errBuildPodBusy
+		}
+
+		return nil, errors.Wrap(err, "lock build pod")
+	}
+
+	return updated, nil
+}
+
+// renewBuildPodLock extends the lock of the owner on the builder pod
+func renewBuildPodLock(pods corev1.PodInterface, name, owner string) error {
+	return updateBuildPodLock(pods, name, func(pod *k8sv1.Pod) (bool, error) {
+		if pod.Annotations[buildLockOwnerAnnotation] != owner {
+			return false, errors.Errorf("build pod %s is not locked by %s anymore", name, owner)
+		}
+
+		setBuildPodLock(pod, owner, time.Now())
+		return true, nil
+	})
+}
+
+// unlockBuildPod removes the lock of the owner from the builder pod
+func unlockBuildPod(pods corev1.PodInterface, name, owner string) error {
+	return updateBuildPodLock(pods, name, func(pod *k8sv1.Pod) (bool, error) {
+		if pod.Annotations[buildLockOwnerAnnotation] != owner {
+			return false, nil
+		}
+
+		delete(pod.Annotations, buildLockOwnerAnnotation)
+		delete(pod.Annotations, buildLockExpiryAnnotation)
+		return true, nil
+	})
+}
+
+// updateBuildPodLock changes the lock annotations of the builder pod and retries on conflicts
+func updateBuildPodLock(pods corev1.PodInterface, name string, change func(pod *k8sv1.Pod) (bool, error)) error {
+	var updateErr error
+	for i := 0; i < 5; i++ {
+		pod, err := pods.Get(context.TODO(), name, metav1.GetOptions{})
+		if err != nil {
+			if kerrors.IsNotFound(err) {
+				return nil
+			}
+
+			return err
+		}
+
+		update, err := change(pod)
+		if err != nil || update == false {
+			return err
+		}
+
+		_, updateErr = pods.Update(context.TODO(), pod, metav1.UpdateOptions{})
+		if updateErr == nil || kerrors.IsConflict(updateErr) == false {
+			return updateErr
+		}
+	}
+
+	return updateErr
+}
+
+// IsReuseEnabled returns true if the kaniko builder pod should be reused
+func IsReuseEnabled(kanikoOptions *latest.KanikoConfig) bool {
+	return kanikoOptions != nil && kanikoOptions.Reuse != nil && (kanikoOptions.Reuse.Enabled == nil || *kanikoOptions.Reuse.Enabled)
+}
+
+// getReusableBuildPod returns the spec of the long lived builder pod. The hash of the spec is
+// saved in an annotation, so the builder pod is recreated if the build settings change
+func (b *Builder) getReusableBuildPod() (*k8sv1.Pod, error) {
+	kanikoOptions := b.helper.ImageConf.Build.Kaniko
+
+	kanikoImage := kanikoReuseBuildImage
+	if kanikoOptions.Image != "" {
+		kanikoImage = kanikoOptions.Image
+	}
+
+	kanikoInitImage := kanikoInitImage
+	if kanikoOptions.InitImage != "" {
+		kanikoInitImage = kanikoOptions.InitImage
+	}
+
+	idleTimeout := defaultIdleTimeout
+	if kanikoOptions.Reuse.IdleTimeout != nil {
+		idleTimeout = *kanikoOptions.Reuse.IdleTimeout
+	}
+
+	// the pod terminates itself if no build was running within the idle timeout.
+	// Markers of builds that were interrupted are ignored after two hours
+	script := fmt.Sprintf(`mkdir -p %s && touch %s && while [ -n "$(find %s -type f -mmin -120)" ] || [ $(( $(date +%%s) - $(stat -c %%Y %s) )) -lt %d ]; do sleep 5; done`, buildMarkerPath, lastBuildFile, buildMarkerPath, lastBuildFile, idleTimeout)
+
+	// build the volumes
+	volumes := []k8sv1.Volume{
+		{
+			Name: "context",
+			VolumeSource: k8sv1.VolumeSource{
+				EmptyDir: &k8sv1.EmptyDirVolumeSource{},
+			},
+		},
+	}
+	volumeMounts := []k8sv1.VolumeMount{
+		{
+			Name:      "context",
+			MountPath: kanikoContextPath,
+		},
+	}
+	additionalVolumes, additionalVolumeMounts, err := b.getVolumes()
+	if err != nil {
+		return nil, err
+	}
+	volumes = append(volumes, additionalVolumes...)
+	volumeMounts = append(volumeMounts, additionalVolumeMounts...)
+
+	pod := &k8sv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{},
+			Labels: map[string]string{
+				ReuseLabel: "true",
+			},
+		},
+		Spec: k8sv1.PodSpec{
+			Containers: []k8sv1.Container{
+				{
+					Name:            "kaniko",
+					Image:           kanikoImage,
+					ImagePullPolicy: k8sv1.PullIfNotPresent,
+					Command:         []string{"sh"},
+					Args:            []string{"-c", script},
+					VolumeMounts:    volumeMounts,
+				},
+				// the context container receives the build context. Kaniko cleans up the filesystem of
+				// its own container after every build, so the devspacehelper would not survive there
+				{
+					Name:            "context",
+					Image:           kanikoInitImage,
+					ImagePullPolicy: k8sv1.PullIfNotPresent,
+					Command:         []string{"sh"},
+					Args:            []string{"-c", script},
+					VolumeMounts: []k8sv1.VolumeMount{
+						{
+							Name:      "context",
+							MountPath: kanikoContextPath,
+						},
+					},
+				},
+			},
+			NodeSelector:       kanikoOptions.NodeSelector,
+			ServiceAccountName: kanikoOptions.ServiceAccount,
+			Volumes:            volumes,
+			RestartPolicy:      k8sv1.RestartPolicyNever,
+		},
+	}
+
+	// add extra annotations
+	for k, v := range kanikoOptions.Annotations {
+		pod.Annotations[k] = v
+	}
+
+	// add extra labels
+	for k, v := range kanikoOptions.Labels {
+		pod.Labels[k] = v
+	}
+
+	// add extra init env vars
+	for k, v := range kanikoOptions.InitEnv {
+		pod.Spec.Containers[1].Env = append(pod.Spec.Containers[1].Env, k8sv1.EnvVar{
+			Name:  k,
+			Value: v,
+		})
+	}
+
+	// add extra env vars
+	pod.Spec.Containers[0].Env, err = b.getEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	// set the resources
+	resources, err := b.getResources()
+	if err != nil {
+		return nil, err
+	} else if resources != nil {
+		pod.Spec.Containers[0].Resources = *resources
+		pod.Spec.Containers[1].Resources = *resources
+	}
+
+	// hash the spec and the cache volume settings
+	out, err := json.Marshal([]interface{}{pod, kanikoOptions.Reuse.CacheVolume})
+	if err != nil {
+		return nil, err
+	}
+	pod.Name = reusableBuildPodName
+	pod.Annotations[buildPodHashAnnotation] = hash.String(string(out))[:10]
+
+	// add the cache volume
+	if kanikoOptions.Reuse.CacheVolume != nil {
+		pod.Spec.Volumes = append(pod.Spec.Volumes, k8sv1.Volume{
+			Name: "cache",
+			VolumeSource: k8sv1.VolumeSource{
+				PersistentVolumeClaim: &k8sv1.PersistentVolumeClaimVolumeSource{
+					ClaimName: pod.Name + "-cache",
+				},
+			},
+		})
+		pod.Spec.Containers[0].VolumeMounts = append(pod.Spec.Containers[0].VolumeMounts, k8sv1.VolumeMount{
+			Name:      "cache",
+			MountPath: kanikoCachePath,
+		})
+	}
+
+	return pod, nil
+}
+
+// getCacheVolumeClaim returns the persistent volume claim that holds the base image cache of the given builder pod
+func (b *Builder) getCacheVolumeClaim(buildPod *k8sv1.Pod) (*k8sv1.PersistentVolumeClaim, error) {
+	cacheVolume := b.helper.ImageConf.Build.Kaniko.Reuse.CacheVolume
+
+	size := defaultCacheVolumeSize
+	if cacheVolume.Size != "" {
+		size = cacheVolume.Size
+	}
+
+	quantity, err := resource.ParseQuantity(size)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse cache volume size")
+	}
+
+	pvc := &k8sv1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: buildPod.Name + "-cache",
+			Labels: map[string]string{
+				ReuseLabel: "true",
+			},
+		},
+		Spec: k8sv1.PersistentVolumeClaimSpec{
+			AccessModes: []k8sv1.PersistentVolumeAccessMode{k8sv1.ReadWriteOnce},
+			Resources: k8sv1.ResourceRequirements{
+				Requests: k8sv1.ResourceList{
+					k8sv1.ResourceStorage: quantity,
+				},
+			},
+		},
+	}
+	if cacheVolume.StorageClassName != "" {
+		pvc.Spec.StorageClassName = &cacheVolume.StorageClassName
+	}
+
+	return pvc, nil
+}
+
+// ensureReusableBuildPod makes sure the reusable builder pod exists and locks it for the owner. It
+// returns errBuildPodBusy if another build holds the lock of the builder pod
+func (b *Builder) ensureReusableBuildPod(buildPod *k8sv1.Pod, owner string, log logpkg.Logger) (*k8sv1.Pod, error) {
+	pods := b.helper.KubeClient.KubeClient().CoreV1().Pods(b.BuildNamespace)
+	existing, err := pods.Get(context.TODO(), buildPod.Name, metav1.GetOptions{})
+	if err != nil && kerrors.IsNotFound(err) == false {
+		return nil, errors.Wrap(err, "get build pod")
+	} else if err == nil {
+		if existing.DeletionTimestamp == nil && (existing.Status.Phase == k8sv1.PodRunning || existing.Status.Phase == k8sv1.PodPending) {
+			existing, err = lockBuildPod(pods, existing, owner)
+			if err != nil {
+				return nil, err
+			} else if existing.Annotations[buildPodHashAnnotation] == buildPod.Annotations[buildPodHashAnnotation] {
+				return existing, nil
+			}
+
+			log.StartWait("Deleting build pod " + existing.Name + " with other build settings")
+		} else {
+			// the pod has terminated because of the idle timeout, so we recreate it
+			log.StartWait("Deleting terminated build pod " + existing.Name)
+		}
+
+		// only delete this pod, because another process might have recreated it already
+		err = pods.Delete(context.TODO(), existing.Name, metav1.DeleteOptions{
+			Preconditions: metav1.NewUIDPreconditions(string(existing.UID)),
+		})
+		if err != nil && kerrors.IsNotFound(err) == false && kerrors.IsConflict(err) == false {
+			return nil, errors.Wrap(err, "delete build pod")
+		}
+
+		err = wait.PollImmediate(time.Second, waitTimeout, func() (bool, error) {
+			pod, err := pods.Get(context.TODO(), existing.Name, metav1.GetOptions{})
+			if kerrors.IsNotFound(err) {
+				return true, nil
+			} else if err != nil {
+				return false, err
+			}
+
+			return pod.UID != existing.UID, nil
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "wait for build pod deletion")
+		}
+	}
+
+	// create the cache volume
+	if b.helper.ImageConf.Build.Kaniko.Reuse.CacheVolume != nil {
+		pvc, err := b.getCacheVolumeClaim(buildPod)
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = b.helper.KubeClient.KubeClient().CoreV1().PersistentVolumeClaims(b.BuildNamespace).Create(context.TODO(), pvc, metav1.CreateOptions{})
+		if err != nil && kerrors.IsAlreadyExists(err) == false {
+			return nil, errors.Errorf("unable to create build cache volume: %s", err.Error())
+		}
+	}
+
+	// the pod is created locked, if another process created it in between it is busy
+	buildPod = buildPod.DeepCopy()
+	setBuildPodLock(buildPod, owner, time.Now())
+	created, err := pods.Create(context.TODO(), buildPod, metav1.CreateOptions{})
+	if err != nil {
+		if kerrors.IsAlreadyExists(err) {
+			return nil, errBuildPodBusy
+		}
+
+		return nil, errors.Errorf("unable to create build pod: %s", err.Error())
+	}
+
+	return created, nil
+}
+
+// waitForReusableBuildPod waits until all containers of the builder pod are ready
+func (b *Builder) waitForReusableBuildPod(name string, log logpkg.Logger) (*k8sv1.Pod, error) {
+	var buildPod *k8sv1.Pod
+
+	log.StartWait("Waiting for build pod to start")
+	defer log.StopWait()
+
+	err := wait.PollImmediate(time.Second, waitTimeout, func() (done bool, err error) {
+		buildPod, err = b.helper.KubeClient.KubeClient().CoreV1().Pods(b.BuildNamespace).Get(context.TODO(), name, metav1.GetOptions{})
+		if err != nil {
+			if kerrors.IsNotFound(err) {
+				return false, nil
+			}
+
+			return false, err
+		}
+
+		for _, status := range buildPod.Status.ContainerStatuses {
+			err := shellStartError(buildPod, status)
+			if err != nil {
+				return false, err
+			}
+
+			if status.State.Terminated != nil {
+				errorLog := ""
+				reader, _ := b.helper.KubeClient.Logs(context.TODO(), b.BuildNamespace, name, status.Name, false, nil, false)
+				if reader != nil {
+					out, err := ioutil.ReadAll(reader)
+					if err == nil {
+						errorLog = string(out)
+					}
+				}
+				if errorLog == "" {
+					errorLog = status.State.Terminated.Message
+				}
+
+				return false, fmt.Errorf("kaniko pod %s/%s container %s has unexpectedly exited with code %d: %s", buildPod.Namespace, buildPod.Name, status.Name, status.State.Terminated.ExitCode, errorLog)
+			} else if status.State.Waiting != nil {
+				if kubectl.CriticalStatus[status.State.Waiting.Reason] {
+					return false, fmt.Errorf("kaniko pod %s/%s container %s cannot start: %s (%s)", buildPod.Namespace, buildPod.Name, status.Name, status.State.Waiting.Message, status.State.Waiting.Reason)
+				}
+			} else if !status.Ready {
+				return false, nil
+			}
+		}
+
+		return len(buildPod.Status.ContainerStatuses) == len(buildPod.Spec.Containers), nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "waiting for kaniko")
+	}
+
+	return buildPod, nil
+}
+
+// shellStartError returns an error if a container of the builder pod could not start its shell. The
+// builder pod keeps running with a shell script, so custom kaniko images without a shell cannot be used
+func shellStartError(buildPod *k8sv1.Pod, status k8sv1.ContainerStatus) error {
+	reason, message := "", ""
+	if status.State.Terminated != nil {
+		reason, message = status.State.Terminated.Reason, status.State.Terminated.Message
+	} else if status.State.Waiting != nil {
+		reason, message = status.State.Waiting.Reason, status.State.Waiting.Message
+	}
+	if shellStartErrorReasons[reason] == false {
+		return nil
+	}
+
+	image := ""
+	for _, container := range buildPod.Spec.Containers {
+		if container.Name == status.Name {
+			image = container.Image
+		}
+	}
+
+	return errors.Errorf("kaniko pod %s/%s container %s cannot start a shell with image %s: %s (%s). A reused build pod requires an image with a shell, e.g. the kaniko debug image %s", buildPod.Namespace, buildPod.Name, status.Name, image, message, reason, kanikoReuseBuildImage)
+}
+
+// buildWithReusablePod builds the image by executing kaniko within the long lived builder pod. It returns
+// errBuildPodBusy if another devspace process is building in the builder pod
+func (b *Builder) buildWithReusablePod(options *types.ImageBuildOptions, contextPath, dockerfilePath string, log logpkg.Logger) error {
+	kanikoOptions := b.helper.ImageConf.Build.Kaniko
+	buildPod, err := b.getReusableBuildPod()
+	if err != nil {
+		return errors.Wrap(err, "get build pod")
+	}
+
+	// only a single build can run at the same time within the pod
+	lock := getReuseLock(b.BuildNamespace, reusableBuildPodName)
+	lock.Lock()
+	defer lock.Unlock()
+
+	buildID := strings.ToLower(randutil.GenerateRandomString(12))
+	hostname, _ := os.Hostname()
+	owner := hostname + "/" + buildID
+	_, err = b.ensureReusableBuildPod(buildPod, owner, log)
+	if err != nil {
+		if err == errBuildPodBusy {
+			log.Infof("Build pod %s/%s is busy with another build, using a separate build pod", b.BuildNamespace, reusableBuildPodName)
+		}
+
+		return err
+	}
+
+	// keep the lock while the build is running and release it afterwards
+	pods := b.helper.KubeClient.KubeClient().CoreV1().Pods(b.BuildNamespace)
+	stopRenew := make(chan struct{})
+	go wait.Until(func() {
+		err := renewBuildPodLock(pods, reusableBuildPodName, owner)
+		if err != nil {
+			log.Warnf("Error renewing lock of build pod %s/%s: %v", b.BuildNamespace, reusableBuildPodName, err)
+		}
+	}, buildLockRenewInterval, stopRenew)
+	defer func() {
+		close(stopRenew)
+		err := unlockBuildPod(pods, reusableBuildPodName, owner)
+		if err != nil {
+			log.Warnf("Error unlocking build pod %s/%s: %v", b.BuildNamespace, reusableBuildPodName, err)
+		}
+	}()
+
+	buildPod, err = b.waitForReusableBuildPod(reusableBuildPodName, log)
+	if err != nil {
+		return err
+	}
+
+	log.Donef("Reusing build pod %s/%s", buildPod.Namespace, buildPod.Name)
+	container := buildPod.Spec.Containers[0].Name
+	contextContainer := buildPod.Spec.Containers[1].Name
+
+	// the build context is kept in a workspace per image and local context, so that
+	// subsequent builds only need to upload the changed files
+	absContextPath, err := filepath.Abs(contextPath)
+	if err != nil {
+		return err
+	}
+	remoteContextPath := kanikoContextPath + "/workspace/" + hash.String(b.helper.ImageConfigName + ":" + absContextPath)[:10]
+
+	// create the marker that keeps the pod alive and check if the workspace exists already
+	marker := buildMarkerPath + "/" + buildID
+	out, _, err := b.helper.KubeClient.ExecBuffered(buildPod, contextContainer, []string{"sh", "-c", fmt.Sprintf("touch %s %s && if [ -d %s ]; then echo exists; else mkdir -p %s; fi", marker, lastBuildFile, remoteContextPath, remoteContextPath)}, nil)
+	if err != nil {
+		return errors.Errorf("error preparing build in build pod %s/%s: %v", buildPod.Namespace, buildPod.Name, err)
+	}
+	defer func() {
+		_, _, err := b.helper.KubeClient.ExecBuffered(buildPod, contextContainer, []string{"sh", "-c", fmt.Sprintf("rm -f %s; touch %s", marker, lastBuildFile)}, nil)
+		if err != nil {
+			log.Warnf("Error cleaning up build marker in build pod %s/%s: %v", buildPod.Namespace, buildPod.Name, err)
+		}
+	}()
+
+	// only upload the changed files if the workspace exists already
+	incremental := strings.TrimSpace(string(out)) == "exists"
+	log.StartWait("Uploading files to build container")
+	err = b.uploadContext(buildPod, contextContainer, remoteContextPath, contextPath, dockerfilePath, incremental, log)
+	log.StopWait()
+	if err != nil {
+		return err
+	}
+	log.Done("Uploaded files to container")
+
+	kanikoArgs, err := b.getKanikoArgs(options, remoteContextPath, dockerfilePath)
+	if err != nil {
+		return err
+	}
+
+	// warm the base image cache
+	if kanikoOptions.Reuse.CacheVolume != nil && (kanikoOptions.Cache == nil || *kanikoOptions.Cache) {
+		kanikoArgs = append(kanikoArgs, "--cache-dir="+kanikoCachePath)
+
+		baseImages, err := getBaseImages(dockerfilePath)
+		if err != nil {
+			log.Warnf("Error reading base images from dockerfile: %v", err)
+		} else if len(baseImages) > 0 {
+			log.StartWait("Warming base image cache")
+			warmerCommand := []string{"/kaniko/warmer", "--cache-dir=" + kanikoCachePath}
+			for _, baseImage := range baseImages {
+				warmerCommand = append(warmerCommand, "--image="+baseImage)
+			}
+
+			_, stderr, err := b.helper.KubeClient.ExecBuffered(buildPod, container, warmerCommand, nil)
+			log.StopWait()
+			if err != nil {
+				log.Warnf("Error warming base image cache: %s %v", string(stderr), err)
+			}
+		}
+	}
+
+	// kaniko needs to clean up the filesystem to be able to run again in the same container
+	command := []string{"/kaniko/executor"}
+	if len(kanikoOptions.Command) > 0 {
+		command = kanikoOptions.Command
+	}
+	command = append(command, kanikoArgs...)
+	command = append(command, "--cleanup")
+
+	// Determine output writer
+	var writer io.Writer
+	if log == logpkg.GetInstance() {
+		writer = stdout
+	} else {
+		writer = log
+	}
+
+	// kaniko logs the digest of the pushed image, which we catch here
+	b.digestWriter = helper.NewDigestWriter(writer, b.helper.ImageName)
+	stdoutLogger := kanikoLogger{out: b.digestWriter}
+
+	err = b.helper.KubeClient.ExecStream(&kubectl.ExecStreamOptions{
+		Pod:       buildPod,
+		Container: container,
+		Command:   command,
+		Stdout:    stdoutLogger,
+		Stderr:    stdoutLogger,
+	})
+	if err != nil {
+		return errors.Errorf("error building image: %v", err)
+	}
+
+	log.Done("Done building image")
+	return nil
+}
+
+var fromRegEx = regexp.MustCompile(`(?im)^\s*FROM\s+(?:--\S+\s+)*(\S+)(?:\s+AS\s+(\S+))?`)
+
+// getBaseImages returns the external images the given dockerfile is based on
+func getBaseImages(dockerfilePath string) ([]string, error) {
+	data, err := ioutil.ReadFile(dockerfilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	stages := map[string]bool{}
+	images := []string{}
+	for _, match := range fromRegEx.FindAllStringSubmatch(string(data), -1) {
+		image := match[1]
+		if strings.ToLower(image) != "scratch" && !strings.Contains(image, "$") && !stages[strings.ToLower(image)] {
+			images = append(images, image)
+		}
+		if match[2] != "" {
+			stages[strings.ToLower(match[2])] = true
+		}
+	}
+
+	return images, nil
+}
+
+// CleanupReusableBuildPods deletes all reusable builder pods and optionally their cache volumes in the given namespace
+func CleanupReusableBuildPods(client kubectl.Client, namespace string, deleteCache bool, log logpkg.Logger) error {
+	listOptions := metav1.ListOptions{LabelSelector: ReuseLabel + "=true"}
+	pods, err := client.KubeClient().CoreV1().Pods(namespace).List(context.TODO(), listOptions)
+	if err != nil {
+		return errors.Wrap(err, "list build pods")
+	}
+
+	for _, pod := range pods.Items {
+		err = client.KubeClient().CoreV1().Pods(namespace).Delete(context.TODO(), pod.Name, metav1.DeleteOptions{})
+		if err != nil && kerrors.IsNotFound(err) == false {
+			return errors.Wrapf(err, "delete build pod %s", pod.Name)
+		}
+
+		log.Donef("Deleted build pod %s/%s", namespace, pod.Name)
+	}
+
+	if deleteCache {
+		pvcs, err := client.KubeClient().CoreV1().PersistentVolumeClaims(namespace).List(context.TODO(), listOptions)
+		if err != nil {
+			return errors.Wrap(err, "list build cache volumes")
+		}
+
+		for _, pvc := range pvcs.Items {
+			err = client.KubeClient().CoreV1().PersistentVolumeClaims(namespace).Delete(context.TODO(), pvc.Name, metav1.DeleteOptions{})
+			if err != nil && kerrors.IsNotFound(err) == false {
+				return errors.Wrapf(err, "delete build cache volume %s", pvc.Name)
+			}
+
+			log.Donef("Deleted build cache volume %s/%s", namespace, pvc.Name)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package kaniko
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetBaseImages(t *testing.T) {
+	dir, err := ioutil.TempDir("", "test")
+	if err != nil {
+		t.Fatalf("Error creating temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	dockerfile := `ARG VERSION=1
+FROM golang:1.15 AS builder
+RUN go build
+FROM --platform=linux/amd64 alpine:$VERSION
+FROM builder as test
+from nginx
+FROM scratch
+`
+	dockerfilePath := filepath.Join(dir, "Dockerfile")
+	err = ioutil.WriteFile(dockerfilePath, []byte(dockerfile), 0666)
+	assert.NilError(t, err)
+
+	images, err := getBaseImages(dockerfilePath)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, images, []string{"golang:1.15", "nginx"})
+}
+
+func TestGetSyncExcludePaths(t *testing.T) {
+	excludePaths := getSyncExcludePaths([]string{"node_modules", "# comment", "", "!dist/keep", "/.git", "**/*.log"}, "/tmp/abc/Dockerfile.dev")
+	assert.DeepEqual(t, excludePaths, []string{"/node_modules", "!/dist/keep", "/.git", "/**/*.log", "/Dockerfile.dev"})
+}
+
+func TestShellStartError(t *testing.T) {
+	buildPod := &k8sv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "devspace-build-kaniko", Namespace: "test"},
+		Spec: k8sv1.PodSpec{
+			Containers: []k8sv1.Container{{Name: "kaniko", Image: "gcr.io/kaniko-project/executor:v1.5.2"}},
+		},
+	}
+
+	err := shellStartError(buildPod, k8sv1.ContainerStatus{
+		Name: "kaniko",
+		State: k8sv1.ContainerState{
+			Terminated: &k8sv1.ContainerStateTerminated{Reason: "StartError", Message: `exec: "sh": executable file not found in $PATH`},
+		},
+	})
+	assert.Error(t, err, `kaniko pod test/devspace-build-kaniko container kaniko cannot start a shell with image gcr.io/kaniko-project/executor:v1.5.2: exec: "sh": executable file not found in $PATH (StartError). A reused build pod requires an image with a shell, e.g. the kaniko debug image gcr.io/kaniko-project/executor:v1.5.2-debug`)
+
+	err = shellStartError(buildPod, k8sv1.ContainerStatus{
+		Name: "kaniko",
+		State: k8sv1.ContainerState{
+			Waiting: &k8sv1.ContainerStateWaiting{Reason: "ContainerCreating"},
+		},
+	})
+	assert.NilError(t, err)
+}
+
+func TestBuildPodLock(t *testing.T) {
+	pods := fake.NewSimpleClientset().CoreV1().Pods("test")
+	buildPod, err := pods.Create(context.TODO(), &k8sv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: reusableBuildPodName, Namespace: "test"},
+	}, metav1.CreateOptions{})
+	assert.NilError(t, err)
+
+	// lock the pod for the first build
+	buildPod, err = lockBuildPod(pods, buildPod, "host/first")
+	assert.NilError(t, err)
+	assert.Equal(t, buildPod.Annotations[buildLockOwnerAnnotation], "host/first")
+
+	// a second build finds the pod busy
+	_, err = lockBuildPod(pods, buildPod, "host/second")
+	assert.Equal(t, err, errBuildPodBusy)
+
+	// only the owner can unlock the pod
+	err = unlockBuildPod(pods, reusableBuildPodName, "host/second")
+	assert.NilError(t, err)
+	buildPod, err = pods.Get(context.TODO(), reusableBuildPodName, metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, buildPod.Annotations[buildLockOwnerAnnotation], "host/first")
+
+	// the lock can be taken over after it expired
+	buildPod.Annotations[buildLockExpiryAnnotation] = time.Now().Add(-time.Minute).Format(time.RFC3339)
+	buildPod, err = lockBuildPod(pods, buildPod, "host/second")
+	assert.NilError(t, err)
+	assert.Equal(t, buildPod.Annotations[buildLockOwnerAnnotation], "host/second")
+
+	// the previous owner cannot renew the lock anymore
+	err = renewBuildPodLock(pods, reusableBuildPodName, "host/first")
+	assert.Error(t, err, "build pod devspace-build-kaniko is not locked by host/first anymore")
+
+	err = unlockBuildPod(pods, reusableBuildPodName, "host/second")
+	assert.NilError(t, err)
+	buildPod, err = pods.Get(context.TODO(), reusableBuildPodName, metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, buildPod.Annotations[buildLockOwnerAnnotation], "")
+}
@@ -0,0 +1,188 @@
+package ko
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/loft-sh/devspace/pkg/devspace/build/builder/helper"
+	"github.com/loft-sh/devspace/pkg/devspace/config"
+	"github.com/loft-sh/devspace/pkg/devspace/config/generated"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
+	logpkg "github.com/loft-sh/devspace/pkg/util/log"
+
+	dockerterm "github.com/moby/term"
+	"github.com/pkg/errors"
+)
+
+// EngineName is the name of the building engine
+const EngineName = "ko"
+
+// DockerRepoEnv is the environment variable ko reads the image repository from
+const DockerRepoEnv = "KO_DOCKER_REPO"
+
+var (
+	_, stdout, _ = dockerterm.StdStreams()
+)
+
+// Builder holds the necessary information to build go images with ko
+type Builder struct {
+	helper *helper.BuildHelper
+
+	skipPush                  bool
+	skipPushOnLocalKubernetes bool
+
+	digestWriter *helper.DigestWriter
+}
+
+// NewBuilder creates a new ko Builder instance
+func NewBuilder(config config.Config, kubeClient kubectl.Client, imageConfigName string, imageConf *latest.ImageConfig, imageTags []string, skipPush, skipPushOnLocalKubernetes bool) *Builder {
+	return &Builder{
+		helper:                    helper.NewBuildHelper(config, kubeClient, EngineName, imageConfigName, imageConf, imageTags),
+		skipPush:                  skipPush,
+		skipPushOnLocalKubernetes: skipPushOnLocalKubernetes,
+	}
+}
+
+// Build implements the interface
+func (b *Builder) Build(log logpkg.Logger) error {
+	return b.helper.Build(b, log)
+}
+
+// ShouldRebuild determines if an image has to be rebuilt
+func (b *Builder) ShouldRebuild(cache *generated.CacheConfig, forceRebuild bool) (bool, error) {
+	return b.helper.ShouldRebuildSource(cache, forceRebuild)
+}
+
+// Digest returns the digest of the pushed image
+func (b *Builder) Digest() string {
+	if b.digestWriter == nil {
+		return ""
+	}
+
+	return b.digestWriter.Digest()
+}
+
+// BuildImage builds the go application in the context path with ko and pushes the image. If pushing is skipped,
+// the image is loaded into the local docker daemon instead
+func (b *Builder) BuildImage(contextPath, dockerfilePath string, entrypoint []string, cmd []string, log logpkg.Logger) error {
+	if len(entrypoint) > 0 || len(cmd) > 0 || b.helper.ImageConf.InjectRestartHelper || len(b.helper.ImageConf.AppendDockerfileInstructions) > 0 {
+		log.Warnf("entrypoint, cmd, injectRestartHelper and appendDockerfileInstructions are not supported for images built with ko and will be ignored")
+	}
+
+	// We skip pushing when it is a local kubernetes cluster
+	koConfig := b.helper.ImageConf.Build.Ko
+	if b.skipPushOnLocalKubernetes && b.helper.KubeClient != nil && b.helper.KubeClient.IsLocalKubernetes() {
+		b.skipPush = true
+	}
+	skipPush := b.skipPush || koConfig.SkipPush
+
+	// Determine output writer
+	var writer io.Writer
+	if log == logpkg.GetInstance() {
+		writer = stdout
+	} else {
+		writer = log
+	}
+
+	// Without pushing, ko writes the image into a tarball that is loaded into the docker daemon
+	tarball := ""
+	if skipPush {
+		tempDir, err := ioutil.TempDir("", "devspace-ko")
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(tempDir)
+
+		tarball = filepath.Join(tempDir, "image.tar")
+	}
+
+	koCommand, args := getKoCommand(koConfig, b.helper.ImageTags, tarball)
+	log.Infof("Build %s:%s with '%s %s'", b.helper.ImageName, b.helper.ImageTags[0], koCommand, strings.Join(args, " "))
+
+	// ko prints the pushed image with its digest, which we catch here
+	out := writer
+	if !skipPush {
+		b.digestWriter = helper.NewDigestWriter(writer, b.helper.ImageName)
+		out = b.digestWriter
+	}
+
+	cmdExec := exec.Command(koCommand, args...)
+	cmdExec.Dir = contextPath
+	cmdExec.Env = append(os.Environ(), getEnv(koConfig, b.helper.ImageName)...)
+	cmdExec.Stdout = out
+	cmdExec.Stderr = writer
+	err := cmdExec.Run()
+	if err != nil {
+		return errors.Errorf("error building image with ko: %v", err)
+	}
+
+	if skipPush {
+		cmdExec = exec.Command("docker", "load", "--input", tarball)
+		cmdExec.Stdout = writer
+		cmdExec.Stderr = writer
+		err = cmdExec.Run()
+		if err != nil {
+			return errors.Errorf("error loading image into docker daemon: %v", err)
+		}
+
+		// Side-load the image into the nodes of a local cluster that does not share the docker daemon
+		if b.helper.KubeClient != nil && b.helper.KubeClient.IsLocalKubernetes() {
+			images := []string{}
+			for _, tag := range b.helper.ImageTags {
+				images = append(images, b.helper.ImageName+":"+tag)
+			}
+
+			return helper.LoadImages(b.helper.KubeClient.CurrentContext(), images, false, log)
+		}
+	}
+
+	return nil
+}
+
+// getKoCommand returns the command and the arguments to build the image with ko. If tarball is not empty,
+// the image is not pushed and written into the tarball instead
+func getKoCommand(koConfig *latest.KoConfig, imageTags []string, tarball string) (string, []string) {
+	commandParts := []string{"ko"}
+	if len(koConfig.Command) > 0 {
+		commandParts = koConfig.Command
+	}
+
+	importPath := "."
+	if koConfig.ImportPath != "" {
+		importPath = koConfig.ImportPath
+	}
+
+	args := append([]string{}, commandParts[1:]...)
+	args = append(args, "build", importPath, "--bare", "--tags", strings.Join(imageTags, ","))
+	if len(koConfig.Platforms) > 0 {
+		args = append(args, "--platform", strings.Join(koConfig.Platforms, ","))
+	}
+	if tarball != "" {
+		args = append(args, "--push=false", "--tarball", tarball)
+	}
+
+	args = append(args, koConfig.Args...)
+	return commandParts[0], args
+}
+
+// getEnv returns the environment variables for ko
+func getEnv(koConfig *latest.KoConfig, imageName string) []string {
+	env := []string{DockerRepoEnv + "=" + imageName}
+
+	keys := []string{}
+	for key := range koConfig.Env {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		env = append(env, key+"="+koConfig.Env[key])
+	}
+
+	return env
+}
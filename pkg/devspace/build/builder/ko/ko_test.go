@@ -0,0 +1,55 @@
+package ko
+
+import (
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"gotest.tools/assert"
+)
+
+type getKoCommandTestCase struct {
+	name string
+
+	koConfig  *latest.KoConfig
+	imageTags []string
+	tarball   string
+
+	expectedCommand string
+	expectedArgs    []string
+}
+
+func TestGetKoCommand(t *testing.T) {
+	testCases := []getKoCommandTestCase{
+		{
+			name:            "Defaults",
+			koConfig:        &latest.KoConfig{},
+			imageTags:       []string{"tag1", "tag2"},
+			expectedCommand: "ko",
+			expectedArgs:    []string{"build", ".", "--bare", "--tags", "tag1,tag2"},
+		},
+		{
+			name: "All options without push",
+			koConfig: &latest.KoConfig{
+				ImportPath: "./cmd/server",
+				Platforms:  []string{"linux/amd64", "linux/arm64"},
+				Args:       []string{"--sbom=none"},
+				Command:    []string{"go", "run", "github.com/google/ko"},
+			},
+			imageTags:       []string{"tag1"},
+			tarball:         "/tmp/image.tar",
+			expectedCommand: "go",
+			expectedArgs:    []string{"run", "github.com/google/ko", "build", "./cmd/server", "--bare", "--tags", "tag1", "--platform", "linux/amd64,linux/arm64", "--push=false", "--tarball", "/tmp/image.tar", "--sbom=none"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		command, args := getKoCommand(testCase.koConfig, testCase.imageTags, testCase.tarball)
+		assert.Equal(t, command, testCase.expectedCommand, "Unexpected command in testCase %s", testCase.name)
+		assert.DeepEqual(t, args, testCase.expectedArgs)
+	}
+}
+
+func TestGetEnv(t *testing.T) {
+	env := getEnv(&latest.KoConfig{Env: map[string]string{"GOFLAGS": "-mod=vendor", "CGO_ENABLED": "0"}}, "myrepo/myimage")
+	assert.DeepEqual(t, env, []string{DockerRepoEnv + "=myrepo/myimage", "CGO_ENABLED=0", "GOFLAGS=-mod=vendor"})
+}
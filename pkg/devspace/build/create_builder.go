@@ -3,11 +3,14 @@ package build
 import (
 	"context"
 	"github.com/loft-sh/devspace/pkg/devspace/build/builder/buildkit"
+	"github.com/loft-sh/devspace/pkg/devspace/build/builder/buildpacks"
 
 	"github.com/loft-sh/devspace/pkg/devspace/build/builder"
 	"github.com/loft-sh/devspace/pkg/devspace/build/builder/custom"
 	"github.com/loft-sh/devspace/pkg/devspace/build/builder/docker"
+	"github.com/loft-sh/devspace/pkg/devspace/build/builder/jib"
 	"github.com/loft-sh/devspace/pkg/devspace/build/builder/kaniko"
+	"github.com/loft-sh/devspace/pkg/devspace/build/builder/ko"
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
 	dockerclient "github.com/loft-sh/devspace/pkg/devspace/docker"
 	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
@@ -24,6 +27,26 @@ func (c *controller) createBuilder(imageConfigName string, imageConf *latest.Ima
 
 	if imageConf.Build != nil && imageConf.Build.Custom != nil {
 		builder = custom.NewBuilder(imageConfigName, imageConf, imageTags)
+	} else if imageConf.Build != nil && imageConf.Build.Ko != nil {
+		builder = ko.NewBuilder(c.config, c.client, imageConfigName, imageConf, imageTags, options.SkipPush, options.SkipPushOnLocalKubernetes)
+	} else if imageConf.Build != nil && imageConf.Build.Jib != nil {
+		builder = jib.NewBuilder(c.config, c.client, imageConfigName, imageConf, imageTags, options.SkipPush, options.SkipPushOnLocalKubernetes)
+	} else if imageConf.Build != nil && imageConf.Build.Buildpacks != nil {
+		dockerClient, err := dockerclient.NewClient(log)
+		if err != nil {
+			return nil, errors.Errorf("Error creating docker client: %v", err)
+		}
+
+		// pack needs a running docker daemon
+		_, err = dockerClient.Ping(context.Background())
+		if err != nil {
+			return nil, errors.Errorf("Couldn't reach docker daemon: %v. Building with buildpacks requires a running docker daemon", err)
+		}
+
+		builder, err = buildpacks.NewBuilder(c.config, dockerClient, c.client, imageConfigName, imageConf, imageTags, options.SkipPush, options.SkipPushOnLocalKubernetes)
+		if err != nil {
+			return nil, errors.Errorf("Error creating buildpacks builder: %v", err)
+		}
 	} else if imageConf.Build != nil && imageConf.Build.BuildKit != nil {
 		log.StartWait("Creating BuildKit builder")
 		defer log.StopWait()
@@ -93,6 +116,15 @@ func (c *controller) createBuilder(imageConfigName string, imageConf *latest.Ima
 	return builder, nil
 }
 
+// getDigest returns the digest of the pushed image if the builder is able to report it
+func getDigest(b builder.Interface) string {
+	if digestReporter, ok := b.(builder.DigestReporter); ok {
+		return digestReporter.Digest()
+	}
+
+	return ""
+}
+
 func convertDockerConfigToKanikoConfig(dockerConfig *latest.ImageConfig) *latest.ImageConfig {
 	kanikoBuildOptions := &latest.KanikoConfig{
 		Cache: ptr.Bool(true),
@@ -0,0 +1,206 @@
+package build
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/loft-sh/devspace/pkg/devspace/build/builder"
+	"github.com/loft-sh/devspace/pkg/devspace/build/builder/buildkit"
+	"github.com/loft-sh/devspace/pkg/devspace/build/builder/buildpacks"
+	"github.com/loft-sh/devspace/pkg/devspace/build/builder/custom"
+	"github.com/loft-sh/devspace/pkg/devspace/build/builder/docker"
+	"github.com/loft-sh/devspace/pkg/devspace/build/builder/jib"
+	"github.com/loft-sh/devspace/pkg/devspace/build/builder/kaniko"
+	"github.com/loft-sh/devspace/pkg/devspace/build/builder/ko"
+	"github.com/loft-sh/devspace/pkg/devspace/config/generated"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	logpkg "github.com/loft-sh/devspace/pkg/util/log"
+)
+
+// Report holds information about every image the build controller has processed
+type Report struct {
+	Images []*ImageReport `json:"images"`
+
+	m sync.Mutex
+}
+
+// ImageReport holds the build information about a single image
+type ImageReport struct {
+	// ImageConfigName is the key of the image in the images section
+	ImageConfigName string `json:"imageConfigName"`
+
+	// ImageName is the image name without tag
+	ImageName string `json:"imageName"`
+
+	// Builder is the engine that was used to build the image
+	Builder string `json:"builder,omitempty"`
+
+	// Skipped is true if the image was not rebuilt
+	Skipped bool `json:"skipped"`
+
+	// Reason describes why an image was rebuilt or skipped
+	Reason string `json:"reason,omitempty"`
+
+	// Duration is the time in seconds it took to build and push the image
+	Duration float64 `json:"duration"`
+
+	// Tags are the tags the image was built with
+	Tags []string `json:"tags,omitempty"`
+
+	// Pushed is true if the image was pushed to the registry
+	Pushed bool `json:"pushed"`
+
+	// Digest is the registry digest of the pushed image if the builder was able to retrieve it
+	Digest string `json:"digest,omitempty"`
+
+	// Findings are the problems the image verification has found
+	Findings []string `json:"findings,omitempty"`
+
+	// Error holds the build error if the build has failed
+	Error string `json:"error,omitempty"`
+}
+
+// Add adds a new image report in a thread safe way
+func (r *Report) Add(imageReport *ImageReport) {
+	if r == nil {
+		return
+	}
+
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	r.Images = append(r.Images, imageReport)
+}
+
+// Print prints the report as table to the given logger
+func (r *Report) Print(log logpkg.Logger) {
+	if r == nil || len(r.Images) == 0 {
+		return
+	}
+
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	values := [][]string{}
+	for _, image := range r.Images {
+		status := "built"
+		if image.Error != "" {
+			status = "failed"
+		} else if image.Skipped {
+			status = "skipped"
+		} else if image.Pushed {
+			status = "pushed"
+		}
+
+		duration := ""
+		if image.Skipped == false {
+			duration = (time.Duration(image.Duration * float64(time.Second))).Round(time.Millisecond * 100).String()
+		}
+
+		values = append(values, []string{
+			image.ImageConfigName,
+			image.Builder,
+			status,
+			image.Reason,
+			duration,
+			strings.Join(image.Tags, ","),
+		})
+	}
+
+	logpkg.PrintTable(log, []string{"IMAGE", "BUILDER", "STATUS", "REASON", "DURATION", "TAGS"}, values)
+
+	for _, image := range r.Images {
+		for _, finding := range image.Findings {
+			log.Warnf("%s: %s", image.ImageConfigName, finding)
+		}
+	}
+}
+
+// builderName returns the name of the engine behind the given builder
+func builderName(b builder.Interface) string {
+	switch b.(type) {
+	case *custom.Builder:
+		return "custom"
+	case *docker.Builder:
+		return docker.EngineName
+	case *buildkit.Builder:
+		return buildkit.EngineName
+	case *kaniko.Builder:
+		return kaniko.EngineName
+	case *buildpacks.Builder:
+		return buildpacks.EngineName
+	case *ko.Builder:
+		return ko.EngineName
+	case *jib.Builder:
+		return jib.EngineName
+	}
+
+	return "unknown"
+}
+
+// rebuildReason compares the image cache before and after the ShouldRebuild check
+// and returns a human readable reason why an image will be rebuilt
+func rebuildReason(imageConf *latest.ImageConfig, before, after *generated.ImageCache, forceRebuild bool) string {
+	if forceRebuild {
+		return "forced rebuild"
+	} else if imageConf.RebuildStrategy == latest.RebuildStrategyAlways {
+		return "rebuild strategy always"
+	} else if before.Tag == "" {
+		return "no previous build"
+	}
+
+	changed := []string{}
+	if before.DockerfileHash != after.DockerfileHash {
+		changed = append(changed, "dockerfile")
+	}
+	if before.ContextHash != after.ContextHash {
+		changed = append(changed, "context")
+	}
+	if before.ImageConfigHash != after.ImageConfigHash {
+		changed = append(changed, "image config")
+	}
+	if before.EntrypointHash != after.EntrypointHash {
+		changed = append(changed, "entrypoint")
+	}
+	if before.CustomFilesHash != after.CustomFilesHash {
+		changed = append(changed, "custom files")
+	}
+	if len(changed) > 0 {
+		return fmt.Sprintf("%s changed", strings.Join(changed, ", "))
+	}
+
+	if imageConf.Build != nil && imageConf.Build.Custom != nil && len(imageConf.Build.Custom.OnChange) == 0 {
+		return "no custom.onChange defined"
+	}
+
+	return "kube context changed"
+}
+
+// shouldPush determines if the builder will try to push the image after building it
+func (c *controller) shouldPush(imageConf *latest.ImageConfig, options *Options) bool {
+	if options.SkipPush {
+		return false
+	} else if options.SkipPushOnLocalKubernetes && c.client != nil && c.client.IsLocalKubernetes() {
+		return false
+	}
+
+	if imageConf.Build != nil {
+		if imageConf.Build.Custom != nil {
+			return false
+		} else if imageConf.Build.Docker != nil && imageConf.Build.Docker.SkipPush {
+			return false
+		} else if imageConf.Build.BuildKit != nil && imageConf.Build.BuildKit.SkipPush {
+			return false
+		} else if imageConf.Build.Buildpacks != nil && imageConf.Build.Buildpacks.SkipPush {
+			return false
+		} else if imageConf.Build.Ko != nil && imageConf.Build.Ko.SkipPush {
+			return false
+		} else if imageConf.Build.Jib != nil && imageConf.Build.Jib.SkipPush {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,80 @@
+package build
+
+import (
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/generated"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"gotest.tools/assert"
+)
+
+type rebuildReasonTestCase struct {
+	name string
+
+	imageConf    *latest.ImageConfig
+	before       *generated.ImageCache
+	after        *generated.ImageCache
+	forceRebuild bool
+
+	expectedReason string
+}
+
+func TestRebuildReason(t *testing.T) {
+	testCases := []rebuildReasonTestCase{
+		{
+			name:           "Forced rebuild",
+			imageConf:      &latest.ImageConfig{},
+			before:         &generated.ImageCache{Tag: "abc"},
+			after:          &generated.ImageCache{Tag: "abc"},
+			forceRebuild:   true,
+			expectedReason: "forced rebuild",
+		},
+		{
+			name:           "First build",
+			imageConf:      &latest.ImageConfig{},
+			before:         &generated.ImageCache{},
+			after:          &generated.ImageCache{DockerfileHash: "123"},
+			expectedReason: "no previous build",
+		},
+		{
+			name:      "Dockerfile and context changed",
+			imageConf: &latest.ImageConfig{},
+			before: &generated.ImageCache{
+				Tag:            "abc",
+				DockerfileHash: "1",
+				ContextHash:    "1",
+			},
+			after: &generated.ImageCache{
+				Tag:            "abc",
+				DockerfileHash: "2",
+				ContextHash:    "2",
+			},
+			expectedReason: "dockerfile, context changed",
+		},
+		{
+			name: "Custom builder without onChange",
+			imageConf: &latest.ImageConfig{
+				Build: &latest.BuildConfig{
+					Custom: &latest.CustomConfig{},
+				},
+			},
+			before:         &generated.ImageCache{Tag: "abc"},
+			after:          &generated.ImageCache{Tag: "abc"},
+			expectedReason: "no custom.onChange defined",
+		},
+	}
+
+	for _, testCase := range testCases {
+		reason := rebuildReason(testCase.imageConf, testCase.before, testCase.after, testCase.forceRebuild)
+		assert.Equal(t, reason, testCase.expectedReason, "Unexpected reason in testCase %s", testCase.name)
+	}
+}
+
+func TestReportAdd(t *testing.T) {
+	var nilReport *Report
+	nilReport.Add(&ImageReport{ImageConfigName: "test"})
+
+	report := &Report{}
+	report.Add(&ImageReport{ImageConfigName: "test"})
+	assert.Equal(t, len(report.Images), 1)
+}
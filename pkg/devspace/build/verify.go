@@ -0,0 +1,58 @@
+package build
+
+import (
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/loft-sh/devspace/pkg/devspace/build/builder"
+	"github.com/loft-sh/devspace/pkg/devspace/build/verify"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/devspace/hook"
+	logpkg "github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/pkg/errors"
+)
+
+// prepareVerify registers the image verification at the builder, which verifies the image after building and
+// before pushing it. Builders that push the image while building cannot verify it and return an error
+func (c *controller) prepareVerify(b builder.Interface, imageConfigName string, imageConf *latest.ImageConfig, imageTag string, imageReport *ImageReport) error {
+	if imageConf.Verify == nil {
+		return nil
+	}
+
+	verifier, ok := b.(builder.Verifier)
+	if !ok {
+		return errors.Errorf("image %s cannot be verified before it is pushed, verify is only supported with the docker and buildpacks builders", imageConfigName)
+	}
+
+	verifier.SetVerifyFunc(func(imageConfig *container.Config, log logpkg.Logger) error {
+		return c.verifyImage(imageConfigName, imageConf, imageConf.Image+":"+imageTag, imageConfig, imageReport, log)
+	})
+	return nil
+}
+
+// verifyImage verifies the built image, records the findings in the report and executes the after verify hooks
+func (c *controller) verifyImage(imageConfigName string, imageConf *latest.ImageConfig, image string, imageConfig *container.Config, imageReport *ImageReport, log logpkg.Logger) error {
+	findings := verify.Verify(imageConf.Verify, image, imageConfig, log)
+	imageReport.Findings = findings
+
+	// Execute after images verify hook
+	err := c.hookExecuter.Execute(hook.AfterVerify, hook.StageImages, imageConfigName, hook.Context{Client: c.client}, log)
+	if err != nil {
+		return err
+	}
+
+	if len(findings) > 0 {
+		if imageConf.Verify.WarnOnly {
+			for _, finding := range findings {
+				log.Warnf("Verification of image %s: %s", image, finding)
+			}
+
+			return nil
+		}
+
+		return errors.Errorf("image %s failed verification: %s", image, strings.Join(findings, ", "))
+	}
+
+	log.Donef("Image %s verified successfully", image)
+	return nil
+}
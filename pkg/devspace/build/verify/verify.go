@@ -0,0 +1,106 @@
+package verify
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+	dockerterm "github.com/docker/docker/pkg/term"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/util/command"
+	logpkg "github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/loft-sh/devspace/pkg/util/shell"
+)
+
+// ImageEnv is the environment variable that holds the image for the verify command
+const ImageEnv = "DEVSPACE_IMAGE"
+
+var (
+	_, stdout, _ = dockerterm.StdStreams()
+)
+
+// Verify runs the verify command and the policy checks against the given image and returns the findings.
+// If imageConfig is nil, the policy checks are skipped
+func Verify(verifyConfig *latest.VerifyConfig, image string, imageConfig *container.Config, log logpkg.Logger) []string {
+	findings := []string{}
+	if verifyConfig.Command != "" {
+		// Determine output writer
+		var writer io.Writer
+		if log == logpkg.GetInstance() {
+			writer = stdout
+		} else {
+			writer = log
+		}
+
+		log.Infof("Verify image %s with command '%s'", image, verifyConfig.Command)
+		env := map[string]string{ImageEnv: image}
+
+		var err error
+		if len(verifyConfig.Args) == 0 {
+			err = shell.ExecuteShellCommand(verifyConfig.Command, writer, writer, env)
+		} else {
+			err = command.ExecuteCommandWithEnv(verifyConfig.Command, verifyConfig.Args, writer, writer, env)
+		}
+		if err != nil {
+			findings = append(findings, fmt.Sprintf("verify command failed: %v", err))
+		}
+	}
+
+	if verifyConfig.Policy != nil {
+		if imageConfig == nil {
+			findings = append(findings, "image config is not available for the policy checks")
+		} else {
+			findings = append(findings, CheckPolicy(verifyConfig.Policy, imageConfig)...)
+		}
+	}
+
+	return findings
+}
+
+// CheckPolicy checks the image config against the policy and returns the violations
+func CheckPolicy(policy *latest.VerifyPolicy, imageConfig *container.Config) []string {
+	findings := []string{}
+	if policy.DisallowRootUser {
+		user := strings.Split(imageConfig.User, ":")[0]
+		if user == "" || user == "root" || user == "0" {
+			findings = append(findings, "image runs as root user")
+		}
+	}
+
+	if policy.AllowedPorts != nil {
+		allowedPorts := map[string]bool{}
+		for _, port := range policy.AllowedPorts {
+			allowedPorts[normalizePort(port)] = true
+		}
+
+		exposedPorts := []string{}
+		for port := range imageConfig.ExposedPorts {
+			exposedPorts = append(exposedPorts, string(port))
+		}
+		sort.Strings(exposedPorts)
+		for _, port := range exposedPorts {
+			if !allowedPorts[normalizePort(port)] {
+				findings = append(findings, fmt.Sprintf("image exposes port %s which is not allowed", port))
+			}
+		}
+	}
+
+	for _, label := range policy.RequiredLabels {
+		if _, ok := imageConfig.Labels[label]; !ok {
+			findings = append(findings, fmt.Sprintf("image is missing required label %s", label))
+		}
+	}
+
+	return findings
+}
+
+func normalizePort(port string) string {
+	port = strings.ToLower(strings.TrimSpace(port))
+	if !strings.Contains(port, "/") {
+		return port + "/tcp"
+	}
+
+	return port
+}
@@ -0,0 +1,63 @@
+package verify
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/go-connections/nat"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"gotest.tools/assert"
+)
+
+type checkPolicyTestCase struct {
+	name string
+
+	policy      *latest.VerifyPolicy
+	imageConfig *container.Config
+
+	expectedFindings []string
+}
+
+func TestCheckPolicy(t *testing.T) {
+	testCases := []checkPolicyTestCase{
+		{
+			name:             "Empty policy",
+			policy:           &latest.VerifyPolicy{},
+			imageConfig:      &container.Config{},
+			expectedFindings: []string{},
+		},
+		{
+			name:             "Root user",
+			policy:           &latest.VerifyPolicy{DisallowRootUser: true},
+			imageConfig:      &container.Config{User: "0:0"},
+			expectedFindings: []string{"image runs as root user"},
+		},
+		{
+			name:             "Non root user",
+			policy:           &latest.VerifyPolicy{DisallowRootUser: true},
+			imageConfig:      &container.Config{User: "1000"},
+			expectedFindings: []string{},
+		},
+		{
+			name:   "Exposed ports",
+			policy: &latest.VerifyPolicy{AllowedPorts: []string{"8080", "53/UDP"}},
+			imageConfig: &container.Config{ExposedPorts: nat.PortSet{
+				"8080/tcp": struct{}{},
+				"53/udp":   struct{}{},
+				"22/tcp":   struct{}{},
+			}},
+			expectedFindings: []string{"image exposes port 22/tcp which is not allowed"},
+		},
+		{
+			name:             "Required labels",
+			policy:           &latest.VerifyPolicy{RequiredLabels: []string{"maintainer", "version"}},
+			imageConfig:      &container.Config{Labels: map[string]string{"maintainer": "me"}},
+			expectedFindings: []string{"image is missing required label version"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		findings := CheckPolicy(testCase.policy, testCase.imageConfig)
+		assert.DeepEqual(t, findings, testCase.expectedFindings)
+	}
+}
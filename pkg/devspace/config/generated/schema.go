@@ -45,6 +45,7 @@ type ImageCache struct {
 
 	ImageName string `yaml:"imageName,omitempty"`
 	Tag       string `yaml:"tag,omitempty"`
+	Digest    string `yaml:"digest,omitempty"`
 }
 
 // DeploymentCache holds the information about a specific deployment
@@ -54,6 +55,22 @@ type DeploymentCache struct {
 	HelmOverridesHash   string `yaml:"helmOverridesHash,omitempty"`
 	HelmChartHash       string `yaml:"helmChartHash,omitempty"`
 	HelmReleaseRevision string `yaml:"helmReleaseRevision,omitempty"`
+	HelmValuesFromHash  string `yaml:"helmValuesFromHash,omitempty"`
 
 	KubectlManifestsHash string `yaml:"kubectlManifestsHash,omitempty"`
+	KubectlRevision      int    `yaml:"kubectlRevision,omitempty"`
+
+	KubectlObjects          []KubectlObject `yaml:"kubectlObjects,omitempty"`
+	KubectlObjectsContext   string          `yaml:"kubectlObjectsContext,omitempty"`
+	KubectlObjectsNamespace string          `yaml:"kubectlObjectsNamespace,omitempty"`
+
+	CommandFilesHash string `yaml:"commandFilesHash,omitempty"`
+}
+
+// KubectlObject identifies an object that was applied by a kubectl or component deployment
+type KubectlObject struct {
+	APIVersion string `yaml:"apiVersion"`
+	Kind       string `yaml:"kind"`
+	Namespace  string `yaml:"namespace,omitempty"`
+	Name       string `yaml:"name"`
 }
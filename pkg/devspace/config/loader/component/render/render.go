@@ -0,0 +1,874 @@
+package render
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+
+	ghodssyaml "github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	yaml "gopkg.in/yaml.v2"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	// NameLabel is the label that marks all pods of a component
+	NameLabel = "app.kubernetes.io/name"
+	// NameLabelValue is the value of the name label
+	NameLabelValue = "devspace-app"
+	// ComponentLabel is the label that holds the name of the component
+	ComponentLabel = "app.kubernetes.io/component"
+	// ManagedByLabel is the label that marks the objects as managed by devspace
+	ManagedByLabel = "app.kubernetes.io/managed-by"
+	// ManagedByLabelValue is the value of the managed by label
+	ManagedByLabelValue = "DevSpace"
+
+	// TLSClusterIssuerAnnotation is the cert-manager annotation that is set if ingress.tlsClusterIssuer is specified
+	TLSClusterIssuerAnnotation = "cert-manager.io/cluster-issuer"
+	// IngressClassAnnotation is the annotation that is set if ingress.ingressClass is specified
+	IngressClassAnnotation = "kubernetes.io/ingress.class"
+)
+
+const (
+	defaultTerminationGracePeriodSeconds = 5
+	defaultVolumeSize                    = "5Gi"
+)
+
+// Component renders the component config into kubernetes objects. A component is rendered into a deployment or,
+// if it has persistent volumes or a service name, into a stateful set. Additionally a service, an ingress and
+// a horizontal pod autoscaler are rendered if they are configured
+func Component(name string, component *latest.ComponentConfig) ([]*unstructured.Unstructured, error) {
+	if component == nil {
+		return nil, errors.New("component is nil")
+	}
+
+	r := &renderer{
+		name:      name,
+		component: component,
+	}
+
+	return r.render()
+}
+
+type renderer struct {
+	name      string
+	component *latest.ComponentConfig
+}
+
+func (r *renderer) render() ([]*unstructured.Unstructured, error) {
+	objects := []runtime.Object{}
+
+	// Service
+	service, err := r.service()
+	if err != nil {
+		return nil, err
+	} else if service != nil {
+		objects = append(objects, service)
+	}
+
+	// Deployment or stateful set, components without containers only consist of a service and ingress
+	if len(r.component.Containers) > 0 {
+		workloads, err := r.workloads(service)
+		if err != nil {
+			return nil, err
+		}
+
+		objects = append(objects, workloads...)
+	} else if len(r.component.InitContainers) > 0 {
+		return nil, errors.New("containers: at least one container is required if initContainers are defined")
+	}
+
+	// Ingress
+	ingress, err := r.ingress(service)
+	if err != nil {
+		return nil, err
+	} else if ingress != nil {
+		objects = append(objects, ingress)
+	}
+
+	return toUnstructured(objects)
+}
+
+// workloads returns the deployment or stateful set of the component and its horizontal pod autoscaler
+func (r *renderer) workloads(service *corev1.Service) ([]runtime.Object, error) {
+	objects := []runtime.Object{}
+	podTemplate, err := r.podTemplate()
+	if err != nil {
+		return nil, err
+	}
+
+	volumeClaimTemplates, err := r.volumeClaimTemplates()
+	if err != nil {
+		return nil, err
+	}
+
+	workloadKind := "Deployment"
+	if len(volumeClaimTemplates) > 0 || r.component.ServiceName != "" {
+		workloadKind = "StatefulSet"
+		serviceName := r.component.ServiceName
+		if serviceName == "" {
+			serviceName = r.name + "-headless"
+			objects = append(objects, r.headlessService(serviceName, service))
+		}
+
+		objects = append(objects, r.statefulSet(podTemplate, volumeClaimTemplates, serviceName))
+	} else {
+		objects = append(objects, r.deployment(podTemplate))
+	}
+
+	autoscaler, err := r.autoscaler(workloadKind)
+	if err != nil {
+		return nil, err
+	} else if autoscaler != nil {
+		objects = append(objects, autoscaler)
+	}
+
+	return objects, nil
+}
+
+func (r *renderer) selectorLabels() map[string]string {
+	return map[string]string{
+		NameLabel:      NameLabelValue,
+		ComponentLabel: r.name,
+	}
+}
+
+func (r *renderer) objectMeta(name string, labels map[string]string, annotations map[string]string) metav1.ObjectMeta {
+	objectLabels := r.selectorLabels()
+	objectLabels[ManagedByLabel] = ManagedByLabelValue
+	for k, v := range labels {
+		objectLabels[k] = v
+	}
+
+	return metav1.ObjectMeta{
+		Name:        name,
+		Labels:      objectLabels,
+		Annotations: annotations,
+	}
+}
+
+func (r *renderer) replicas() *int32 {
+	replicas := int32(1)
+	if r.component.Replicas != nil {
+		replicas = int32(*r.component.Replicas)
+	}
+
+	return &replicas
+}
+
+func (r *renderer) deployment(podTemplate *corev1.PodTemplateSpec) *appsv1.Deployment {
+	strategy := appsv1.DeploymentStrategy{
+		Type: appsv1.RecreateDeploymentStrategyType,
+	}
+	if r.component.RollingUpdate != nil && r.component.RollingUpdate.Enabled != nil && *r.component.RollingUpdate.Enabled {
+		strategy.Type = appsv1.RollingUpdateDeploymentStrategyType
+		strategy.RollingUpdate = &appsv1.RollingUpdateDeployment{}
+		if r.component.RollingUpdate.MaxSurge != "" {
+			maxSurge := intstr.Parse(r.component.RollingUpdate.MaxSurge)
+			strategy.RollingUpdate.MaxSurge = &maxSurge
+		}
+		if r.component.RollingUpdate.MaxUnavailable != "" {
+			maxUnavailable := intstr.Parse(r.component.RollingUpdate.MaxUnavailable)
+			strategy.RollingUpdate.MaxUnavailable = &maxUnavailable
+		}
+	}
+
+	return &appsv1.Deployment{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+		ObjectMeta: r.objectMeta(r.name, r.component.Labels, r.component.Annotations),
+		Spec: appsv1.DeploymentSpec{
+			Replicas: r.replicas(),
+			Strategy: strategy,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: r.selectorLabels(),
+			},
+			Template: *podTemplate,
+		},
+	}
+}
+
+func (r *renderer) statefulSet(podTemplate *corev1.PodTemplateSpec, volumeClaimTemplates []corev1.PersistentVolumeClaim, serviceName string) *appsv1.StatefulSet {
+	updateStrategy := appsv1.StatefulSetUpdateStrategy{}
+	if r.component.RollingUpdate != nil && r.component.RollingUpdate.Enabled != nil && *r.component.RollingUpdate.Enabled {
+		updateStrategy.Type = appsv1.RollingUpdateStatefulSetStrategyType
+		if r.component.RollingUpdate.Partition != nil {
+			partition := int32(*r.component.RollingUpdate.Partition)
+			updateStrategy.RollingUpdate = &appsv1.RollingUpdateStatefulSetStrategy{
+				Partition: &partition,
+			}
+		}
+	}
+
+	return &appsv1.StatefulSet{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "StatefulSet"},
+		ObjectMeta: r.objectMeta(r.name, r.component.Labels, r.component.Annotations),
+		Spec: appsv1.StatefulSetSpec{
+			Replicas:       r.replicas(),
+			UpdateStrategy: updateStrategy,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: r.selectorLabels(),
+			},
+			Template:             *podTemplate,
+			VolumeClaimTemplates: volumeClaimTemplates,
+			ServiceName:          serviceName,
+			PodManagementPolicy:  appsv1.PodManagementPolicyType(r.component.PodManagementPolicy),
+		},
+	}
+}
+
+func (r *renderer) podTemplate() (*corev1.PodTemplateSpec, error) {
+	c := r.component
+	spec := corev1.PodSpec{
+		NodeName:                     c.NodeName,
+		AutomountServiceAccountToken: c.AutomountServiceAccountToken,
+		EnableServiceLinks:           c.EnableServiceLinks,
+		SetHostnameAsFQDN:            c.SetHostnameAsFQDN,
+		ShareProcessNamespace:        c.ShareProcessNamespace,
+		ActiveDeadlineSeconds:        int64Ptr(c.ActiveDeadlineSeconds),
+		Priority:                     int32Ptr(c.Priority),
+		PriorityClassName:            stringValue(c.PriorityClassName),
+		RuntimeClassName:             c.RuntimeClassName,
+		SchedulerName:                stringValue(c.SchedulerName),
+		DeprecatedServiceAccount:     stringValue(c.ServiceAccount),
+		ServiceAccountName:           stringValue(c.ServiceAccountName),
+		Hostname:                     stringValue(c.Hostname),
+		Subdomain:                    stringValue(c.Subdomain),
+		HostIPC:                      boolValue(c.HostIPC),
+		HostNetwork:                  boolValue(c.HostNetwork),
+		HostPID:                      boolValue(c.HostPID),
+		DNSPolicy:                    corev1.DNSPolicy(stringValue(c.DnsPolicy)),
+		RestartPolicy:                corev1.RestartPolicy(stringValue(c.RestartPolicy)),
+	}
+	if c.PreemptionPolicy != nil {
+		preemptionPolicy := corev1.PreemptionPolicy(*c.PreemptionPolicy)
+		spec.PreemptionPolicy = &preemptionPolicy
+	}
+
+	terminationGracePeriodSeconds := int64(defaultTerminationGracePeriodSeconds)
+	if c.TerminationGracePeriodSeconds != nil {
+		terminationGracePeriodSeconds = int64(*c.TerminationGracePeriodSeconds)
+	}
+	spec.TerminationGracePeriodSeconds = &terminationGracePeriodSeconds
+
+	for _, pullSecret := range c.PullSecrets {
+		if pullSecret != nil && *pullSecret != "" {
+			spec.ImagePullSecrets = append(spec.ImagePullSecrets, corev1.LocalObjectReference{Name: *pullSecret})
+		}
+	}
+
+	// Convert the fields that are passed through to the pod spec
+	for _, conversion := range []conversion{
+		{"tolerations", c.Tolerations, &spec.Tolerations},
+		{"affinity", c.Affinity, &spec.Affinity},
+		{"nodeSelector", c.NodeSelector, &spec.NodeSelector},
+		{"dnsConfig", c.DNSConfig, &spec.DNSConfig},
+		{"hostAliases", c.HostAliases, &spec.HostAliases},
+		{"overhead", c.Overhead, &spec.Overhead},
+		{"readinessGates", c.ReadinessGates, &spec.ReadinessGates},
+		{"securityContext", c.SecurityContext, &spec.SecurityContext},
+		{"topologySpreadConstraints", c.TopologySpreadConstraints, &spec.TopologySpreadConstraints},
+		{"ephemeralContainers", c.EphemeralContainers, &spec.EphemeralContainers},
+	} {
+		err := convert(conversion.field, conversion.value, conversion.into)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Volumes
+	for i, volume := range c.Volumes {
+		if volume == nil || volume.Name == "" {
+			return nil, errors.Errorf("volumes[%d].name is required", i)
+		} else if volume.ConfigMap != nil && volume.Secret != nil {
+			return nil, errors.Errorf("volumes[%d]: configMap and secret cannot be used together", i)
+		}
+
+		if volume.ConfigMap != nil {
+			source := &corev1.ConfigMapVolumeSource{}
+			err := convert(fmt.Sprintf("volumes[%d].configMap", i), volume.ConfigMap, source)
+			if err != nil {
+				return nil, err
+			}
+
+			spec.Volumes = append(spec.Volumes, corev1.Volume{Name: volume.Name, VolumeSource: corev1.VolumeSource{ConfigMap: source}})
+		} else if volume.Secret != nil {
+			source := &corev1.SecretVolumeSource{}
+			err := convert(fmt.Sprintf("volumes[%d].secret", i), volume.Secret, source)
+			if err != nil {
+				return nil, err
+			}
+
+			spec.Volumes = append(spec.Volumes, corev1.Volume{Name: volume.Name, VolumeSource: corev1.VolumeSource{Secret: source}})
+		}
+	}
+
+	// Containers
+	for i, container := range c.InitContainers {
+		initContainer, err := r.container(fmt.Sprintf("initContainers[%d]", i), fmt.Sprintf("init-container-%d", i), container)
+		if err != nil {
+			return nil, err
+		}
+
+		spec.InitContainers = append(spec.InitContainers, *initContainer)
+	}
+	for i, container := range c.Containers {
+		container, err := r.container(fmt.Sprintf("containers[%d]", i), fmt.Sprintf("container-%d", i), container)
+		if err != nil {
+			return nil, err
+		}
+
+		spec.Containers = append(spec.Containers, *container)
+	}
+	return &corev1.PodTemplateSpec{
+		ObjectMeta: r.objectMeta("", c.Labels, c.Annotations),
+		Spec:       spec,
+	}, nil
+}
+
+func (r *renderer) container(field, defaultName string, config *latest.ContainerConfig) (*corev1.Container, error) {
+	if config == nil {
+		return nil, errors.Errorf("%s is empty", field)
+	} else if config.Image == "" {
+		return nil, errors.Errorf("%s.image is required", field)
+	}
+
+	container := &corev1.Container{
+		Name:                     config.Name,
+		Image:                    config.Image,
+		Command:                  config.Command,
+		Args:                     config.Args,
+		Stdin:                    config.Stdin,
+		TTY:                      config.TTY,
+		StdinOnce:                config.StdinOnce,
+		WorkingDir:               config.WorkingDir,
+		ImagePullPolicy:          corev1.PullPolicy(config.ImagePullPolicy),
+		TerminationMessagePath:   config.TerminationMessagePath,
+		TerminationMessagePolicy: corev1.TerminationMessagePolicy(config.TerminationMessagePolicy),
+	}
+	if container.Name == "" {
+		container.Name = defaultName
+	}
+
+	for _, conversion := range []conversion{
+		{"env", config.Env, &container.Env},
+		{"envFrom", config.EnvFrom, &container.EnvFrom},
+		{"resources", config.Resources, &container.Resources},
+		{"livenessProbe", config.LivenessProbe, &container.LivenessProbe},
+		{"readinessProbe", config.ReadinessProbe, &container.ReadinessProbe},
+		{"startupProbe", config.StartupProbe, &container.StartupProbe},
+		{"securityContext", config.SecurityContext, &container.SecurityContext},
+		{"lifecycle", config.Lifecycle, &container.Lifecycle},
+		{"volumeDevices", config.VolumeDevices, &container.VolumeDevices},
+	} {
+		err := convert(field+"."+conversion.field, conversion.value, conversion.into)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for i, volumeMount := range config.VolumeMounts {
+		if volumeMount == nil || volumeMount.ContainerPath == "" {
+			return nil, errors.Errorf("%s.volumeMounts[%d].containerPath is required", field, i)
+		} else if volumeMount.Volume == nil || volumeMount.Volume.Name == "" {
+			return nil, errors.Errorf("%s.volumeMounts[%d].volume.name is required", field, i)
+		} else if r.findVolume(volumeMount.Volume.Name) == nil {
+			return nil, errors.Errorf("%s.volumeMounts[%d].volume.name: volume %s is not defined in volumes", field, i, volumeMount.Volume.Name)
+		}
+
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      volumeMount.Volume.Name,
+			MountPath: volumeMount.ContainerPath,
+			SubPath:   volumeMount.Volume.SubPath,
+			ReadOnly:  boolValue(volumeMount.Volume.ReadOnly),
+		})
+	}
+
+	return container, nil
+}
+
+func (r *renderer) findVolume(name string) *latest.VolumeConfig {
+	for _, volume := range r.component.Volumes {
+		if volume != nil && volume.Name == name {
+			return volume
+		}
+	}
+
+	return nil
+}
+
+// volumeClaimTemplates returns the claim templates for all volumes that are neither config maps nor secrets
+func (r *renderer) volumeClaimTemplates() ([]corev1.PersistentVolumeClaim, error) {
+	claims := []corev1.PersistentVolumeClaim{}
+	for i, volume := range r.component.Volumes {
+		if volume == nil || volume.ConfigMap != nil || volume.Secret != nil {
+			continue
+		}
+
+		size := volume.Size
+		if size == "" {
+			size = defaultVolumeSize
+		}
+
+		quantity, err := resource.ParseQuantity(size)
+		if err != nil {
+			return nil, errors.Errorf("volumes[%d].size: %v", i, err)
+		}
+
+		accessModes := []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}
+		if len(volume.AccessModes) > 0 {
+			accessModes = []corev1.PersistentVolumeAccessMode{}
+			for _, accessMode := range volume.AccessModes {
+				accessModes = append(accessModes, corev1.PersistentVolumeAccessMode(accessMode))
+			}
+		}
+
+		claim := corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        volume.Name,
+				Labels:      volume.Labels,
+				Annotations: volume.Annotations,
+			},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				AccessModes: accessModes,
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceStorage: quantity,
+					},
+				},
+				VolumeName: volume.VolumeName,
+			},
+		}
+		if volume.StorageClassName != "" {
+			claim.Spec.StorageClassName = &volume.StorageClassName
+		}
+		if volume.VolumeMode != "" {
+			volumeMode := corev1.PersistentVolumeMode(volume.VolumeMode)
+			claim.Spec.VolumeMode = &volumeMode
+		}
+
+		err = convert(fmt.Sprintf("volumes[%d].dataSource", i), volume.DataSource, &claim.Spec.DataSource)
+		if err != nil {
+			return nil, err
+		}
+
+		claims = append(claims, claim)
+	}
+
+	return claims, nil
+}
+
+func (r *renderer) servicePorts() ([]corev1.ServicePort, error) {
+	ports := []corev1.ServicePort{}
+	for i, port := range r.component.Service.Ports {
+		if port == nil || port.Port == nil {
+			return nil, errors.Errorf("service.ports[%d].port is required", i)
+		}
+
+		targetPort := *port.Port
+		if port.ContainerPort != nil {
+			targetPort = *port.ContainerPort
+		}
+
+		servicePort := corev1.ServicePort{
+			Name:       port.Name,
+			Port:       int32(*port.Port),
+			TargetPort: intstr.FromInt(targetPort),
+			Protocol:   corev1.Protocol(port.Protocol),
+		}
+		if servicePort.Name == "" {
+			servicePort.Name = fmt.Sprintf("port-%d", i)
+		}
+		if servicePort.Protocol == "" {
+			servicePort.Protocol = corev1.ProtocolTCP
+		}
+
+		ports = append(ports, servicePort)
+	}
+
+	return ports, nil
+}
+
+func (r *renderer) service() (*corev1.Service, error) {
+	config := r.component.Service
+	if config == nil {
+		return nil, nil
+	}
+
+	ports, err := r.servicePorts()
+	if err != nil {
+		return nil, err
+	}
+
+	name := config.Name
+	if name == "" {
+		name = r.name
+	}
+
+	serviceType := corev1.ServiceType(config.Type)
+	if serviceType == "" {
+		serviceType = corev1.ServiceTypeClusterIP
+	}
+
+	service := &corev1.Service{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Service"},
+		ObjectMeta: r.objectMeta(name, config.Labels, config.Annotations),
+		Spec: corev1.ServiceSpec{
+			Type:                     serviceType,
+			Ports:                    ports,
+			Selector:                 r.selectorLabels(),
+			ExternalIPs:              config.ExternalIPs,
+			ClusterIP:                config.ClusterIP,
+			ExternalName:             config.ExternalName,
+			ExternalTrafficPolicy:    corev1.ServiceExternalTrafficPolicyType(config.ExternalTrafficPolicy),
+			HealthCheckNodePort:      int32(config.HealthCheckNodePort),
+			LoadBalancerIP:           stringValue(config.LoadBalancerIP),
+			LoadBalancerSourceRanges: config.LoadBalancerSourceRanges,
+			PublishNotReadyAddresses: config.PublishNotReadyAddresses,
+			TopologyKeys:             config.TopologyKeys,
+		},
+	}
+	if config.IpFamily != nil {
+		service.Spec.IPFamilies = []corev1.IPFamily{corev1.IPFamily(*config.IpFamily)}
+	}
+
+	err = convert("service.sessionAffinity", config.SessionAffinity, &service.Spec.SessionAffinity)
+	if err != nil {
+		return nil, err
+	}
+
+	err = convert("service.sessionAffinityConfig", config.SessionAffinityConfig, &service.Spec.SessionAffinityConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return service, nil
+}
+
+// headlessService returns the service that governs the stateful set
+func (r *renderer) headlessService(name string, service *corev1.Service) *corev1.Service {
+	headlessService := &corev1.Service{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Service"},
+		ObjectMeta: r.objectMeta(name, nil, nil),
+		Spec: corev1.ServiceSpec{
+			ClusterIP: corev1.ClusterIPNone,
+			Selector:  r.selectorLabels(),
+		},
+	}
+	if service != nil {
+		headlessService.Spec.Ports = service.Spec.Ports
+	}
+
+	return headlessService
+}
+
+func (r *renderer) ingress(service *corev1.Service) (*networkingv1.Ingress, error) {
+	config := r.component.Ingress
+	if config == nil {
+		return nil, nil
+	}
+
+	name := config.Name
+	if name == "" {
+		name = r.name
+	}
+
+	annotations := map[string]string{}
+	for k, v := range config.Annotations {
+		annotations[k] = v
+	}
+	if config.TLSClusterIssuer != "" {
+		annotations[TLSClusterIssuerAnnotation] = config.TLSClusterIssuer
+	}
+	if config.IngressClass != "" {
+		annotations[IngressClassAnnotation] = config.IngressClass
+	}
+	if len(annotations) == 0 {
+		annotations = nil
+	}
+
+	ingress := &networkingv1.Ingress{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "networking.k8s.io/v1", Kind: "Ingress"},
+		ObjectMeta: r.objectMeta(name, config.Labels, annotations),
+		Spec: networkingv1.IngressSpec{
+			IngressClassName: config.IngressClassName,
+		},
+	}
+
+	tlsHosts := map[string][]string{}
+	tlsSecrets := []string{}
+	addTLSHost := func(secret, host string) {
+		if secret == "true" {
+			secret = name + "-tls"
+		} else if secret == "" || secret == "false" {
+			return
+		}
+		if _, ok := tlsHosts[secret]; !ok {
+			tlsSecrets = append(tlsSecrets, secret)
+		}
+
+		tlsHosts[secret] = append(tlsHosts[secret], host)
+	}
+
+	pathType := networkingv1.PathTypeImplementationSpecific
+	for i, rule := range config.Rules {
+		if rule == nil {
+			return nil, errors.Errorf("ingress.rules[%d] is empty", i)
+		}
+
+		serviceName := rule.ServiceName
+		if serviceName == "" {
+			if service == nil {
+				return nil, errors.Errorf("ingress.rules[%d].serviceName is required if no service is defined", i)
+			}
+
+			serviceName = service.Name
+		}
+
+		servicePort := 0
+		if rule.ServicePort != nil {
+			servicePort = *rule.ServicePort
+		} else if service != nil && rule.ServiceName == "" && len(service.Spec.Ports) > 0 {
+			servicePort = int(service.Spec.Ports[0].Port)
+		} else {
+			return nil, errors.Errorf("ingress.rules[%d].servicePort is required", i)
+		}
+
+		path := rule.Path
+		if path == "" {
+			path = "/"
+		}
+
+		ingress.Spec.Rules = append(ingress.Spec.Rules, networkingv1.IngressRule{
+			Host: rule.Host,
+			IngressRuleValue: networkingv1.IngressRuleValue{
+				HTTP: &networkingv1.HTTPIngressRuleValue{
+					Paths: []networkingv1.HTTPIngressPath{
+						{
+							Path:     path,
+							PathType: &pathType,
+							Backend: networkingv1.IngressBackend{
+								Service: &networkingv1.IngressServiceBackend{
+									Name: serviceName,
+									Port: networkingv1.ServiceBackendPort{Number: int32(servicePort)},
+								},
+							},
+						},
+					},
+				},
+			},
+		})
+
+		tls := rule.TLS
+		if config.TLS != "" {
+			tls = config.TLS
+		}
+		if rule.Host != "" {
+			addTLSHost(tls, rule.Host)
+		}
+	}
+
+	for _, secret := range tlsSecrets {
+		ingress.Spec.TLS = append(ingress.Spec.TLS, networkingv1.IngressTLS{
+			Hosts:      tlsHosts[secret],
+			SecretName: secret,
+		})
+	}
+
+	err := convert("ingress.backend", config.Backend, &ingress.Spec.DefaultBackend)
+	if err != nil {
+		return nil, err
+	}
+
+	return ingress, nil
+}
+
+func (r *renderer) autoscaler(workloadKind string) (*autoscalingv2beta2.HorizontalPodAutoscaler, error) {
+	if r.component.Autoscaling == nil || r.component.Autoscaling.Horizontal == nil {
+		return nil, nil
+	}
+
+	config := r.component.Autoscaling.Horizontal
+	if config.MaxReplicas == nil {
+		return nil, errors.New("autoScaling.horizontal.maxReplicas is required")
+	}
+
+	metrics := []autoscalingv2beta2.MetricSpec{}
+	for _, metric := range []struct {
+		field    string
+		resource corev1.ResourceName
+		value    string
+		relative bool
+	}{
+		{"averageCPU", corev1.ResourceCPU, config.AverageCPU, false},
+		{"averageRelativeCPU", corev1.ResourceCPU, config.AverageRelativeCPU, true},
+		{"averageMemory", corev1.ResourceMemory, config.AverageMemory, false},
+		{"averageRelativeMemory", corev1.ResourceMemory, config.AverageRelativeMemory, true},
+	} {
+		if metric.value == "" {
+			continue
+		}
+
+		target := autoscalingv2beta2.MetricTarget{}
+		if metric.relative {
+			utilization, err := strconv.Atoi(strings.TrimSuffix(metric.value, "%"))
+			if err != nil {
+				return nil, errors.Errorf("autoScaling.horizontal.%s: %s is not a valid percentage", metric.field, metric.value)
+			}
+
+			averageUtilization := int32(utilization)
+			target.Type = autoscalingv2beta2.UtilizationMetricType
+			target.AverageUtilization = &averageUtilization
+		} else {
+			quantity, err := resource.ParseQuantity(metric.value)
+			if err != nil {
+				return nil, errors.Errorf("autoScaling.horizontal.%s: %v", metric.field, err)
+			}
+
+			target.Type = autoscalingv2beta2.AverageValueMetricType
+			target.AverageValue = &quantity
+		}
+
+		metrics = append(metrics, autoscalingv2beta2.MetricSpec{
+			Type: autoscalingv2beta2.ResourceMetricSourceType,
+			Resource: &autoscalingv2beta2.ResourceMetricSource{
+				Name:   metric.resource,
+				Target: target,
+			},
+		})
+	}
+
+	return &autoscalingv2beta2.HorizontalPodAutoscaler{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "autoscaling/v2beta2", Kind: "HorizontalPodAutoscaler"},
+		ObjectMeta: r.objectMeta(r.name, nil, nil),
+		Spec: autoscalingv2beta2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2beta2.CrossVersionObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       workloadKind,
+				Name:       r.name,
+			},
+			MinReplicas: r.replicas(),
+			MaxReplicas: int32(*config.MaxReplicas),
+			Metrics:     metrics,
+		},
+	}, nil
+}
+
+// conversion maps a passthrough value of the component config to a field of a kubernetes object
+type conversion struct {
+	field string
+	value interface{}
+	into  interface{}
+}
+
+// convert converts a passthrough value of the component config into the given kubernetes type
+// and fails if the value contains unknown fields. Empty values are skipped
+func convert(field string, value interface{}, into interface{}) error {
+	v := reflect.ValueOf(value)
+	if !v.IsValid() || ((v.Kind() == reflect.Map || v.Kind() == reflect.Slice) && v.Len() == 0) {
+		return nil
+	}
+
+	out, err := yaml.Marshal(value)
+	if err != nil {
+		return errors.Errorf("%s: %v", field, err)
+	}
+
+	jsonBytes, err := ghodssyaml.YAMLToJSON(out)
+	if err != nil {
+		return errors.Errorf("%s: %v", field, err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(jsonBytes))
+	decoder.DisallowUnknownFields()
+	err = decoder.Decode(into)
+	if err != nil {
+		return errors.Errorf("%s: %v", field, err)
+	}
+
+	return nil
+}
+
+func toUnstructured(objects []runtime.Object) ([]*unstructured.Unstructured, error) {
+	unstructuredObjects := []*unstructured.Unstructured{}
+	for _, obj := range objects {
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		if err != nil {
+			return nil, err
+		}
+
+		// the status is never part of the desired state
+		delete(content, "status")
+		cleanup(content)
+		unstructuredObjects = append(unstructuredObjects, &unstructured.Unstructured{Object: content})
+	}
+
+	return unstructuredObjects, nil
+}
+
+// cleanup removes the null values and empty status fields the typed objects leave behind
+func cleanup(obj map[string]interface{}) {
+	for key, value := range obj {
+		switch v := value.(type) {
+		case nil:
+			delete(obj, key)
+		case map[string]interface{}:
+			cleanup(v)
+			if key == "status" && len(v) == 0 {
+				delete(obj, key)
+			}
+		case []interface{}:
+			for _, item := range v {
+				if m, ok := item.(map[string]interface{}); ok {
+					cleanup(m)
+				}
+			}
+		}
+	}
+}
+
+func int64Ptr(value *int) *int64 {
+	if value == nil {
+		return nil
+	}
+
+	ret := int64(*value)
+	return &ret
+}
+
+func int32Ptr(value *int) *int32 {
+	if value == nil {
+		return nil
+	}
+
+	ret := int32(*value)
+	return &ret
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+
+	return *value
+}
+
+func boolValue(value *bool) bool {
+	if value == nil {
+		return false
+	}
+
+	return *value
+}
@@ -0,0 +1,295 @@
+package render
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+
+	"github.com/ghodss/yaml"
+	yaml2 "gopkg.in/yaml.v2"
+	"gotest.tools/assert"
+)
+
+type componentTestCase struct {
+	name string
+
+	component string
+
+	expectedOutput string
+	expectedErr    string
+}
+
+func TestComponent(t *testing.T) {
+	testCases := []componentTestCase{
+		{
+			name: "Deployment with service and ingress",
+			component: `
+containers:
+- image: nginx
+  env:
+  - name: MODE
+    value: production
+  resources:
+    limits:
+      cpu: 500m
+service:
+  ports:
+  - port: 80
+    containerPort: 8080
+ingress:
+  tls: "true"
+  tlsClusterIssuer: letsencrypt
+  rules:
+  - host: example.com
+`,
+			expectedOutput: `apiVersion: v1
+kind: Service
+metadata:
+  labels:
+    app.kubernetes.io/component: web
+    app.kubernetes.io/managed-by: DevSpace
+    app.kubernetes.io/name: devspace-app
+  name: web
+spec:
+  ports:
+  - name: port-0
+    port: 80
+    protocol: TCP
+    targetPort: 8080
+  selector:
+    app.kubernetes.io/component: web
+    app.kubernetes.io/name: devspace-app
+  type: ClusterIP
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  labels:
+    app.kubernetes.io/component: web
+    app.kubernetes.io/managed-by: DevSpace
+    app.kubernetes.io/name: devspace-app
+  name: web
+spec:
+  replicas: 1
+  selector:
+    matchLabels:
+      app.kubernetes.io/component: web
+      app.kubernetes.io/name: devspace-app
+  strategy:
+    type: Recreate
+  template:
+    metadata:
+      labels:
+        app.kubernetes.io/component: web
+        app.kubernetes.io/managed-by: DevSpace
+        app.kubernetes.io/name: devspace-app
+    spec:
+      containers:
+      - env:
+        - name: MODE
+          value: production
+        image: nginx
+        name: container-0
+        resources:
+          limits:
+            cpu: 500m
+      terminationGracePeriodSeconds: 5
+---
+apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  annotations:
+    cert-manager.io/cluster-issuer: letsencrypt
+  labels:
+    app.kubernetes.io/component: web
+    app.kubernetes.io/managed-by: DevSpace
+    app.kubernetes.io/name: devspace-app
+  name: web
+spec:
+  rules:
+  - host: example.com
+    http:
+      paths:
+      - backend:
+          service:
+            name: web
+            port:
+              number: 80
+        path: /
+        pathType: ImplementationSpecific
+  tls:
+  - hosts:
+    - example.com
+    secretName: web-tls
+`,
+		},
+		{
+			name: "Stateful set with persistent volume and autoscaling",
+			component: `
+containers:
+- image: postgres
+  volumeMounts:
+  - containerPath: /var/lib/postgresql/data
+    volume:
+      name: data
+volumes:
+- name: data
+  size: 1Gi
+rollingUpdate:
+  enabled: true
+  partition: 1
+autoScaling:
+  horizontal:
+    maxReplicas: 3
+    averageRelativeCPU: "80"
+`,
+			expectedOutput: `apiVersion: v1
+kind: Service
+metadata:
+  labels:
+    app.kubernetes.io/component: web
+    app.kubernetes.io/managed-by: DevSpace
+    app.kubernetes.io/name: devspace-app
+  name: web-headless
+spec:
+  clusterIP: None
+  selector:
+    app.kubernetes.io/component: web
+    app.kubernetes.io/name: devspace-app
+---
+apiVersion: apps/v1
+kind: StatefulSet
+metadata:
+  labels:
+    app.kubernetes.io/component: web
+    app.kubernetes.io/managed-by: DevSpace
+    app.kubernetes.io/name: devspace-app
+  name: web
+spec:
+  replicas: 1
+  selector:
+    matchLabels:
+      app.kubernetes.io/component: web
+      app.kubernetes.io/name: devspace-app
+  serviceName: web-headless
+  template:
+    metadata:
+      labels:
+        app.kubernetes.io/component: web
+        app.kubernetes.io/managed-by: DevSpace
+        app.kubernetes.io/name: devspace-app
+    spec:
+      containers:
+      - image: postgres
+        name: container-0
+        resources: {}
+        volumeMounts:
+        - mountPath: /var/lib/postgresql/data
+          name: data
+      terminationGracePeriodSeconds: 5
+  updateStrategy:
+    rollingUpdate:
+      partition: 1
+    type: RollingUpdate
+  volumeClaimTemplates:
+  - metadata:
+      name: data
+    spec:
+      accessModes:
+      - ReadWriteOnce
+      resources:
+        requests:
+          storage: 1Gi
+---
+apiVersion: autoscaling/v2beta2
+kind: HorizontalPodAutoscaler
+metadata:
+  labels:
+    app.kubernetes.io/component: web
+    app.kubernetes.io/managed-by: DevSpace
+    app.kubernetes.io/name: devspace-app
+  name: web
+spec:
+  maxReplicas: 3
+  metrics:
+  - resource:
+      name: cpu
+      target:
+        averageUtilization: 80
+        type: Utilization
+    type: Resource
+  minReplicas: 1
+  scaleTargetRef:
+    apiVersion: apps/v1
+    kind: StatefulSet
+    name: web
+`,
+		},
+		{
+			name: "Unknown field in env",
+			component: `
+containers:
+- image: nginx
+  env:
+  - name: MODE
+    valueFromm: production
+`,
+			expectedErr: `containers[0].env: json: unknown field "valueFromm"`,
+		},
+		{
+			name: "Missing image",
+			component: `
+containers:
+- name: web
+`,
+			expectedErr: "containers[0].image is required",
+		},
+		{
+			name: "Unknown volume",
+			component: `
+containers:
+- image: nginx
+  volumeMounts:
+  - containerPath: /data
+    volume:
+      name: data
+`,
+			expectedErr: "containers[0].volumeMounts[0].volume.name: volume data is not defined in volumes",
+		},
+		{
+			name: "Ingress without service",
+			component: `
+containers:
+- image: nginx
+ingress:
+  rules:
+  - host: example.com
+`,
+			expectedErr: "ingress.rules[0].serviceName is required if no service is defined",
+		},
+	}
+
+	for _, testCase := range testCases {
+		component := &latest.ComponentConfig{}
+		err := yaml2.UnmarshalStrict([]byte(testCase.component), component)
+		assert.NilError(t, err, "Error parsing component in testCase %s", testCase.name)
+
+		objects, err := Component("web", component)
+		if testCase.expectedErr == "" {
+			assert.NilError(t, err, "Error in testCase %s", testCase.name)
+		} else {
+			assert.Error(t, err, testCase.expectedErr, "Wrong or no error in testCase %s", testCase.name)
+			continue
+		}
+
+		manifests := []string{}
+		for _, obj := range objects {
+			out, err := yaml.Marshal(obj)
+			assert.NilError(t, err, "Error marshaling object in testCase %s", testCase.name)
+			manifests = append(manifests, string(out))
+		}
+
+		assert.Equal(t, strings.Join(manifests, "---\n"), testCase.expectedOutput, "Unexpected output in testCase %s", testCase.name)
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/loft-sh/devspace/pkg/devspace/config/generated"
 	fakegenerated "github.com/loft-sh/devspace/pkg/devspace/config/generated/testing"
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer/util"
 	"github.com/loft-sh/devspace/pkg/util/fsutil"
 	fakekubeconfig "github.com/loft-sh/devspace/pkg/util/kubeconfig/testing"
 	"github.com/loft-sh/devspace/pkg/util/log"
@@ -652,6 +653,35 @@ deployments:
 				},
 			},
 		},
+		"Runtime variables": {
+			in: &parseTestCaseInput{
+				config: `
+version: v1beta10
+deployments:
+- name: ${my_var}
+  command:
+    deploy:
+      command: terraform apply -var image=${runtime.images.backend.image}:${runtime.images.backend.tag}`,
+				options: &ConfigOptions{},
+				generatedConfig: &generated.Config{Vars: map[string]string{
+					"my_var": "test",
+				}},
+			},
+			expected: &latest.Config{
+				Version: latest.Version,
+				Dev:     latest.DevConfig{},
+				Deployments: []*latest.DeploymentConfig{
+					{
+						Name: "test",
+						Command: &latest.CommandDeploymentConfig{
+							Deploy: &latest.DeploymentCommand{
+								Command: "terraform apply -var image=${runtime.images.backend.image}:${runtime.images.backend.tag}",
+							},
+						},
+					},
+				},
+			},
+		},
 		"Profile replace with variable": {
 			in: &parseTestCaseInput{
 				config: `
@@ -1085,6 +1115,91 @@ profiles:
 	}
 }
 
+type runtimeVariablesTestCase struct {
+	name string
+
+	command string
+	args    []string
+
+	expectedCommand string
+	expectedArgs    []string
+}
+
+func TestRuntimeVariables(t *testing.T) {
+	testCases := []runtimeVariablesTestCase{
+		{
+			name:            "Runtime variable",
+			command:         "terraform apply -var tag=${runtime.images.backend.tag}",
+			expectedCommand: "terraform apply -var tag=abcdef",
+		},
+		{
+			name:            "Escaped runtime variable",
+			command:         "echo $${runtime.images.backend.tag}",
+			expectedCommand: "echo ${runtime.images.backend.tag}",
+		},
+		{
+			name:            "Escaped variable",
+			command:         "echo $${HOME}",
+			expectedCommand: "echo ${HOME}",
+		},
+		{
+			name:            "Escaped variables in args",
+			command:         "echo",
+			args:            []string{"$${runtime.images.backend.image}", "$${HOME}", "${runtime.images.backend.image}"},
+			expectedCommand: "echo",
+			expectedArgs:    []string{"${runtime.images.backend.image}", "${HOME}", "myimage"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		deployCommand := map[interface{}]interface{}{"command": testCase.command}
+		if testCase.args != nil {
+			args := []interface{}{}
+			for _, arg := range testCase.args {
+				args = append(args, arg)
+			}
+			deployCommand["args"] = args
+		}
+
+		rawConfig := map[interface{}]interface{}{
+			"version": latest.Version,
+			"images": map[interface{}]interface{}{
+				"backend": map[interface{}]interface{}{"image": "myimage"},
+			},
+			"deployments": []interface{}{
+				map[interface{}]interface{}{
+					"name":    "test",
+					"command": map[interface{}]interface{}{"deploy": deployCommand},
+				},
+			},
+		}
+
+		generatedConfig := generated.New()
+		generatedConfig.GetActive().Images["backend"] = &generated.ImageCache{ImageName: "myimage", Tag: "abcdef"}
+		options := &ConfigOptions{GeneratedConfig: generatedConfig, generatedLoader: &fakeGeneratedLoader{}}
+
+		configLoader := NewConfigLoader("").(*configLoader)
+		parsedConfig, _, _, err := configLoader.parseConfig(rawConfig, NewDefaultParser(), options, log.Discard)
+		assert.NilError(t, err, "Error parsing config in testCase %s", testCase.name)
+
+		config := config2.NewConfig(rawConfig, parsedConfig, generatedConfig, nil)
+		deploy := parsedConfig.Deployments[0].Command.Deploy
+		_, command, err := util.ReplaceRuntimeVariables(deploy.Command, config, nil, nil)
+		assert.NilError(t, err, "Error replacing runtime variables in testCase %s", testCase.name)
+		assert.Equal(t, command, testCase.expectedCommand, "Unexpected command in testCase %s", testCase.name)
+
+		args := []string{}
+		for _, arg := range deploy.Args {
+			_, replaced, err := util.ReplaceRuntimeVariables(arg, config, nil, nil)
+			assert.NilError(t, err, "Error replacing runtime variables in testCase %s", testCase.name)
+			args = append(args, replaced)
+		}
+		if testCase.expectedArgs != nil {
+			assert.DeepEqual(t, args, testCase.expectedArgs)
+		}
+	}
+}
+
 type fakeGeneratedLoader struct{}
 
 func (f *fakeGeneratedLoader) Load() (*generated.Config, error) {
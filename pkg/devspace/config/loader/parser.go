@@ -120,7 +120,20 @@ func fillVariables(resolver variable.Resolver, preparedConfig map[interface{}]in
 	}
 
 	// parse cli --var's, the resolver will cache them for us
-	_, err = resolver.ConvertFlags(options.Vars)
+	flagVars, err := resolver.ConvertFlags(options.Vars)
+	if err != nil {
+		return err
+	}
+
+	// make sure runtime variables are only used where they are resolved
+	definedVars := map[string]bool{}
+	for name := range flagVars {
+		definedVars[name] = true
+	}
+	for _, v := range vars {
+		definedVars[strings.TrimSpace(v.Name)] = true
+	}
+	err = validateRuntimeVariables(preparedConfig, definedVars)
 	if err != nil {
 		return err
 	}
@@ -142,6 +155,9 @@ func fillVariables(resolver variable.Resolver, preparedConfig map[interface{}]in
 		}
 	}
 
+	// runtime variables in command deployments are replaced during execution, so we keep their escapes
+	keepRuntimeVariableEscapes(preparedConfig)
+
 	// Walk over data and fill in variables
 	err = resolver.FillVariables(preparedConfig)
 	if err != nil {
@@ -151,6 +167,52 @@ func fillVariables(resolver variable.Resolver, preparedConfig map[interface{}]in
 	return nil
 }
 
+// keepRuntimeVariableEscapes keeps the escapes of runtime variables in deployments[*].command, which is
+// the only place where runtime variables are replaced
+func keepRuntimeVariableEscapes(preparedConfig map[interface{}]interface{}) {
+	deployments, ok := preparedConfig["deployments"].([]interface{})
+	if !ok {
+		return
+	}
+
+	for _, deployment := range deployments {
+		deploymentMap, ok := deployment.(map[interface{}]interface{})
+		if !ok {
+			continue
+		}
+
+		commandMap, ok := deploymentMap["command"].(map[interface{}]interface{})
+		if !ok {
+			continue
+		}
+
+		for k, v := range commandMap {
+			if k == "files" {
+				continue
+			}
+
+			commandMap[k] = keepEscapes(v)
+		}
+	}
+}
+
+func keepEscapes(value interface{}) interface{} {
+	switch t := value.(type) {
+	case map[interface{}]interface{}:
+		for k, v := range t {
+			t[k] = keepEscapes(v)
+		}
+	case []interface{}:
+		for i, v := range t {
+			t[i] = keepEscapes(v)
+		}
+	case string:
+		return variable.KeepRuntimeVariableEscapes(t)
+	}
+
+	return value
+}
+
 func askQuestions(resolver variable.Resolver, vars []*latest.Variable) error {
 	for _, definition := range vars {
 		name := strings.TrimSpace(definition.Name)
@@ -3,13 +3,18 @@ package loader
 import (
 	"fmt"
 	jsonyaml "github.com/ghodss/yaml"
+	"github.com/loft-sh/devspace/pkg/devspace/bootstrap"
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader/component/render"
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader/variable"
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
 	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer/helm/merge"
 	"github.com/loft-sh/devspace/pkg/util/log"
+	varspkg "github.com/loft-sh/devspace/pkg/util/vars"
 	"github.com/loft-sh/devspace/pkg/util/yamlutil"
 	"github.com/pkg/errors"
 	"gopkg.in/yaml.v2"
 	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"path/filepath"
 	"strings"
 )
@@ -58,11 +63,21 @@ func validate(config *latest.Config, log log.Logger) error {
 		return err
 	}
 
+	err = validateNamespaces(config)
+	if err != nil {
+		return err
+	}
+
 	err = validatePullSecrets(config)
 	if err != nil {
 		return err
 	}
 
+	err = validateSecrets(config)
+	if err != nil {
+		return err
+	}
+
 	err = validateCommands(config)
 	if err != nil {
 		return err
@@ -210,19 +225,96 @@ func validateHooks(config *latest.Config) error {
 }
 
 func validateDeployments(config *latest.Config) error {
+	deploymentNames := map[string]bool{}
+	for _, deployConfig := range config.Deployments {
+		deploymentNames[deployConfig.Name] = true
+	}
+
 	for index, deployConfig := range config.Deployments {
 		if deployConfig.Name == "" {
 			return errors.Errorf("deployments[%d].name is required", index)
 		}
-		if deployConfig.Helm == nil && deployConfig.Kubectl == nil {
-			return errors.Errorf("Please specify either helm or kubectl as deployment type in deployment %s", deployConfig.Name)
+		deploymentTypes := 0
+		if deployConfig.Helm != nil {
+			deploymentTypes++
+		}
+		if deployConfig.Kubectl != nil {
+			deploymentTypes++
+		}
+		if deployConfig.Command != nil {
+			deploymentTypes++
+		}
+		if deployConfig.Component != nil {
+			deploymentTypes++
+		}
+		if deploymentTypes == 0 {
+			return errors.Errorf("Please specify either helm, kubectl, command or component as deployment type in deployment %s", deployConfig.Name)
+		} else if deploymentTypes > 1 {
+			return errors.Errorf("Please specify only one of helm, kubectl, command or component as deployment type in deployment %s", deployConfig.Name)
 		}
 		if deployConfig.Helm != nil && (deployConfig.Helm.Chart == nil || deployConfig.Helm.Chart.Name == "") && (deployConfig.Helm.ComponentChart == nil || *deployConfig.Helm.ComponentChart == false) {
 			return errors.Errorf("deployments[%d].helm.chart and deployments[%d].helm.chart.name or deployments[%d].helm.componentChart is required", index, index, index)
 		}
+		if deployConfig.Helm != nil && deployConfig.Helm.Chart != nil && strings.HasPrefix(deployConfig.Helm.Chart.Name, "oci://") {
+			if deployConfig.Helm.Chart.RepoURL != "" {
+				return errors.Errorf("deployments[%d].helm.chart.repo cannot be used with an oci chart", index)
+			} else if deployConfig.Helm.V2 {
+				return errors.Errorf("deployments[%d].helm.chart.name: oci charts require helm v3", index)
+			}
+		}
+		if deployConfig.Helm != nil {
+			for valuesFromIndex, valuesFrom := range deployConfig.Helm.ValuesFrom {
+				sources := 0
+				for _, ref := range []*latest.HelmValuesFromKeyRef{valuesFrom.Secret, valuesFrom.ConfigMap, valuesFrom.Release} {
+					if ref == nil {
+						continue
+					}
+
+					sources++
+					if ref.Name == "" {
+						return errors.Errorf("deployments[%d].helm.valuesFrom[%d]: name is required", index, valuesFromIndex)
+					} else if ref.Key == "" && ref != valuesFrom.Release {
+						return errors.Errorf("deployments[%d].helm.valuesFrom[%d]: key is required", index, valuesFromIndex)
+					}
+				}
+				if valuesFrom.Template != "" {
+					sources++
+				}
+				if sources != 1 {
+					return errors.Errorf("deployments[%d].helm.valuesFrom[%d]: please specify exactly one of secret, configMap, release or template", index, valuesFromIndex)
+				}
+			}
+		}
+		if deployConfig.Helm != nil && deployConfig.Helm.PostRenderer != nil && deployConfig.Helm.V2 {
+			return errors.Errorf("deployments[%d].helm.postRenderer requires helm v3", index)
+		}
 		if deployConfig.Kubectl != nil && deployConfig.Kubectl.Manifests == nil {
 			return errors.Errorf("deployments[%d].kubectl.manifests is required", index)
 		}
+		if deployConfig.Command != nil {
+			if deployConfig.Command.Deploy == nil || deployConfig.Command.Deploy.Command == "" {
+				return errors.Errorf("deployments[%d].command.deploy.command is required", index)
+			}
+			for name, command := range map[string]*latest.DeploymentCommand{"render": deployConfig.Command.Render, "status": deployConfig.Command.Status, "purge": deployConfig.Command.Purge} {
+				if command != nil && command.Command == "" {
+					return errors.Errorf("deployments[%d].command.%s.command is required", index, name)
+				}
+			}
+		}
+		if deployConfig.Component != nil {
+			_, err := render.Component(deployConfig.Name, deployConfig.Component)
+			if err != nil {
+				return errors.Errorf("deployments[%d].component.%v", index, err)
+			}
+		}
+		for _, dependency := range deployConfig.DependsOn {
+			if dependency == deployConfig.Name {
+				return errors.Errorf("deployments[%d].dependsOn: deployment %s cannot depend on itself", index, deployConfig.Name)
+			}
+			if !deploymentNames[dependency] {
+				return errors.Errorf("deployments[%d].dependsOn: unknown deployment %s", index, dependency)
+			}
+		}
 		if deployConfig.Helm != nil && deployConfig.Helm.ComponentChart != nil && *deployConfig.Helm.ComponentChart == true {
 			// Load override values from path
 			overwriteValues := map[interface{}]interface{}{}
@@ -262,6 +354,44 @@ func validateDeployments(config *latest.Config) error {
 	return nil
 }
 
+func validateNamespaces(config *latest.Config) error {
+	names := map[string]bool{}
+	for i, namespace := range config.Namespaces {
+		if namespace.Name == "" {
+			return errors.Errorf("namespaces[%d].name: cannot be empty", i)
+		} else if names[namespace.Name] {
+			return errors.Errorf("namespaces[%d].name: namespace %s is defined multiple times", i, namespace.Name)
+		}
+		names[namespace.Name] = true
+
+		for j, quota := range namespace.ResourceQuotas {
+			if quota.Name == "" {
+				return errors.Errorf("namespaces[%d].resourceQuotas[%d].name: cannot be empty", i, j)
+			}
+			err := bootstrap.ConvertSpec(quota.Spec, &k8sv1.ResourceQuotaSpec{})
+			if err != nil {
+				return errors.Errorf("namespaces[%d].resourceQuotas[%d].%v", i, j, err)
+			}
+		}
+		for j, limitRange := range namespace.LimitRanges {
+			if limitRange.Name == "" {
+				return errors.Errorf("namespaces[%d].limitRanges[%d].name: cannot be empty", i, j)
+			}
+			err := bootstrap.ConvertSpec(limitRange.Spec, &k8sv1.LimitRangeSpec{})
+			if err != nil {
+				return errors.Errorf("namespaces[%d].limitRanges[%d].%v", i, j, err)
+			}
+		}
+		for j, serviceAccount := range namespace.ServiceAccounts {
+			if serviceAccount.Name == "" {
+				return errors.Errorf("namespaces[%d].serviceAccounts[%d].name: cannot be empty", i, j)
+			}
+		}
+	}
+
+	return nil
+}
+
 func validatePullSecrets(config *latest.Config) error {
 	for i, ps := range config.PullSecrets {
 		if ps.Registry == "" {
@@ -272,6 +402,61 @@ func validatePullSecrets(config *latest.Config) error {
 	return nil
 }
 
+// validateRuntimeVariables makes sure runtime variables are only used where they are resolved, which are
+// the command deployments. Variables that are defined by the user are regular variables
+func validateRuntimeVariables(preparedConfig map[interface{}]interface{}, definedVars map[string]bool) error {
+	return findRuntimeVariables(preparedConfig, "", definedVars)
+}
+
+func findRuntimeVariables(value interface{}, path string, definedVars map[string]bool) error {
+	switch t := value.(type) {
+	case map[interface{}]interface{}:
+		for k, v := range t {
+			childPath := fmt.Sprintf("%v", k)
+			if path != "" {
+				childPath = path + "." + childPath
+			}
+			if k == "command" && strings.HasPrefix(path, "deployments[") {
+				continue
+			}
+
+			err := findRuntimeVariables(v, childPath, definedVars)
+			if err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for i, v := range t {
+			err := findRuntimeVariables(v, fmt.Sprintf("%s[%d]", path, i), definedVars)
+			if err != nil {
+				return err
+			}
+		}
+	case string:
+		_, err := varspkg.ParseString(t, func(name string) (interface{}, error) {
+			name = strings.TrimSpace(name)
+			if variable.IsRuntimeVariable(name) && definedVars[name] == false {
+				return nil, errors.Errorf("%s: runtime variable ${%s} can only be used in deployments[*].command", path, name)
+			}
+
+			return "", nil
+		})
+		return err
+	}
+
+	return nil
+}
+
+func validateSecrets(config *latest.Config) error {
+	for i, secret := range config.Secrets {
+		if secret.Path == "" {
+			return errors.Errorf("secrets[%d].path: cannot be empty", i)
+		}
+	}
+
+	return nil
+}
+
 func validateImages(config *latest.Config) error {
 	// images lists all the image names in order to check for duplicates
 	images := map[string]bool{}
@@ -288,6 +473,15 @@ func validateImages(config *latest.Config) error {
 		if imageConf.Build != nil && imageConf.Build.Custom != nil && imageConf.Build.Custom.Command == "" && len(imageConf.Build.Custom.Commands) == 0 {
 			return errors.Errorf("images.%s.build.custom.command or images.%s.build.custom.commands is required", imageConfigName, imageConfigName)
 		}
+		if imageConf.Build != nil && imageConf.Build.Jib != nil && imageConf.Build.Jib.Type != "" && imageConf.Build.Jib.Type != latest.JibTypeMaven && imageConf.Build.Jib.Type != latest.JibTypeGradle {
+			return errors.Errorf("images.%s.build.jib.type %s is invalid. Please choose one of %v", imageConfigName, string(imageConf.Build.Jib.Type), []latest.JibType{latest.JibTypeMaven, latest.JibTypeGradle})
+		}
+		if imageConf.Verify != nil && imageConf.Build != nil {
+			build := imageConf.Build
+			if build.Custom != nil || build.Ko != nil || build.Jib != nil || (build.Buildpacks == nil && (build.BuildKit != nil || (build.Docker == nil && build.Kaniko != nil))) {
+				return errors.Errorf("images.%s.verify is only supported with the docker and buildpacks builders, because the other builders push the image while building it", imageConfigName)
+			}
+		}
 		if images[imageConf.Image] {
 			return errors.Errorf("multiple image definitions with the same image name are not allowed")
 		}
@@ -307,6 +501,17 @@ func validateImages(config *latest.Config) error {
 				}
 			}
 		}
+		if imageConf.Build != nil && imageConf.Build.Kaniko != nil && imageConf.Build.Kaniko.Reuse != nil {
+			if imageConf.Build.Kaniko.Reuse.IdleTimeout != nil && *imageConf.Build.Kaniko.Reuse.IdleTimeout <= 0 {
+				return errors.Errorf("images.%s.build.kaniko.reuse.idleTimeout has to be greater than 0", imageConfigName)
+			}
+			if imageConf.Build.Kaniko.Reuse.CacheVolume != nil && imageConf.Build.Kaniko.Reuse.CacheVolume.Size != "" {
+				_, err := resource.ParseQuantity(imageConf.Build.Kaniko.Reuse.CacheVolume.Size)
+				if err != nil {
+					return errors.Errorf("images.%s.build.kaniko.reuse.cacheVolume.size is invalid: %v", imageConfigName, err)
+				}
+			}
+		}
 		images[imageConf.Image] = true
 	}
 
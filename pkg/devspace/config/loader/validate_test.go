@@ -0,0 +1,66 @@
+package loader
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v2"
+	"gotest.tools/assert"
+)
+
+type validateRuntimeVariablesTestCase struct {
+	name string
+
+	config      string
+	definedVars map[string]bool
+
+	expectedErr string
+}
+
+func TestValidateRuntimeVariables(t *testing.T) {
+	testCases := []validateRuntimeVariablesTestCase{
+		{
+			name: "Runtime variable in command deployment",
+			config: `
+deployments:
+- name: test
+  command:
+    deploy: terraform apply -var tag=${runtime.images.backend.tag}`,
+		},
+		{
+			name: "Runtime variable in helm values",
+			config: `
+deployments:
+- name: test
+  helm:
+    values:
+      tag: ${runtime.images.backend.tag}`,
+			expectedErr: "deployments[0].helm.values.tag: runtime variable ${runtime.images.backend.tag} can only be used in deployments[*].command",
+		},
+		{
+			name: "Escaped runtime variable",
+			config: `
+hooks:
+- command: echo $${runtime.images.backend.tag}`,
+		},
+		{
+			name: "Defined variable with runtime prefix",
+			config: `
+hooks:
+- command: echo ${runtime.value}`,
+			definedVars: map[string]bool{"runtime.value": true},
+		},
+	}
+
+	for _, testCase := range testCases {
+		config := map[interface{}]interface{}{}
+		err := yaml.Unmarshal([]byte(testCase.config), &config)
+		assert.NilError(t, err, "Error parsing config in testCase %s", testCase.name)
+
+		err = validateRuntimeVariables(config, testCase.definedVars)
+		if testCase.expectedErr == "" {
+			assert.NilError(t, err, "Error in testCase %s", testCase.name)
+		} else {
+			assert.Error(t, err, testCase.expectedErr, "Wrong or no error in testCase %s", testCase.name)
+		}
+	}
+}
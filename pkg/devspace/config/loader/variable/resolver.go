@@ -14,6 +14,7 @@ import (
 func NewResolver(cache map[string]string, predefinedVariableOptions *PredefinedVariableOptions, log log.Logger) Resolver {
 	return &resolver{
 		memoryCache:     map[string]interface{}{},
+		definedVars:     map[string]bool{},
 		persistentCache: cache,
 		options:         predefinedVariableOptions,
 		log:             log,
@@ -22,6 +23,7 @@ func NewResolver(cache map[string]string, predefinedVariableOptions *PredefinedV
 
 type resolver struct {
 	memoryCache     map[string]interface{}
+	definedVars     map[string]bool
 	persistentCache map[string]string
 	options         *PredefinedVariableOptions
 	log             log.Logger
@@ -58,11 +60,17 @@ func (r *resolver) ReplaceString(str string) (interface{}, error) {
 }
 
 func (r *resolver) FindVariables(haystack map[interface{}]interface{}, vars []*latest.Variable) (map[string]bool, error) {
+	for _, v := range vars {
+		r.definedVars[strings.TrimSpace(v.Name)] = true
+	}
+
 	// find out what vars are really used
 	varsUsed := map[string]bool{}
 	err := walk.Walk(haystack, varMatchFn, func(value string) (interface{}, error) {
 		_, _ = varspkg.ParseString(value, func(v string) (interface{}, error) {
-			varsUsed[v] = true
+			if r.isRuntimeVariable(strings.TrimSpace(v)) == false {
+				varsUsed[v] = true
+			}
 			return "", nil
 		})
 
@@ -103,6 +111,11 @@ func (r *resolver) ConvertFlags(flags []string) (map[string]interface{}, error)
 func (r *resolver) Resolve(name string, definition *latest.Variable) (interface{}, error) {
 	name = strings.TrimSpace(name)
 
+	// runtime variables are resolved during execution
+	if definition == nil && r.isRuntimeVariable(name) {
+		return "${" + name + "}", nil
+	}
+
 	// check if in vars already
 	v, ok := r.memoryCache[name]
 	if ok {
@@ -126,6 +139,16 @@ func (r *resolver) Resolve(name string, definition *latest.Variable) (interface{
 	return value, nil
 }
 
+// isRuntimeVariable checks if the variable is a runtime variable that is not defined by the user
+func (r *resolver) isRuntimeVariable(name string) bool {
+	if IsRuntimeVariable(name) == false || r.definedVars[name] {
+		return false
+	}
+
+	_, ok := r.memoryCache[name]
+	return !ok
+}
+
 func (r *resolver) findVariablesInDefinition(definition *latest.Variable) map[string]bool {
 	varsUsed := map[string]bool{}
 	if definition == nil {
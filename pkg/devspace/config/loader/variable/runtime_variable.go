@@ -0,0 +1,26 @@
+package variable
+
+import (
+	"regexp"
+	"strings"
+)
+
+// RuntimeVariablePrefix is the prefix of variables that are not resolved while loading the config, but
+// during execution, e.g. ${runtime.images.backend.tag}
+const RuntimeVariablePrefix = "runtime."
+
+// escapedRuntimeVariableRegex matches escaped runtime variables, e.g. $${runtime.images.backend.tag}
+var escapedRuntimeVariableRegex = regexp.MustCompile(`\$\$+!?\{\s*` + regexp.QuoteMeta(RuntimeVariablePrefix))
+
+// IsRuntimeVariable checks if the variable with the given name is a runtime variable
+func IsRuntimeVariable(name string) bool {
+	return strings.HasPrefix(name, RuntimeVariablePrefix)
+}
+
+// KeepRuntimeVariableEscapes escapes the escaped runtime variables in the value once more. Filling in the
+// variables removes one escape, so the runtime variables are still escaped when they are replaced during execution
+func KeepRuntimeVariableEscapes(value string) string {
+	return escapedRuntimeVariableRegex.ReplaceAllStringFunc(value, func(match string) string {
+		return "$" + match
+	})
+}
@@ -36,10 +36,18 @@ type Config struct {
 	// Vars are config variables that can be used inside other config sections to replace certain values dynamically
 	Vars []*Variable `yaml:"vars,omitempty" json:"vars,omitempty" patchStrategy:"merge" patchMergeKey:"name"`
 
+	// Namespaces are namespaces that will be created or updated by devspace together with their quotas,
+	// limit ranges and service accounts before image pull secrets are created and anything is deployed
+	Namespaces []*NamespaceConfig `yaml:"namespaces,omitempty" json:"namespaces,omitempty" patchStrategy:"merge" patchMergeKey:"name"`
+
 	// PullSecrets are image pull secrets that will be created by devspace in the target namespace
 	// during devspace dev or devspace deploy
 	PullSecrets []*PullSecretConfig `yaml:"pullSecrets,omitempty" json:"pullSecrets,omitempty" patchStrategy:"merge" patchMergeKey:"registry"`
 
+	// Secrets are encrypted secret files that will be decrypted and created as kubernetes secrets by devspace
+	// during devspace dev or devspace deploy
+	Secrets []*SecretConfig `yaml:"secrets,omitempty" json:"secrets,omitempty" patchStrategy:"merge" patchMergeKey:"path"`
+
 	// Images holds configuration of how devspace should build images
 	Images map[string]*ImageConfig `yaml:"images,omitempty" json:"images,omitempty"`
 
@@ -148,6 +156,39 @@ type ImageConfig struct {
 
 	// Specific build options how to build the specified image
 	Build *BuildConfig `yaml:"build,omitempty" json:"build,omitempty"`
+
+	// Verify defines checks that are run after the image was built. The docker builder runs
+	// them before the image is pushed, other builders after the build has finished
+	Verify *VerifyConfig `yaml:"verify,omitempty" json:"verify,omitempty"`
+}
+
+// VerifyConfig defines how a built image should be verified
+type VerifyConfig struct {
+	// Command is a scanner command that is executed with the env variable DEVSPACE_IMAGE set
+	// to the built image. A non zero exit code fails the verification
+	Command string `yaml:"command,omitempty" json:"command,omitempty"`
+
+	// Args are optional and if defined, command is not executed within a shell
+	// and rather directly.
+	Args []string `yaml:"args,omitempty" json:"args,omitempty"`
+
+	// Policy defines rules the config of the built image has to satisfy
+	Policy *VerifyPolicy `yaml:"policy,omitempty" json:"policy,omitempty"`
+
+	// WarnOnly prints the findings instead of failing the build
+	WarnOnly bool `yaml:"warnOnly,omitempty" json:"warnOnly,omitempty"`
+}
+
+// VerifyPolicy defines rules for the config of a built image
+type VerifyPolicy struct {
+	// DisallowRootUser fails if the image runs as root
+	DisallowRootUser bool `yaml:"disallowRootUser,omitempty" json:"disallowRootUser,omitempty"`
+
+	// AllowedPorts are the ports the image is allowed to expose, e.g. 8080 or 53/udp
+	AllowedPorts []string `yaml:"allowedPorts,omitempty" json:"allowedPorts,omitempty"`
+
+	// RequiredLabels are the labels that have to be set on the image
+	RequiredLabels []string `yaml:"requiredLabels,omitempty" json:"requiredLabels,omitempty"`
 }
 
 // RebuildStrategy is the type of a image rebuild strategy
@@ -176,6 +217,18 @@ type BuildConfig struct {
 	// a custom script.
 	Custom *CustomConfig `yaml:"custom,omitempty" json:"custom,omitempty"`
 
+	// If buildpacks is specified, DevSpace will build the image with Cloud Native Buildpacks
+	// using the pack cli. No Dockerfile is needed in this case
+	Buildpacks *BuildpacksConfig `yaml:"buildpacks,omitempty" json:"buildpacks,omitempty"`
+
+	// If ko is specified, DevSpace will build the go application with ko without
+	// a Dockerfile or docker daemon
+	Ko *KoConfig `yaml:"ko,omitempty" json:"ko,omitempty"`
+
+	// If jib is specified, DevSpace will build the java application with jib without
+	// a Dockerfile or docker daemon
+	Jib *JibConfig `yaml:"jib,omitempty" json:"jib,omitempty"`
+
 	// This overrides other options and is able to disable the build for this image.
 	// Useful if you just want to select the image in a sync path or via devspace enter --image
 	Disabled bool `yaml:"disabled,omitempty" json:"disabled,omitempty"`
@@ -213,6 +266,77 @@ type BuildKitConfig struct {
 	Options *BuildOptions `yaml:"options,omitempty" json:"options,omitempty"`
 }
 
+// BuildpacksConfig tells the DevSpace CLI to build the image with Cloud Native Buildpacks
+type BuildpacksConfig struct {
+	// The builder image to use. Defaults to paketobuildpacks/builder:base
+	Builder string `yaml:"builder,omitempty" json:"builder,omitempty"`
+
+	// The buildpacks to use. If empty, the builder will detect the buildpacks
+	Buildpacks []string `yaml:"buildpacks,omitempty" json:"buildpacks,omitempty"`
+
+	// Environment variables that are passed to the buildpacks during the build
+	Env map[string]string `yaml:"env,omitempty" json:"env,omitempty"`
+
+	// If this is true, DevSpace will not push any images
+	SkipPush bool `yaml:"skipPush,omitempty" json:"skipPush,omitempty"`
+
+	// Additional arguments to call pack build with
+	Args []string `yaml:"args,omitempty" json:"args,omitempty"`
+
+	// Override the base command to build images. Defaults to ["pack"]
+	Command []string `yaml:"command,omitempty" json:"command,omitempty"`
+}
+
+// KoConfig tells the DevSpace CLI to build the image with ko
+type KoConfig struct {
+	// The go import path of the main package to build relative to the context. Defaults to "."
+	ImportPath string `yaml:"importPath,omitempty" json:"importPath,omitempty"`
+
+	// The platforms to build the image for, e.g. linux/amd64
+	Platforms []string `yaml:"platforms,omitempty" json:"platforms,omitempty"`
+
+	// Environment variables that are set for the go build, e.g. CGO_ENABLED=0
+	Env map[string]string `yaml:"env,omitempty" json:"env,omitempty"`
+
+	// If this is true, DevSpace will not push any images
+	SkipPush bool `yaml:"skipPush,omitempty" json:"skipPush,omitempty"`
+
+	// Additional arguments to call ko build with
+	Args []string `yaml:"args,omitempty" json:"args,omitempty"`
+
+	// Override the base command to build images. Defaults to ["ko"]
+	Command []string `yaml:"command,omitempty" json:"command,omitempty"`
+}
+
+// JibConfig tells the DevSpace CLI to build the image with jib
+type JibConfig struct {
+	// The build tool jib is used with, either maven or gradle. If empty,
+	// DevSpace detects the build tool by looking for a pom.xml in the context
+	Type JibType `yaml:"type,omitempty" json:"type,omitempty"`
+
+	// The maven module or gradle project to build
+	Project string `yaml:"project,omitempty" json:"project,omitempty"`
+
+	// If this is true, DevSpace will not push any images
+	SkipPush bool `yaml:"skipPush,omitempty" json:"skipPush,omitempty"`
+
+	// Additional arguments to call maven or gradle with
+	Args []string `yaml:"args,omitempty" json:"args,omitempty"`
+
+	// Override the base command to build images. Defaults to the maven or gradle wrapper
+	// in the context if found and mvn or gradle otherwise
+	Command []string `yaml:"command,omitempty" json:"command,omitempty"`
+}
+
+// JibType is the type of the build tool jib is used with
+type JibType string
+
+// List of build tools jib can be used with
+const (
+	JibTypeMaven  JibType = "maven"
+	JibTypeGradle JibType = "gradle"
+)
+
 // BuildKitInClusterConfig holds the buildkit builder config
 type BuildKitInClusterConfig struct {
 	// Name is the name of the builder to use. If omitted, DevSpace will try to create
@@ -312,6 +436,33 @@ type KanikoConfig struct {
 
 	// other build options that will be passed to the kaniko pod
 	Options *BuildOptions `yaml:"options,omitempty" json:"options,omitempty"`
+
+	// if set, devspace will reuse a long lived kaniko builder pod per namespace instead
+	// of creating a new build pod for every build
+	Reuse *KanikoReuseConfig `yaml:"reuse,omitempty" json:"reuse,omitempty"`
+}
+
+// KanikoReuseConfig tells devspace how the reusable kaniko builder pod should look like
+type KanikoReuseConfig struct {
+	// Enabled specifies if the builder pod should be reused
+	Enabled *bool `yaml:"enabled,omitempty" json:"enabled,omitempty"`
+
+	// IdleTimeout is the time in seconds after the last build when the builder pod
+	// terminates itself. Defaults to 1800
+	IdleTimeout *int64 `yaml:"idleTimeout,omitempty" json:"idleTimeout,omitempty"`
+
+	// CacheVolume if set creates a persistent volume claim that holds the kaniko base
+	// image cache and survives the builder pod
+	CacheVolume *KanikoCacheVolume `yaml:"cacheVolume,omitempty" json:"cacheVolume,omitempty"`
+}
+
+// KanikoCacheVolume describes the persistent volume claim of the kaniko base image cache
+type KanikoCacheVolume struct {
+	// Size is the requested storage size of the volume, defaults to 10Gi
+	Size string `yaml:"size,omitempty" json:"size,omitempty"`
+
+	// StorageClassName is the storage class of the volume
+	StorageClassName string `yaml:"storageClassName,omitempty" json:"storageClassName,omitempty"`
 }
 
 // KanikoPodResources describes the resources section of the started kaniko pod
@@ -442,10 +593,13 @@ type BuildOptions struct {
 
 // DeploymentConfig defines the configuration how the devspace should be deployed
 type DeploymentConfig struct {
-	Name      string         `yaml:"name" json:"name"`
-	Namespace string         `yaml:"namespace,omitempty" json:"namespace,omitempty"`
-	Helm      *HelmConfig    `yaml:"helm,omitempty" json:"helm,omitempty"`
-	Kubectl   *KubectlConfig `yaml:"kubectl,omitempty" json:"kubectl,omitempty"`
+	Name      string                   `yaml:"name" json:"name"`
+	Namespace string                   `yaml:"namespace,omitempty" json:"namespace,omitempty"`
+	DependsOn []string                 `yaml:"dependsOn,omitempty" json:"dependsOn,omitempty"`
+	Helm      *HelmConfig              `yaml:"helm,omitempty" json:"helm,omitempty"`
+	Kubectl   *KubectlConfig           `yaml:"kubectl,omitempty" json:"kubectl,omitempty"`
+	Command   *CommandDeploymentConfig `yaml:"command,omitempty" json:"command,omitempty"`
+	Component *ComponentConfig         `yaml:"component,omitempty" json:"component,omitempty"`
 }
 
 // ComponentConfig holds the component information
@@ -629,7 +783,9 @@ type HelmConfig struct {
 	ComponentChart   *bool                       `yaml:"componentChart,omitempty" json:"componentChart,omitempty"`
 	Values           map[interface{}]interface{} `yaml:"values,omitempty" json:"values,omitempty"`
 	ValuesFiles      []string                    `yaml:"valuesFiles,omitempty" json:"valuesFiles,omitempty"`
+	ValuesFrom       []*HelmValuesFromConfig     `yaml:"valuesFrom,omitempty" json:"valuesFrom,omitempty"`
 	ReplaceImageTags *bool                       `yaml:"replaceImageTags,omitempty" json:"replaceImageTags,omitempty"`
+	PinImageDigests  bool                        `yaml:"pinImageDigests,omitempty" json:"pinImageDigests,omitempty"`
 	Wait             bool                        `yaml:"wait,omitempty" json:"wait,omitempty"`
 	DisplayOutput    bool                        `yaml:"displayOutput,omitempty" json:"output,omitempty"`
 	Timeout          *int64                      `yaml:"timeout,omitempty" json:"timeout,omitempty"`
@@ -642,6 +798,7 @@ type HelmConfig struct {
 	Path             string                      `yaml:"path,omitempty" json:"path,omitempty"`
 	V2               bool                        `yaml:"v2,omitempty" json:"v2,omitempty"`
 	TillerNamespace  string                      `yaml:"tillerNamespace,omitempty" json:"tillerNamespace,omitempty"`
+	PostRenderer     *HelmPostRendererConfig     `yaml:"postRenderer,omitempty" json:"postRenderer,omitempty"`
 
 	DeleteArgs   []string `yaml:"deleteArgs,omitempty" json:"deleteArgs,omitempty"`
 	TemplateArgs []string `yaml:"templateArgs,omitempty" json:"templateArgs,omitempty"`
@@ -649,6 +806,50 @@ type HelmConfig struct {
 	FetchArgs    []string `yaml:"fetchArgs,omitempty" json:"fetchArgs,omitempty"`
 }
 
+// HelmValuesFromConfig defines a source of helm values that is resolved during deployment. Exactly one of
+// secret, configMap, release or template has to be specified
+type HelmValuesFromConfig struct {
+	Secret    *HelmValuesFromKeyRef `yaml:"secret,omitempty" json:"secret,omitempty"`
+	ConfigMap *HelmValuesFromKeyRef `yaml:"configMap,omitempty" json:"configMap,omitempty"`
+	Release   *HelmValuesFromKeyRef `yaml:"release,omitempty" json:"release,omitempty"`
+	Template  string                `yaml:"template,omitempty" json:"template,omitempty"`
+
+	// TargetPath is the dot separated path the value is inserted at (e.g. postgresql.auth.password). If
+	// empty, the value has to be a yaml object that is merged into the values
+	TargetPath string `yaml:"targetPath,omitempty" json:"targetPath,omitempty"`
+
+	// If optional is true, the source is skipped if it does not exist
+	Optional bool `yaml:"optional,omitempty" json:"optional,omitempty"`
+}
+
+// HelmValuesFromKeyRef references a value of a secret, config map or helm release
+type HelmValuesFromKeyRef struct {
+	Name      string `yaml:"name" json:"name"`
+	Namespace string `yaml:"namespace,omitempty" json:"namespace,omitempty"`
+
+	// Key is the data key of a secret or config map. For releases it is an optional dot separated
+	// path within the values of the release
+	Key string `yaml:"key,omitempty" json:"key,omitempty"`
+}
+
+// HelmPostRendererConfig defines how devspace post-renders the manifests of a helm chart
+type HelmPostRendererConfig struct {
+	// If true (default), images in the rendered manifests are replaced like in kubectl deployments
+	ReplaceImageTags *bool `yaml:"replaceImageTags,omitempty" json:"replaceImageTags,omitempty"`
+
+	// Resources holds the JSON patches that are applied to matching resources
+	Resources []*PostRendererResourceConfig `yaml:"resources,omitempty" json:"resources,omitempty"`
+}
+
+// PostRendererResourceConfig defines the patches that are applied to a rendered resource. Empty
+// target fields match every resource
+type PostRendererResourceConfig struct {
+	APIVersion string         `yaml:"apiVersion,omitempty" json:"apiVersion,omitempty"`
+	Kind       string         `yaml:"kind,omitempty" json:"kind,omitempty"`
+	Name       string         `yaml:"name,omitempty" json:"name,omitempty"`
+	Patches    []*PatchConfig `yaml:"patches,omitempty" json:"patches,omitempty"`
+}
+
 // ChartConfig defines the helm chart options
 type ChartConfig struct {
 	Name     string `yaml:"name,omitempty" json:"name,omitempty"`
@@ -660,14 +861,45 @@ type ChartConfig struct {
 
 // KubectlConfig defines the specific kubectl options used during deployment
 type KubectlConfig struct {
-	Manifests        []string `yaml:"manifests,omitempty" json:"manifests,omitempty"`
-	Kustomize        *bool    `yaml:"kustomize,omitempty" json:"kustomize,omitempty"`
-	KustomizeArgs    []string `yaml:"kustomizeArgs,omitempty" json:"kustomizeArgs,omitempty"`
-	ReplaceImageTags *bool    `yaml:"replaceImageTags,omitempty" json:"replaceImageTags,omitempty"`
-	DeleteArgs       []string `yaml:"deleteArgs,omitempty" json:"deleteArgs,omitempty"`
-	CreateArgs       []string `yaml:"createArgs,omitempty" json:"createArgs,omitempty"`
-	ApplyArgs        []string `yaml:"applyArgs,omitempty" json:"applyArgs,omitempty"`
-	CmdPath          string   `yaml:"cmdPath,omitempty" json:"cmdPath,omitempty"`
+	Manifests           []string `yaml:"manifests,omitempty" json:"manifests,omitempty"`
+	Kustomize           *bool    `yaml:"kustomize,omitempty" json:"kustomize,omitempty"`
+	KustomizeArgs       []string `yaml:"kustomizeArgs,omitempty" json:"kustomizeArgs,omitempty"`
+	KustomizeBinaryPath string   `yaml:"kustomizeBinaryPath,omitempty" json:"kustomizeBinaryPath,omitempty"`
+	ReplaceImageTags    *bool    `yaml:"replaceImageTags,omitempty" json:"replaceImageTags,omitempty"`
+	PinImageDigests     bool     `yaml:"pinImageDigests,omitempty" json:"pinImageDigests,omitempty"`
+	DeleteArgs          []string `yaml:"deleteArgs,omitempty" json:"deleteArgs,omitempty"`
+	CreateArgs          []string `yaml:"createArgs,omitempty" json:"createArgs,omitempty"`
+	ApplyArgs           []string `yaml:"applyArgs,omitempty" json:"applyArgs,omitempty"`
+	ServerSideApply     bool     `yaml:"serverSideApply,omitempty" json:"serverSideApply,omitempty"`
+	Prune               bool     `yaml:"prune,omitempty" json:"prune,omitempty"`
+	Wait                bool     `yaml:"wait,omitempty" json:"wait,omitempty"`
+	Timeout             *int64   `yaml:"timeout,omitempty" json:"timeout,omitempty"`
+	CmdPath             string   `yaml:"cmdPath,omitempty" json:"cmdPath,omitempty"`
+}
+
+// CommandDeploymentConfig defines a deployment that is deployed by executing commands, e.g. terraform or a vendor cli
+type CommandDeploymentConfig struct {
+	// Deploy is executed to deploy the deployment
+	Deploy *DeploymentCommand `yaml:"deploy,omitempty" json:"deploy,omitempty"`
+	// Render is executed by devspace render and should print the rendered manifests to stdout
+	Render *DeploymentCommand `yaml:"render,omitempty" json:"render,omitempty"`
+	// Status is executed by devspace list deployments and should print the status to stdout
+	Status *DeploymentCommand `yaml:"status,omitempty" json:"status,omitempty"`
+	// Purge is executed to delete the deployment
+	Purge *DeploymentCommand `yaml:"purge,omitempty" json:"purge,omitempty"`
+
+	// Files are the files, folders or glob patterns that are hashed to find out if the deployment has
+	// to be redeployed
+	Files []string `yaml:"files,omitempty" json:"files,omitempty"`
+}
+
+// DeploymentCommand defines a command of a command deployment
+type DeploymentCommand struct {
+	// Command is the command to execute. Runtime variables like ${runtime.images.backend.tag} are replaced
+	// with the built images
+	Command string `yaml:"command" json:"command"`
+	// Args are optional and if defined, command is not executed within a shell and rather directly
+	Args []string `yaml:"args,omitempty" json:"args,omitempty"`
 }
 
 // DevConfig defines the devspace deployment
@@ -1036,6 +1268,9 @@ type HookWhenConfig struct {
 	Before  *HookWhenAtConfig `yaml:"before,omitempty" json:"before,omitempty"`
 	After   *HookWhenAtConfig `yaml:"after,omitempty" json:"after,omitempty"`
 	OnError *HookWhenAtConfig `yaml:"onError,omitempty" json:"onError,omitempty"`
+
+	// AfterVerify is only supported for the images stage and executed after an image was verified
+	AfterVerify *HookWhenAtConfig `yaml:"afterVerify,omitempty" json:"afterVerify,omitempty"`
 }
 
 // HookWhenAtConfig defines at which stage the hook should be executed
@@ -1045,6 +1280,7 @@ type HookWhenAtConfig struct {
 	Deployments      string `yaml:"deployments,omitempty" json:"deployments,omitempty"`
 	Dependencies     string `yaml:"dependencies,omitempty" json:"dependencies,omitempty"`
 	PullSecrets      string `yaml:"pullSecrets,omitempty" json:"pullSecrets,omitempty"`
+	Namespaces       string `yaml:"namespaces,omitempty" json:"namespaces,omitempty"`
 }
 
 // CommandConfig defines the command specification
@@ -1127,7 +1363,9 @@ type ProfileConfig struct {
 // ProfileConfigStructure is the base structure used to validate profiles
 type ProfileConfigStructure struct {
 	Vars         []interface{}               `yaml:"vars,omitempty" json:"vars,omitempty" patchStrategy:"merge" patchMergeKey:"name"`
+	Namespaces   []interface{}               `yaml:"namespaces,omitempty" json:"namespaces,omitempty" patchStrategy:"merge" patchMergeKey:"name"`
 	PullSecrets  []interface{}               `yaml:"pullSecrets,omitempty" json:"pullSecrets,omitempty" patchStrategy:"merge" patchMergeKey:"registry"`
+	Secrets      []interface{}               `yaml:"secrets,omitempty" json:"secrets,omitempty" patchStrategy:"merge" patchMergeKey:"path"`
 	Images       map[interface{}]interface{} `yaml:"images,omitempty" json:"images,omitempty"`
 	Deployments  []interface{}               `yaml:"deployments,omitempty" json:"deployments,omitempty" patchStrategy:"merge" patchMergeKey:"name"`
 	Dev          map[interface{}]interface{} `yaml:"dev,omitempty" json:"dev,omitempty"`
@@ -1150,6 +1388,61 @@ type PatchConfig struct {
 	From      string      `yaml:"from,omitempty" json:"from,omitempty"`
 }
 
+// NamespaceConfig defines a namespace that should be created or updated by DevSpace
+type NamespaceConfig struct {
+	// Name of the namespace
+	Name string `yaml:"name" json:"name"`
+
+	// Labels are added to the namespace, e.g. istio-injection: enabled
+	Labels map[string]string `yaml:"labels,omitempty" json:"labels,omitempty"`
+
+	// Annotations are added to the namespace
+	Annotations map[string]string `yaml:"annotations,omitempty" json:"annotations,omitempty"`
+
+	// ResourceQuotas are created or updated in the namespace
+	ResourceQuotas []*NamespaceResourceConfig `yaml:"resourceQuotas,omitempty" json:"resourceQuotas,omitempty"`
+
+	// LimitRanges are created or updated in the namespace
+	LimitRanges []*NamespaceResourceConfig `yaml:"limitRanges,omitempty" json:"limitRanges,omitempty"`
+
+	// ServiceAccounts are created or updated in the namespace. The default service account
+	// can be configured by using the name default
+	ServiceAccounts []*ServiceAccountConfig `yaml:"serviceAccounts,omitempty" json:"serviceAccounts,omitempty"`
+}
+
+// NamespaceResourceConfig defines a resource quota or limit range within a namespace
+type NamespaceResourceConfig struct {
+	// Name of the resource
+	Name string `yaml:"name" json:"name"`
+
+	// Spec is the kubernetes spec of the resource quota or limit range
+	Spec map[interface{}]interface{} `yaml:"spec,omitempty" json:"spec,omitempty"`
+}
+
+// ServiceAccountConfig defines a service account within a namespace
+type ServiceAccountConfig struct {
+	// Name of the service account
+	Name string `yaml:"name" json:"name"`
+
+	// Labels are added to the service account
+	Labels map[string]string `yaml:"labels,omitempty" json:"labels,omitempty"`
+
+	// Annotations are added to the service account, e.g. eks.amazonaws.com/role-arn
+	Annotations map[string]string `yaml:"annotations,omitempty" json:"annotations,omitempty"`
+
+	// AutomountServiceAccountToken sets automountServiceAccountToken of the service account
+	AutomountServiceAccountToken *bool `yaml:"automountServiceAccountToken,omitempty" json:"automountServiceAccountToken,omitempty"`
+}
+
+// SecretConfig defines an encrypted secret file that should be decrypted and created by DevSpace
+type SecretConfig struct {
+	// Path of the encrypted secret file, which can be created with devspace secret encrypt
+	Path string `yaml:"path" json:"path"`
+
+	// Namespace to create the secrets in, if they do not specify a namespace themselves
+	Namespace string `yaml:"namespace,omitempty" json:"namespace,omitempty"`
+}
+
 // PullSecretConfig defines a pull secret that should be created by DevSpace
 type PullSecretConfig struct {
 	// The registry to create the image pull secret for.
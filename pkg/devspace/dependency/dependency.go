@@ -13,6 +13,7 @@ import (
 	"mvdan.cc/sh/v3/interp"
 	"os"
 
+	"github.com/loft-sh/devspace/pkg/devspace/bootstrap"
 	"github.com/loft-sh/devspace/pkg/devspace/build"
 	"github.com/loft-sh/devspace/pkg/devspace/config/generated"
 	"github.com/loft-sh/devspace/pkg/devspace/config/loader"
@@ -20,6 +21,7 @@ import (
 	"github.com/loft-sh/devspace/pkg/devspace/deploy"
 	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
 	"github.com/loft-sh/devspace/pkg/devspace/pullsecrets"
+	"github.com/loft-sh/devspace/pkg/devspace/secrets"
 	"github.com/loft-sh/devspace/pkg/util/hash"
 	"github.com/loft-sh/devspace/pkg/util/log"
 
@@ -360,7 +362,9 @@ type Dependency struct {
 
 	dockerClient     docker.Client
 	kubeClient       kubectl.Client
+	bootstrapClient  bootstrap.Client
 	registryClient   pullsecrets.Client
+	secretsClient    secrets.Client
 	buildController  build.Controller
 	deployController deploy.Controller
 	generatedSaver   generated.ConfigLoader
@@ -424,6 +428,18 @@ func (d *Dependency) Deploy(forceDependencies, skipBuild, skipDeploy, forceDeplo
 		return errors.Errorf("Unable to create namespace: %v", err)
 	}
 
+	// Create namespaces, quotas and service accounts
+	err = d.bootstrapClient.EnsureNamespaces()
+	if err != nil {
+		return err
+	}
+
+	// Create the secrets of the encrypted secret files
+	err = d.secretsClient.ApplySecrets()
+	if err != nil {
+		return err
+	}
+
 	// Create pull secrets and private registry if necessary
 	err = d.registryClient.CreatePullSecrets()
 	if err != nil {
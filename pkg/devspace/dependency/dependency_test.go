@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	fakebootstrap "github.com/loft-sh/devspace/pkg/devspace/bootstrap/testing"
 	fakebuild "github.com/loft-sh/devspace/pkg/devspace/build/testing"
 	"github.com/loft-sh/devspace/pkg/devspace/config/generated"
 	fakegeneratedloader "github.com/loft-sh/devspace/pkg/devspace/config/generated/testing"
@@ -17,6 +18,7 @@ import (
 	fakedeploy "github.com/loft-sh/devspace/pkg/devspace/deploy/testing"
 	fakekube "github.com/loft-sh/devspace/pkg/devspace/kubectl/testing"
 	fakeregistry "github.com/loft-sh/devspace/pkg/devspace/pullsecrets/testing"
+	fakesecrets "github.com/loft-sh/devspace/pkg/devspace/secrets/testing"
 	"github.com/loft-sh/devspace/pkg/util/fsutil"
 	"github.com/loft-sh/devspace/pkg/util/hash"
 	"github.com/loft-sh/devspace/pkg/util/log"
@@ -657,8 +659,10 @@ func TestDeploy(t *testing.T) {
 						},
 					},
 				},
-				kubeClient:     &fakekube.Client{},
-				registryClient: &fakeregistry.Client{},
+				kubeClient:      &fakekube.Client{},
+				bootstrapClient: &fakebootstrap.Client{},
+				registryClient:  &fakeregistry.Client{},
+				secretsClient:   &fakesecrets.Client{},
 				buildController: &fakebuild.FakeController{
 					BuiltImages: map[string]string{
 						"": "",
@@ -2,11 +2,13 @@ package dependency
 
 import (
 	"fmt"
+	"github.com/loft-sh/devspace/pkg/devspace/bootstrap"
 	"github.com/loft-sh/devspace/pkg/devspace/build"
 	"github.com/loft-sh/devspace/pkg/devspace/config"
 	"github.com/loft-sh/devspace/pkg/devspace/dependency/types"
 	"github.com/loft-sh/devspace/pkg/devspace/deploy"
 	"github.com/loft-sh/devspace/pkg/devspace/pullsecrets"
+	"github.com/loft-sh/devspace/pkg/devspace/secrets"
 	"os"
 	"path/filepath"
 	"strings"
@@ -182,7 +184,9 @@ func (r *resolver) resolveRecursive(basePath, parentID string, currentDependency
 			}
 
 			// after we traversed the dependencies initialize the managers with the correct dependencies
+			child.bootstrapClient = bootstrap.NewClient(child.localConfig, child.children, child.kubeClient, r.log)
 			child.registryClient = pullsecrets.NewClient(child.localConfig, child.children, child.kubeClient, child.dockerClient, r.log)
+			child.secretsClient = secrets.NewClient(child.localConfig, child.kubeClient, r.log)
 			child.buildController = build.NewController(child.localConfig, child.children, child.kubeClient)
 			child.deployController = deploy.NewController(child.localConfig, child.children, child.kubeClient)
 		}
@@ -1,6 +1,7 @@
 package deploy
 
 import (
+	"bufio"
 	config2 "github.com/loft-sh/devspace/pkg/devspace/config"
 	"github.com/loft-sh/devspace/pkg/devspace/dependency/types"
 	"io"
@@ -8,6 +9,8 @@ import (
 
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
 	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer/command"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer/component"
 	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer/helm"
 	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer/kubectl"
 	helmclient "github.com/loft-sh/devspace/pkg/devspace/helm"
@@ -18,6 +21,7 @@ import (
 	"github.com/loft-sh/devspace/pkg/util/log"
 
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 )
 
 // Options describe how the deployments should be deployed
@@ -26,13 +30,19 @@ type Options struct {
 	ForceDeploy bool
 	BuiltImages map[string]string
 	Deployments []string
+
+	// Parallel deploys deployments that do not depend on each other in parallel
+	Parallel             bool
+	MaxConcurrentDeploys int
 }
 
 // Controller is the main deploying interface
 type Controller interface {
 	Deploy(options *Options, log log.Logger) error
 	Render(options *Options, out io.Writer, log log.Logger) error
+	Diff(options *Options, out io.Writer, log log.Logger) (bool, error)
 	Purge(deployments []string, log log.Logger) error
+	Rollback(deployments []string, revision int, log log.Logger) error
 }
 
 type controller struct {
@@ -76,30 +86,9 @@ func (c *controller) Render(options *Options, out io.Writer, log log.Logger) err
 				}
 			}
 
-			var (
-				deployClient deployer.Interface
-				err          error
-			)
-
-			if deployConfig.Kubectl != nil {
-				deployClient, err = kubectl.New(c.config, c.dependencies, c.client, deployConfig, log)
-				if err != nil {
-					return errors.Errorf("error render: deployment %s error: %v", deployConfig.Name, err)
-				}
-
-			} else if deployConfig.Helm != nil {
-				// Get helm client
-				helmClient, err := GetCachedHelmClient(c.config.Config(), deployConfig, c.client, helmV2Clients, true, log)
-				if err != nil {
-					return errors.Wrap(err, "get cached helm client")
-				}
-
-				deployClient, err = helm.New(c.config, c.dependencies, helmClient, c.client, deployConfig, log)
-				if err != nil {
-					return errors.Errorf("error render: deployment %s error: %v", deployConfig.Name, err)
-				}
-			} else {
-				return errors.Errorf("error render: deployment %s has no deployment method", deployConfig.Name)
+			deployClient, err := c.createRenderer(deployConfig, helmV2Clients, log)
+			if err != nil {
+				return err
 			}
 
 			err = deployClient.Render(options.BuiltImages, out)
@@ -118,88 +107,291 @@ func (c *controller) Deploy(options *Options, log log.Logger) error {
 	if config.Deployments != nil && len(config.Deployments) > 0 {
 		helmV2Clients := map[string]helmtypes.Client{}
 
-		// Execute before deployments deploy hook
-		err := c.hookExecuter.Execute(hook.Before, hook.StageDeployments, hook.All, hook.Context{Client: c.client}, log)
+		// Sort the deployments by their dependencies
+		deployments, err := SortDeployments(config.Deployments)
 		if err != nil {
 			return err
 		}
 
-		for _, deployConfig := range config.Deployments {
-			if len(options.Deployments) > 0 {
-				shouldSkip := true
-
-				for _, deployment := range options.Deployments {
-					if deployment == strings.TrimSpace(deployConfig.Name) {
-						shouldSkip = false
-						break
-					}
-				}
-
-				if shouldSkip {
-					continue
-				}
+		// Filter the deployments
+		selected := map[string]bool{}
+		deploymentsToDeploy := []*latest.DeploymentConfig{}
+		for _, deployConfig := range deployments {
+			if shouldSkipDeployment(options.Deployments, deployConfig.Name) {
+				continue
 			}
 
-			var (
-				deployClient deployer.Interface
-				err          error
-				method       string
-			)
+			selected[deployConfig.Name] = true
+			deploymentsToDeploy = append(deploymentsToDeploy, deployConfig)
+		}
 
-			if deployConfig.Kubectl != nil {
-				deployClient, err = kubectl.New(c.config, c.dependencies, c.client, deployConfig, log)
-				if err != nil {
-					return errors.Errorf("error deploying: deployment %s error: %v", deployConfig.Name, err)
-				}
+		// Execute before deployments deploy hook
+		err = c.hookExecuter.Execute(hook.Before, hook.StageDeployments, hook.All, hook.Context{Client: c.client}, log)
+		if err != nil {
+			return err
+		}
 
-				method = "kubectl"
-			} else if deployConfig.Helm != nil {
-				// Get helm client
-				helmClient, err := GetCachedHelmClient(c.config.Config(), deployConfig, c.client, helmV2Clients, false, log)
+		if options.Parallel && len(deploymentsToDeploy) > 1 {
+			err = c.deployParallel(deploymentsToDeploy, selected, options, helmV2Clients, log)
+			if err != nil {
+				return err
+			}
+		} else {
+			for _, deployConfig := range deploymentsToDeploy {
+				deployClient, method, err := c.createDeployer(deployConfig, helmV2Clients, log)
 				if err != nil {
 					return err
 				}
 
-				deployClient, err = helm.New(c.config, c.dependencies, helmClient, c.client, deployConfig, log)
+				err = c.deployOne(deployConfig, deployClient, method, options, log)
 				if err != nil {
-					return errors.Errorf("error deploying: deployment %s error: %v", deployConfig.Name, err)
+					return err
 				}
-
-				method = "helm"
-			} else {
-				return errors.Errorf("error deploying: deployment %s has no deployment method", deployConfig.Name)
 			}
+		}
 
-			// Execute before deployment deploy hook
-			err = c.hookExecuter.Execute(hook.Before, hook.StageDeployments, deployConfig.Name, hook.Context{Client: c.client}, log)
-			if err != nil {
-				return err
+		// Execute after deployments deploy hook
+		err = c.hookExecuter.Execute(hook.After, hook.StageDeployments, hook.All, hook.Context{Client: c.client}, log)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type deployResult struct {
+	name string
+	err  error
+}
+
+// deployParallel deploys the deployments in parallel. A deployment is only started after all the
+// deployments it depends on were deployed successfully
+func (c *controller) deployParallel(deployments []*latest.DeploymentConfig, selected map[string]bool, options *Options, helmV2Clients map[string]helmtypes.Client, logger log.Logger) error {
+	// Make sure the deployment caches exist, because they cannot be created concurrently
+	for _, deployConfig := range deployments {
+		c.config.Generated().GetActive().GetDeploymentCache(deployConfig.Name)
+	}
+
+	var (
+		started    = map[string]bool{}
+		done       = map[string]bool{}
+		resultChan = make(chan deployResult)
+		running    = 0
+		firstErr   error
+	)
+
+	for {
+		// Start all deployments whose dependencies are deployed
+		for i, deployConfig := range deployments {
+			if firstErr != nil || (options.MaxConcurrentDeploys > 0 && running >= options.MaxConcurrentDeploys) {
+				break
+			}
+			if started[deployConfig.Name] || !dependenciesDone(deployConfig, done, selected) {
+				continue
 			}
 
-			wasDeployed, err := deployClient.Deploy(options.ForceDeploy, options.BuiltImages)
+			// Create a string log
+			reader, writer := io.Pipe()
+			streamLog := log.NewStreamLogger(writer, logrus.InfoLevel)
+			deployLog := log.NewPrefixLogger("["+deployConfig.Name+"] ", log.Colors[(len(log.Colors)-1)-(i%len(log.Colors))], logger)
+			go func() {
+				scanner := bufio.NewScanner(reader)
+				for scanner.Scan() {
+					deployLog.Info(scanner.Text())
+				}
+			}()
+
+			deployClient, method, err := c.createDeployer(deployConfig, helmV2Clients, streamLog)
 			if err != nil {
-				c.hookExecuter.OnError(hook.StageDeployments, []string{hook.All, deployConfig.Name}, hook.Context{Client: c.client, Error: err}, log)
-				return errors.Errorf("error deploying %s: %v", deployConfig.Name, err)
+				_ = writer.Close()
+				firstErr = err
+				break
 			}
 
-			if wasDeployed {
-				log.Donef("Successfully deployed %s with %s", deployConfig.Name, method)
+			started[deployConfig.Name] = true
+			running++
+			go func(deployConfig *latest.DeploymentConfig) {
+				err := c.deployOne(deployConfig, deployClient, method, options, streamLog)
+				_ = writer.Close()
+				resultChan <- deployResult{name: deployConfig.Name, err: err}
+			}(deployConfig)
+		}
 
-				// Execute after deployment deploy hook
-				err = c.hookExecuter.Execute(hook.After, hook.StageDeployments, deployConfig.Name, hook.Context{Client: c.client}, log)
-				if err != nil {
-					return err
-				}
-			} else {
-				log.Infof("Skipping deployment %s", deployConfig.Name)
+		if running == 0 {
+			break
+		}
+
+		// Wait for the next deployment to finish
+		result := <-resultChan
+		running--
+		if result.err != nil {
+			if firstErr == nil {
+				firstErr = result.err
 			}
+
+			continue
 		}
 
-		// Execute after deployments deploy hook
-		err = c.hookExecuter.Execute(hook.After, hook.StageDeployments, hook.All, hook.Context{Client: c.client}, log)
+		done[result.name] = true
+	}
+
+	return firstErr
+}
+
+// createRenderer creates the deployer for the given deployment that is used to render the deployment
+func (c *controller) createRenderer(deployConfig *latest.DeploymentConfig, helmV2Clients map[string]helmtypes.Client, log log.Logger) (deployer.Interface, error) {
+	if deployConfig.Kubectl != nil {
+		deployClient, err := kubectl.New(c.config, c.dependencies, c.client, deployConfig, log)
+		if err != nil {
+			return nil, errors.Errorf("error render: deployment %s error: %v", deployConfig.Name, err)
+		}
+
+		return deployClient, nil
+	} else if deployConfig.Helm != nil {
+		// Get helm client
+		helmClient, err := GetCachedHelmClient(c.config.Config(), deployConfig, c.client, helmV2Clients, true, log)
+		if err != nil {
+			return nil, errors.Wrap(err, "get cached helm client")
+		}
+
+		deployClient, err := helm.New(c.config, c.dependencies, helmClient, c.client, deployConfig, log)
+		if err != nil {
+			return nil, errors.Errorf("error render: deployment %s error: %v", deployConfig.Name, err)
+		}
+
+		return deployClient, nil
+	} else if deployConfig.Command != nil {
+		deployClient, err := command.New(c.config, c.dependencies, c.client, deployConfig, log)
+		if err != nil {
+			return nil, errors.Errorf("error render: deployment %s error: %v", deployConfig.Name, err)
+		}
+
+		return deployClient, nil
+	} else if deployConfig.Component != nil {
+		deployClient, err := component.New(c.config, c.dependencies, c.client, deployConfig, log)
+		if err != nil {
+			return nil, errors.Errorf("error render: deployment %s error: %v", deployConfig.Name, err)
+		}
+
+		return deployClient, nil
+	}
+
+	return nil, errors.Errorf("error render: deployment %s has no deployment method", deployConfig.Name)
+}
+
+// createDeployer creates the deployer for the given deployment and returns it together with the deployment method
+func (c *controller) createDeployer(deployConfig *latest.DeploymentConfig, helmV2Clients map[string]helmtypes.Client, log log.Logger) (deployer.Interface, string, error) {
+	if deployConfig.Kubectl != nil {
+		deployClient, err := kubectl.New(c.config, c.dependencies, c.client, deployConfig, log)
+		if err != nil {
+			return nil, "", errors.Errorf("error deploying: deployment %s error: %v", deployConfig.Name, err)
+		}
+
+		return deployClient, "kubectl", nil
+	} else if deployConfig.Helm != nil {
+		// Get helm client
+		helmClient, err := GetCachedHelmClient(c.config.Config(), deployConfig, c.client, helmV2Clients, false, log)
+		if err != nil {
+			return nil, "", err
+		}
+
+		deployClient, err := helm.New(c.config, c.dependencies, helmClient, c.client, deployConfig, log)
+		if err != nil {
+			return nil, "", errors.Errorf("error deploying: deployment %s error: %v", deployConfig.Name, err)
+		}
+
+		return deployClient, "helm", nil
+	} else if deployConfig.Command != nil {
+		deployClient, err := command.New(c.config, c.dependencies, c.client, deployConfig, log)
+		if err != nil {
+			return nil, "", errors.Errorf("error deploying: deployment %s error: %v", deployConfig.Name, err)
+		}
+
+		return deployClient, "command", nil
+	} else if deployConfig.Component != nil {
+		deployClient, err := component.New(c.config, c.dependencies, c.client, deployConfig, log)
+		if err != nil {
+			return nil, "", errors.Errorf("error deploying: deployment %s error: %v", deployConfig.Name, err)
+		}
+
+		return deployClient, "component", nil
+	}
+
+	return nil, "", errors.Errorf("error deploying: deployment %s has no deployment method", deployConfig.Name)
+}
+
+// deployOne deploys a single deployment and executes its hooks
+func (c *controller) deployOne(deployConfig *latest.DeploymentConfig, deployClient deployer.Interface, method string, options *Options, log log.Logger) error {
+	// Execute before deployment deploy hook
+	err := c.hookExecuter.Execute(hook.Before, hook.StageDeployments, deployConfig.Name, hook.Context{Client: c.client}, log)
+	if err != nil {
+		return err
+	}
+
+	wasDeployed, err := deployClient.Deploy(options.ForceDeploy, options.BuiltImages)
+	if err != nil {
+		c.hookExecuter.OnError(hook.StageDeployments, []string{hook.All, deployConfig.Name}, hook.Context{Client: c.client, Error: err}, log)
+		return errors.Errorf("error deploying %s: %v", deployConfig.Name, err)
+	}
+
+	if wasDeployed {
+		log.Donef("Successfully deployed %s with %s", deployConfig.Name, method)
+
+		// Execute after deployment deploy hook
+		err = c.hookExecuter.Execute(hook.After, hook.StageDeployments, deployConfig.Name, hook.Context{Client: c.client}, log)
 		if err != nil {
 			return err
 		}
+	} else {
+		log.Infof("Skipping deployment %s", deployConfig.Name)
+	}
+
+	return nil
+}
+
+// shouldSkipDeployment returns true if deployments are specified and the given deployment is not one of them
+func shouldSkipDeployment(deployments []string, name string) bool {
+	if len(deployments) == 0 {
+		return false
+	}
+
+	for _, deployment := range deployments {
+		if deployment == strings.TrimSpace(name) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Rollback rolls back all deployments or a set of deployments to the given revision or the previous revision if revision is 0
+func (c *controller) Rollback(deployments []string, revision int, log log.Logger) error {
+	config := c.config.Config()
+	if len(config.Deployments) == 0 {
+		return nil
+	}
+
+	sortedDeployments, err := SortDeployments(config.Deployments)
+	if err != nil {
+		return err
+	}
+
+	helmV2Clients := map[string]helmtypes.Client{}
+	for _, deployConfig := range sortedDeployments {
+		if shouldSkipDeployment(deployments, deployConfig.Name) {
+			continue
+		}
+
+		deployClient, _, err := c.createDeployer(deployConfig, helmV2Clients, log)
+		if err != nil {
+			return err
+		}
+
+		err = deployClient.Rollback(revision)
+		if err != nil {
+			return errors.Errorf("error rolling back %s: %v", deployConfig.Name, err)
+		}
 	}
 
 	return nil
@@ -215,18 +407,24 @@ func (c *controller) Purge(deployments []string, log log.Logger) error {
 	if config.Deployments != nil {
 		helmV2Clients := map[string]helmtypes.Client{}
 
+		// Sort the deployments by their dependencies
+		sortedDeployments, err := SortDeployments(config.Deployments)
+		if err != nil {
+			return err
+		}
+
 		// Execute before deployments purge hook
-		err := c.hookExecuter.Execute(hook.Before, hook.StagePurgeDeployments, hook.All, hook.Context{Client: c.client}, log)
+		err = c.hookExecuter.Execute(hook.Before, hook.StagePurgeDeployments, hook.All, hook.Context{Client: c.client}, log)
 		if err != nil {
 			return err
 		}
 
 		// Reverse them
-		for i := len(config.Deployments) - 1; i >= 0; i-- {
+		for i := len(sortedDeployments) - 1; i >= 0; i-- {
 			var (
 				err          error
 				deployClient deployer.Interface
-				deployConfig = config.Deployments[i]
+				deployConfig = sortedDeployments[i]
 			)
 
 			// Check if we should skip deleting deployment
@@ -261,6 +459,16 @@ func (c *controller) Purge(deployments []string, log log.Logger) error {
 				if err != nil {
 					return errors.Wrap(err, "create helm client")
 				}
+			} else if deployConfig.Command != nil {
+				deployClient, err = command.New(c.config, c.dependencies, c.client, deployConfig, log)
+				if err != nil {
+					return errors.Wrap(err, "create command client")
+				}
+			} else if deployConfig.Component != nil {
+				deployClient, err = component.New(c.config, c.dependencies, c.client, deployConfig, log)
+				if err != nil {
+					return errors.Wrap(err, "create component client")
+				}
 			} else {
 				return errors.Errorf("error purging: deployment %s has no deployment method", deployConfig.Name)
 			}
@@ -0,0 +1,268 @@
+package command
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	config2 "github.com/loft-sh/devspace/pkg/devspace/config"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/devspace/dependency/types"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer/util"
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
+	"github.com/loft-sh/devspace/pkg/util/command"
+	"github.com/loft-sh/devspace/pkg/util/hash"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/loft-sh/devspace/pkg/util/shell"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+)
+
+const (
+	// DeploymentNameEnv is the env variable that holds the name of the deployment
+	DeploymentNameEnv = "DEVSPACE_DEPLOYMENT_NAME"
+	// NamespaceEnv is the env variable that holds the namespace of the deployment
+	NamespaceEnv = "DEVSPACE_NAMESPACE"
+	// KubeContextEnv is the env variable that holds the kube context devspace uses
+	KubeContextEnv = "DEVSPACE_KUBE_CONTEXT"
+)
+
+// DeployConfig holds the information necessary to deploy via custom commands
+type DeployConfig struct {
+	KubeClient       kubectl.Client
+	DeploymentConfig *latest.DeploymentConfig
+	Log              log.Logger
+
+	config       config2.Config
+	dependencies []types.Dependency
+}
+
+// New creates a new deploy config for command deployments
+func New(config config2.Config, dependencies []types.Dependency, kubeClient kubectl.Client, deployConfig *latest.DeploymentConfig, log log.Logger) (deployer.Interface, error) {
+	if deployConfig.Command == nil {
+		return nil, errors.New("error creating command deploy config: command is nil")
+	} else if deployConfig.Command.Deploy == nil {
+		return nil, errors.New("no deploy command defined for command deploy")
+	}
+
+	return &DeployConfig{
+		KubeClient:       kubeClient,
+		DeploymentConfig: deployConfig,
+		Log:              log,
+
+		config:       config2.Ensure(config),
+		dependencies: dependencies,
+	}, nil
+}
+
+// Status executes the status command and returns its output as status
+func (d *DeployConfig) Status() (*deployer.StatusResult, error) {
+	result := &deployer.StatusResult{
+		Name:   d.DeploymentConfig.Name,
+		Type:   "Command",
+		Target: d.getDeployTarget(),
+	}
+
+	if d.DeploymentConfig.Command.Status == nil {
+		result.Status = "Not deployed"
+		deployCache := d.config.Generated().GetActive().Deployments[d.DeploymentConfig.Name]
+		if deployCache != nil && deployCache.DeploymentConfigHash != "" {
+			result.Status = "Deployed"
+		}
+
+		return result, nil
+	}
+
+	out := &bytes.Buffer{}
+	err := d.execute(d.DeploymentConfig.Command.Status, nil, out, d.Log)
+	if err != nil {
+		result.Status = fmt.Sprintf("Error: %v", err)
+		return result, nil
+	}
+
+	result.Status = strings.TrimSpace(out.String())
+	if idx := strings.Index(result.Status, "\n"); idx != -1 {
+		result.Status = result.Status[:idx]
+	}
+
+	return result, nil
+}
+
+// Drift is not supported for command deployments, because devspace doesn't know what the commands deploy
+func (d *DeployConfig) Drift() ([]*deployer.ResourceDrift, error) {
+	return nil, errors.Errorf("drift detection is not supported for command deployment %s", d.DeploymentConfig.Name)
+}
+
+// Deploy executes the deploy command if the files, the deployment config or the used images have changed
+func (d *DeployConfig) Deploy(forceDeploy bool, builtImages map[string]string) (bool, error) {
+	deployCache := d.config.Generated().GetActive().GetDeploymentCache(d.DeploymentConfig.Name)
+
+	// Hash the files
+	filesHash, err := d.hashFiles()
+	if err != nil {
+		return false, err
+	}
+
+	// Hash the deployment config
+	configStr, err := yaml.Marshal(d.DeploymentConfig)
+	if err != nil {
+		return false, errors.Wrap(err, "marshal deployment config")
+	}
+
+	deploymentConfigHash := hash.String(string(configStr))
+
+	// Check if the command uses a built image
+	deployCommand, shouldRedeploy, err := d.replaceRuntimeVariables(d.DeploymentConfig.Command.Deploy, builtImages)
+	if err != nil {
+		return false, err
+	}
+
+	forceDeploy = forceDeploy || shouldRedeploy || deployCache.CommandFilesHash != filesHash || deployCache.DeploymentConfigHash != deploymentConfigHash
+	if forceDeploy == false {
+		return false, nil
+	}
+
+	d.Log.Infof("Executing deploy command of deployment %s", d.DeploymentConfig.Name)
+	err = d.run(deployCommand, d.Log, d.Log)
+	if err != nil {
+		return false, errors.Wrap(err, "execute deploy command")
+	}
+
+	deployCache.CommandFilesHash = filesHash
+	deployCache.DeploymentConfigHash = deploymentConfigHash
+	return true, nil
+}
+
+// Render executes the render command and writes its output to the out stream
+func (d *DeployConfig) Render(builtImages map[string]string, out io.Writer) error {
+	if d.DeploymentConfig.Command.Render == nil {
+		d.Log.Infof("Skipping deployment %s, because it has no render command", d.DeploymentConfig.Name)
+		return nil
+	}
+
+	err := d.execute(d.DeploymentConfig.Command.Render, builtImages, out, d.Log)
+	if err != nil {
+		return errors.Wrap(err, "execute render command")
+	}
+
+	_, err = out.Write([]byte("\n---\n"))
+	return err
+}
+
+// Rollback is not supported for command deployments, so the deployment is skipped
+func (d *DeployConfig) Rollback(revision int) error {
+	d.Log.Warnf("Skipping rollback of deployment %s, because command deployments cannot be rolled back", d.DeploymentConfig.Name)
+	return nil
+}
+
+// Delete executes the purge command
+func (d *DeployConfig) Delete() error {
+	if d.DeploymentConfig.Command.Purge == nil {
+		d.Log.Warnf("Deployment %s has no purge command, please delete it manually", d.DeploymentConfig.Name)
+	} else {
+		err := d.execute(d.DeploymentConfig.Command.Purge, nil, d.Log, d.Log)
+		if err != nil {
+			return errors.Wrap(err, "execute purge command")
+		}
+	}
+
+	delete(d.config.Generated().GetActive().Deployments, d.DeploymentConfig.Name)
+	return nil
+}
+
+// execute replaces the runtime variables in the command and runs it
+func (d *DeployConfig) execute(cmd *latest.DeploymentCommand, builtImages map[string]string, stdout io.Writer, stderr io.Writer) error {
+	cmd, _, err := d.replaceRuntimeVariables(cmd, builtImages)
+	if err != nil {
+		return err
+	}
+
+	return d.run(cmd, stdout, stderr)
+}
+
+// run runs the command with the deployment information as env variables
+func (d *DeployConfig) run(cmd *latest.DeploymentCommand, stdout io.Writer, stderr io.Writer) error {
+	env := map[string]string{
+		DeploymentNameEnv: d.DeploymentConfig.Name,
+		NamespaceEnv:      d.DeploymentConfig.Namespace,
+	}
+	if d.KubeClient != nil {
+		env[KubeContextEnv] = d.KubeClient.CurrentContext()
+		if env[NamespaceEnv] == "" {
+			env[NamespaceEnv] = d.KubeClient.Namespace()
+		}
+	}
+
+	// if args are nil we execute the command in a shell
+	if cmd.Args == nil {
+		return shell.ExecuteShellCommand(cmd.Command, stdout, stderr, env)
+	}
+
+	// else we execute it directly
+	return command.ExecuteCommandWithEnv(cmd.Command, cmd.Args, stdout, stderr, env)
+}
+
+// replaceRuntimeVariables returns a copy of the command with replaced runtime variables
+func (d *DeployConfig) replaceRuntimeVariables(cmd *latest.DeploymentCommand, builtImages map[string]string) (*latest.DeploymentCommand, bool, error) {
+	shouldRedeploy, replacedCommand, err := util.ReplaceRuntimeVariables(cmd.Command, d.config, d.dependencies, builtImages)
+	if err != nil {
+		return nil, false, err
+	}
+
+	replaced := &latest.DeploymentCommand{Command: replacedCommand}
+	if cmd.Args != nil {
+		replaced.Args = []string{}
+		for _, arg := range cmd.Args {
+			redeploy, replacedArg, err := util.ReplaceRuntimeVariables(arg, d.config, d.dependencies, builtImages)
+			if err != nil {
+				return nil, false, err
+			} else if redeploy {
+				shouldRedeploy = true
+			}
+
+			replaced.Args = append(replaced.Args, replacedArg)
+		}
+	}
+
+	return replaced, shouldRedeploy, nil
+}
+
+// hashFiles hashes the files that match the configured paths
+func (d *DeployConfig) hashFiles() (string, error) {
+	files := []string{}
+	for _, pattern := range d.DeploymentConfig.Command.Files {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return "", errors.Wrapf(err, "command.files: %s", pattern)
+		}
+
+		files = append(files, matches...)
+	}
+	sort.Strings(files)
+
+	filesHash := ""
+	for _, file := range files {
+		fileHash, err := hash.Directory(file)
+		if err != nil {
+			return "", errors.Errorf("Error hashing %s: %v", file, err)
+		}
+
+		filesHash += fileHash
+	}
+
+	return hash.String(filesHash), nil
+}
+
+func (d *DeployConfig) getDeployTarget() string {
+	target := d.DeploymentConfig.Command.Deploy.Command
+	if len(target) > 20 {
+		target = target[:20] + "..."
+	}
+
+	return target
+}
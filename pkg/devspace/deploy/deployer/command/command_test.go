@@ -0,0 +1,98 @@
+package command
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config"
+	"github.com/loft-sh/devspace/pkg/devspace/config/generated"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	log "github.com/loft-sh/devspace/pkg/util/log/testing"
+	"gotest.tools/assert"
+)
+
+func TestCommandDeployment(t *testing.T) {
+	dir, err := ioutil.TempDir("", "command-deployment")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	inputPath := filepath.Join(dir, "main.tf")
+	outputPath := filepath.Join(dir, "deployed")
+	err = ioutil.WriteFile(inputPath, []byte("resource"), 0666)
+	assert.NilError(t, err)
+
+	cache := generated.New()
+	cache.GetActive().Images["backend"] = &generated.ImageCache{ImageName: "myimage", Tag: "abcdef"}
+	deploymentConfig := &latest.DeploymentConfig{
+		Name:      "infra",
+		Namespace: "myNamespace",
+		Command: &latest.CommandDeploymentConfig{
+			Deploy: &latest.DeploymentCommand{
+				Command: "echo ${runtime.images.backend.image}:${runtime.images.backend.tag} $DEVSPACE_NAMESPACE > " + outputPath,
+			},
+			Render: &latest.DeploymentCommand{
+				Command: "echo",
+				Args:    []string{"image: ${runtime.images.backend.image}:${runtime.images.backend.tag}"},
+			},
+			Status: &latest.DeploymentCommand{
+				Command: "echo ready",
+			},
+			Purge: &latest.DeploymentCommand{
+				Command: "rm " + outputPath,
+			},
+			Files: []string{filepath.Join(dir, "*.tf")},
+		},
+	}
+
+	deployer, err := New(config.NewConfig(nil, &latest.Config{
+		Images: map[string]*latest.ImageConfig{
+			"backend": {Image: "myimage"},
+		},
+		Deployments: []*latest.DeploymentConfig{deploymentConfig},
+	}, cache, nil), nil, nil, deploymentConfig, &log.FakeLogger{})
+	assert.NilError(t, err)
+
+	// First deployment
+	deployed, err := deployer.Deploy(false, nil)
+	assert.NilError(t, err)
+	assert.Assert(t, deployed)
+
+	out, err := ioutil.ReadFile(outputPath)
+	assert.NilError(t, err)
+	assert.Equal(t, string(out), "myimage:abcdef myNamespace\n")
+
+	// Nothing changed
+	deployed, err = deployer.Deploy(false, nil)
+	assert.NilError(t, err)
+	assert.Assert(t, deployed == false)
+
+	// Image was built
+	deployed, err = deployer.Deploy(false, map[string]string{"myimage": "abcdef"})
+	assert.NilError(t, err)
+	assert.Assert(t, deployed)
+
+	// File has changed
+	err = ioutil.WriteFile(inputPath, []byte("changed resource"), 0666)
+	assert.NilError(t, err)
+	deployed, err = deployer.Deploy(false, nil)
+	assert.NilError(t, err)
+	assert.Assert(t, deployed)
+
+	status, err := deployer.Status()
+	assert.NilError(t, err)
+	assert.Equal(t, status.Status, "ready")
+
+	rendered := &bytes.Buffer{}
+	err = deployer.Render(nil, rendered)
+	assert.NilError(t, err)
+	assert.Equal(t, rendered.String(), "image: myimage:abcdef\n\n---\n")
+
+	err = deployer.Delete()
+	assert.NilError(t, err)
+	_, err = os.Stat(outputPath)
+	assert.Assert(t, os.IsNotExist(err))
+	assert.Assert(t, cache.GetActive().Deployments["infra"] == nil)
+}
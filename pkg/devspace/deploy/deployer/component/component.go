@@ -0,0 +1,256 @@
+package component
+
+import (
+	"io"
+	"strings"
+
+	config2 "github.com/loft-sh/devspace/pkg/devspace/config"
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader/component/render"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/devspace/dependency/types"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer/util"
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
+	"github.com/loft-sh/devspace/pkg/util/hash"
+	"github.com/loft-sh/devspace/pkg/util/log"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// DeployConfig holds the information necessary to deploy a component natively without helm
+type DeployConfig struct {
+	KubeClient kubectl.Client
+	Namespace  string
+
+	DeploymentConfig *latest.DeploymentConfig
+	Log              log.Logger
+
+	config       config2.Config
+	dependencies []types.Dependency
+	objectClient kubectl.ObjectClient
+}
+
+// New creates a new deploy config for component deployments
+func New(config config2.Config, dependencies []types.Dependency, kubeClient kubectl.Client, deployConfig *latest.DeploymentConfig, log log.Logger) (deployer.Interface, error) {
+	if deployConfig.Component == nil {
+		return nil, errors.New("error creating component deploy config: component is nil")
+	}
+
+	namespace := deployConfig.Namespace
+	if namespace == "" && kubeClient != nil {
+		namespace = kubeClient.Namespace()
+	}
+
+	return &DeployConfig{
+		KubeClient: kubeClient,
+		Namespace:  namespace,
+
+		DeploymentConfig: deployConfig,
+		Log:              log,
+
+		config:       config2.Ensure(config),
+		dependencies: dependencies,
+	}, nil
+}
+
+// Render writes the rendered component objects to the out stream
+func (d *DeployConfig) Render(builtImages map[string]string, out io.Writer) error {
+	_, objects, err := d.getObjects(builtImages)
+	if err != nil {
+		return err
+	}
+
+	manifest, err := toManifest(objects)
+	if err != nil {
+		return err
+	}
+
+	_, err = out.Write([]byte(manifest + "\n---\n"))
+	return err
+}
+
+// Status returns whether the component is deployed
+func (d *DeployConfig) Status() (*deployer.StatusResult, error) {
+	result := &deployer.StatusResult{
+		Name:   d.DeploymentConfig.Name,
+		Type:   "Component",
+		Target: d.Namespace,
+		Status: "Not deployed",
+	}
+
+	deployCache := d.config.Generated().GetActive().Deployments[d.DeploymentConfig.Name]
+	if deployCache != nil && len(deployCache.KubectlObjects) > 0 {
+		result.Status = "Deployed"
+	}
+
+	return result, nil
+}
+
+// Drift compares the currently rendered component with the live objects in the cluster
+func (d *DeployConfig) Drift() ([]*deployer.ResourceDrift, error) {
+	deployCache := d.config.Generated().GetActive().GetDeploymentCache(d.DeploymentConfig.Name)
+	if len(deployCache.KubectlObjects) == 0 {
+		return nil, errors.Errorf("deployment %s is not deployed, please run 'devspace deploy' first", d.DeploymentConfig.Name)
+	}
+
+	_, objects, err := d.getObjects(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest, err := toManifest(objects)
+	if err != nil {
+		return nil, err
+	}
+
+	objectClient, err := d.getObjectClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return util.DetectDrift(objectClient, manifest, d.Namespace)
+}
+
+// Deploy applies the rendered component with server-side apply and prunes the objects that are not rendered anymore
+func (d *DeployConfig) Deploy(forceDeploy bool, builtImages map[string]string) (bool, error) {
+	deployCache := d.config.Generated().GetActive().GetDeploymentCache(d.DeploymentConfig.Name)
+
+	// Hash the deployment config
+	configStr, err := yaml.Marshal(d.DeploymentConfig)
+	if err != nil {
+		return false, errors.Wrap(err, "marshal deployment config")
+	}
+
+	deploymentConfigHash := hash.String(string(configStr))
+
+	shouldRedeploy, objects, err := d.getObjects(builtImages)
+	if err != nil {
+		return false, err
+	}
+
+	previousObjects := util.PreviousObjects(deployCache, d.kubeContext(), d.Namespace)
+	forceDeploy = forceDeploy || shouldRedeploy || deployCache.DeploymentConfigHash != deploymentConfigHash || len(previousObjects) == 0
+	if forceDeploy == false {
+		return false, nil
+	}
+
+	objectClient, err := d.getObjectClient()
+	if err != nil {
+		return false, err
+	}
+
+	err = util.ApplyObjects(objectClient, objects, d.Namespace)
+	if err != nil {
+		return false, err
+	}
+
+	// Delete the objects that are not part of the component anymore
+	appliedObjects := util.GetObjectReferences(objects, d.Namespace)
+	err = util.PruneObjects(objectClient, previousObjects, appliedObjects, d.Log)
+	if err != nil {
+		return false, err
+	}
+
+	util.SetAppliedObjects(deployCache, appliedObjects, d.kubeContext(), d.Namespace)
+	deployCache.DeploymentConfigHash = deploymentConfigHash
+	return true, nil
+}
+
+// Rollback is not supported for component deployments, so the deployment is skipped
+func (d *DeployConfig) Rollback(revision int) error {
+	d.Log.Warnf("Skipping rollback of deployment %s, because component deployments cannot be rolled back", d.DeploymentConfig.Name)
+	return nil
+}
+
+// Delete deletes the deployed objects of the component
+func (d *DeployConfig) Delete() error {
+	deployCache := d.config.Generated().GetActive().GetDeploymentCache(d.DeploymentConfig.Name)
+	objects := deployCache.KubectlObjects
+	if len(objects) == 0 {
+		// Fall back to the currently rendered objects if the cache is empty
+		_, renderedObjects, err := d.getObjects(nil)
+		if err != nil {
+			return err
+		}
+
+		objects = util.GetObjectReferences(renderedObjects, d.Namespace)
+	}
+
+	objectClient, err := d.getObjectClient()
+	if err != nil {
+		return err
+	}
+
+	d.Log.StartWait("Deleting component " + d.DeploymentConfig.Name)
+	defer d.Log.StopWait()
+
+	err = util.DeleteObjects(objectClient, objects, d.Log)
+	if err != nil {
+		return err
+	}
+
+	delete(d.config.Generated().GetActive().Deployments, d.DeploymentConfig.Name)
+	return nil
+}
+
+// kubeContext returns the kube context the component is deployed to
+func (d *DeployConfig) kubeContext() string {
+	if d.KubeClient == nil {
+		return ""
+	}
+
+	return d.KubeClient.CurrentContext()
+}
+
+// getObjects renders the component and replaces the image names with the built or cached images
+func (d *DeployConfig) getObjects(builtImages map[string]string) (bool, []*unstructured.Unstructured, error) {
+	objects, err := render.Component(d.DeploymentConfig.Name, d.DeploymentConfig.Component)
+	if err != nil {
+		return false, nil, errors.Wrapf(err, "render component %s", d.DeploymentConfig.Name)
+	}
+
+	shouldRedeploy := false
+	for _, obj := range objects {
+		redeploy, err := util.ReplaceImageNamesStringMap(obj.Object, d.config, d.dependencies, builtImages, map[string]bool{"image": true}, false)
+		if err != nil {
+			return false, nil, err
+		} else if redeploy {
+			shouldRedeploy = true
+		}
+	}
+
+	return shouldRedeploy, objects, nil
+}
+
+func (d *DeployConfig) getObjectClient() (kubectl.ObjectClient, error) {
+	if d.objectClient == nil {
+		if d.KubeClient == nil {
+			return nil, errors.New("kube client is required for component deployments")
+		}
+
+		objectClient, err := kubectl.NewObjectClient(d.KubeClient.RestConfig())
+		if err != nil {
+			return nil, err
+		}
+
+		d.objectClient = objectClient
+	}
+
+	return d.objectClient, nil
+}
+
+func toManifest(objects []*unstructured.Unstructured) (string, error) {
+	manifests := []string{}
+	for _, obj := range objects {
+		out, err := yaml.Marshal(obj)
+		if err != nil {
+			return "", errors.Wrap(err, "marshal yaml")
+		}
+
+		manifests = append(manifests, string(out))
+	}
+
+	return strings.Join(manifests, "\n---\n"), nil
+}
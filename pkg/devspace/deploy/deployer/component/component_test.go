@@ -0,0 +1,131 @@
+package component
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config"
+	"github.com/loft-sh/devspace/pkg/devspace/config/generated"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	log "github.com/loft-sh/devspace/pkg/util/log/testing"
+	"gotest.tools/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/fake"
+)
+
+type fakeObjectClient struct {
+	client dynamic.Interface
+}
+
+func (f *fakeObjectClient) ResourceInterface(gvk schema.GroupVersionKind, namespace string) (dynamic.ResourceInterface, bool, error) {
+	switch gvk.Kind {
+	case "Service":
+		return f.client.Resource(schema.GroupVersionResource{Version: "v1", Resource: "services"}).Namespace(namespace), true, nil
+	case "Deployment":
+		return f.client.Resource(schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}).Namespace(namespace), true, nil
+	}
+
+	return nil, false, &meta.NoKindMatchError{GroupKind: gvk.GroupKind()}
+}
+
+func newObject(apiVersion, kind, namespace, name string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(apiVersion)
+	obj.SetKind(kind)
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	return obj
+}
+
+func newDeploymentConfig() *latest.DeploymentConfig {
+	return &latest.DeploymentConfig{
+		Name:      "backend",
+		Namespace: "myNamespace",
+		Component: &latest.ComponentConfig{
+			Containers: []*latest.ContainerConfig{
+				{
+					Image: "myimage",
+				},
+			},
+			Service: &latest.ServiceConfig{
+				Ports: []*latest.ServicePortConfig{
+					{
+						Port: intPtr(8080),
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestRender(t *testing.T) {
+	cache := generated.New()
+	cache.GetActive().Images["backend"] = &generated.ImageCache{ImageName: "myimage", Tag: "abcdef"}
+	deploymentConfig := newDeploymentConfig()
+
+	deployer, err := New(config.NewConfig(nil, &latest.Config{
+		Images: map[string]*latest.ImageConfig{
+			"backend": {Image: "myimage"},
+		},
+		Deployments: []*latest.DeploymentConfig{deploymentConfig},
+	}, cache, nil), nil, nil, deploymentConfig, &log.FakeLogger{})
+	assert.NilError(t, err)
+
+	rendered := &bytes.Buffer{}
+	err = deployer.Render(nil, rendered)
+	assert.NilError(t, err)
+	assert.Assert(t, strings.Contains(rendered.String(), "kind: Service\n"))
+	assert.Assert(t, strings.Contains(rendered.String(), "kind: Deployment\n"))
+	assert.Assert(t, strings.Contains(rendered.String(), "image: myimage:abcdef\n"))
+	assert.Assert(t, strings.HasSuffix(rendered.String(), "\n---\n"))
+}
+
+func TestDelete(t *testing.T) {
+	dynamicClient := fake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		{Version: "v1", Resource: "services"}:                   "ServiceList",
+		{Group: "apps", Version: "v1", Resource: "deployments"}: "DeploymentList",
+	},
+		newObject("v1", "Service", "myNamespace", "backend"),
+		newObject("apps/v1", "Deployment", "myNamespace", "backend"),
+		newObject("v1", "Service", "myNamespace", "other"),
+	)
+
+	cache := generated.New()
+	cache.GetActive().GetDeploymentCache("backend").KubectlObjects = []generated.KubectlObject{
+		{APIVersion: "v1", Kind: "Service", Namespace: "myNamespace", Name: "backend"},
+		{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "myNamespace", Name: "backend"},
+	}
+	deploymentConfig := newDeploymentConfig()
+	deployer := &DeployConfig{
+		Namespace:        "myNamespace",
+		DeploymentConfig: deploymentConfig,
+		Log:              &log.FakeLogger{},
+
+		config:       config.NewConfig(nil, &latest.Config{Deployments: []*latest.DeploymentConfig{deploymentConfig}}, cache, nil),
+		objectClient: &fakeObjectClient{client: dynamicClient},
+	}
+
+	err := deployer.Delete()
+	assert.NilError(t, err)
+	assert.Assert(t, cache.GetActive().Deployments["backend"] == nil)
+
+	services, err := dynamicClient.Resource(schema.GroupVersionResource{Version: "v1", Resource: "services"}).Namespace("myNamespace").List(context.TODO(), metav1.ListOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, len(services.Items), 1)
+	assert.Equal(t, services.Items[0].GetName(), "other")
+
+	deployments, err := dynamicClient.Resource(schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}).Namespace("myNamespace").List(context.TODO(), metav1.ListOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, len(deployments.Items), 0)
+}
+
+func intPtr(i int) *int {
+	return &i
+}
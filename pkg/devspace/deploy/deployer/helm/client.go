@@ -43,6 +43,7 @@ type DeployConfig struct {
 
 	config       config2.Config
 	dependencies []types.Dependency
+	objectClient kubectl.ObjectClient
 }
 
 // New creates a new helm deployment client
@@ -12,6 +12,7 @@ import (
 	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer/helm/merge"
 	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer/util"
 	"github.com/loft-sh/devspace/pkg/devspace/helm"
+	"github.com/loft-sh/devspace/pkg/devspace/helm/postrender"
 	hashpkg "github.com/loft-sh/devspace/pkg/util/hash"
 	"github.com/loft-sh/devspace/pkg/util/yamlutil"
 	"github.com/mgutz/ansi"
@@ -144,6 +145,30 @@ func (d *DeployConfig) internalDeploy(forceDeploy bool, builtImages map[string]s
 		}
 	}
 
+	// Resolve the values from secrets, config maps, releases and templates
+	valuesFromHash := ""
+	if len(d.DeploymentConfig.Helm.ValuesFrom) > 0 {
+		namespace := releaseNamespace
+		if namespace == "" && d.Kube != nil {
+			namespace = d.Kube.Namespace()
+		}
+
+		resolvedValues, err := d.resolveValuesFrom(overwriteValues, releaseName, namespace)
+		if err != nil {
+			return false, nil, err
+		}
+
+		resolvedValuesYaml, err := yaml.Marshal(resolvedValues)
+		if err != nil {
+			return false, nil, err
+		}
+
+		valuesFromHash = hashpkg.String(string(resolvedValuesYaml))
+		if d.config.Generated().GetActive().GetDeploymentCache(d.DeploymentConfig.Name).HelmValuesFromHash != valuesFromHash {
+			forceDeploy = true
+		}
+	}
+
 	// Load override values from data and merge them
 	if d.DeploymentConfig.Helm.Values != nil {
 		merge.Values(overwriteValues).MergeInto(d.DeploymentConfig.Helm.Values)
@@ -152,7 +177,7 @@ func (d *DeployConfig) internalDeploy(forceDeploy bool, builtImages map[string]s
 	// Add devspace specific values
 	if d.DeploymentConfig.Helm.ReplaceImageTags == nil || *d.DeploymentConfig.Helm.ReplaceImageTags == true {
 		// Replace image names
-		shouldRedeploy, err := util.ReplaceImageNames(overwriteValues, d.config, d.dependencies, builtImages, nil)
+		shouldRedeploy, err := util.ReplaceImageNames(overwriteValues, d.config, d.dependencies, builtImages, nil, d.DeploymentConfig.Helm.PinImageDigests)
 		if err != nil {
 			return false, nil, err
 		}
@@ -162,6 +187,21 @@ func (d *DeployConfig) internalDeploy(forceDeploy bool, builtImages map[string]s
 		}
 	}
 
+	// Prepare the post-renderer
+	var postRenderSpec *postrender.Spec
+	if d.DeploymentConfig.Helm.PostRenderer != nil {
+		postRenderSpec, err = d.getPostRenderSpec(builtImages)
+		if err != nil {
+			return false, nil, err
+		}
+
+		for image := range builtImages {
+			if _, ok := postRenderSpec.ImageReplacements[image]; ok {
+				forceDeploy = true
+			}
+		}
+	}
+
 	// Deployment is not necessary
 	if forceDeploy == false {
 		return false, nil, nil
@@ -173,6 +213,13 @@ func (d *DeployConfig) internalDeploy(forceDeploy bool, builtImages map[string]s
 			return false, nil, err
 		}
 
+		if postRenderSpec != nil {
+			str, err = postrender.RunString(str, postRenderSpec)
+			if err != nil {
+				return false, nil, errors.Wrap(err, "post-render")
+			}
+		}
+
 		out.Write([]byte("\n" + str + "\n"))
 		return true, nil, nil
 	}
@@ -180,12 +227,34 @@ func (d *DeployConfig) internalDeploy(forceDeploy bool, builtImages map[string]s
 	d.Log.StartWait(fmt.Sprintf("Deploying chart %s (%s) with helm", d.DeploymentConfig.Helm.Chart.Name, d.DeploymentConfig.Name))
 	defer d.Log.StopWait()
 
+	// Let helm call devspace as post-renderer
+	helmConfig := d.DeploymentConfig.Helm
+	if postRenderSpec != nil {
+		executable, err := os.Executable()
+		if err != nil {
+			return false, nil, errors.Wrap(err, "find devspace executable")
+		}
+
+		postRenderer, cleanup, err := postrender.WriteExecutable(executable, postRenderSpec)
+		if err != nil {
+			return false, nil, errors.Wrap(err, "write post-renderer")
+		}
+		defer cleanup()
+
+		helmConfigCopy := *helmConfig
+		helmConfigCopy.UpgradeArgs = append(append([]string{}, helmConfig.UpgradeArgs...), "--post-renderer", postRenderer)
+		helmConfig = &helmConfigCopy
+	}
+
 	// Deploy chart
-	appRelease, err := d.Helm.InstallChart(releaseName, releaseNamespace, overwriteValues, d.DeploymentConfig.Helm)
+	appRelease, err := d.Helm.InstallChart(releaseName, releaseNamespace, overwriteValues, helmConfig)
 	if err != nil {
 		return false, nil, errors.Errorf("Unable to deploy helm chart: %v\nRun `%s` and `%s` to recreate the chart", err, ansi.Color("devspace purge -d "+d.DeploymentConfig.Name, "white+b"), ansi.Color("devspace deploy", "white+b"))
 	}
 
+	// Remember the resolved values, so we redeploy if they change
+	d.config.Generated().GetActive().GetDeploymentCache(d.DeploymentConfig.Name).HelmValuesFromHash = valuesFromHash
+
 	// Print revision
 	if appRelease != nil {
 		d.Log.Donef("Deployed helm chart (Release revision: %s)", appRelease.Revision)
@@ -195,3 +264,22 @@ func (d *DeployConfig) internalDeploy(forceDeploy bool, builtImages map[string]s
 
 	return true, appRelease, nil
 }
+
+// getPostRenderSpec returns the spec for post-rendering the manifests of the chart
+func (d *DeployConfig) getPostRenderSpec(builtImages map[string]string) (*postrender.Spec, error) {
+	postRendererConfig := d.DeploymentConfig.Helm.PostRenderer
+	spec := &postrender.Spec{
+		Resources: postRendererConfig.Resources,
+	}
+
+	if postRendererConfig.ReplaceImageTags == nil || *postRendererConfig.ReplaceImageTags == true {
+		imageReplacements, err := util.ImageReplacements(d.config, d.dependencies, builtImages, d.DeploymentConfig.Helm.PinImageDigests)
+		if err != nil {
+			return nil, err
+		}
+
+		spec.ImageReplacements = imageReplacements
+	}
+
+	return spec, nil
+}
@@ -0,0 +1,40 @@
+package helm
+
+import (
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer/util"
+	"github.com/loft-sh/devspace/pkg/devspace/helm"
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
+	"github.com/pkg/errors"
+)
+
+// Drift compares the manifests of the deployed release with the live objects in the cluster
+func (d *DeployConfig) Drift() ([]*deployer.ResourceDrift, error) {
+	var err error
+	if d.Helm == nil {
+		// Get HelmClient
+		d.Helm, err = helm.NewClient(d.config.Config(), d.DeploymentConfig, d.Kube, d.TillerNamespace, false, false, d.Log)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	releaseNamespace := d.DeploymentConfig.Namespace
+	if releaseNamespace == "" {
+		releaseNamespace = d.Kube.Namespace()
+	}
+
+	manifests, err := d.Helm.GetManifest(d.DeploymentConfig.Name, releaseNamespace, d.DeploymentConfig.Helm)
+	if err != nil {
+		return nil, errors.Wrapf(err, "get manifests of release %s", d.DeploymentConfig.Name)
+	}
+
+	if d.objectClient == nil {
+		d.objectClient, err = kubectl.NewObjectClient(d.Kube.RestConfig())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return util.DetectDrift(d.objectClient, manifests, releaseNamespace)
+}
@@ -0,0 +1,74 @@
+package helm
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/loft-sh/devspace/pkg/devspace/helm"
+	helmtypes "github.com/loft-sh/devspace/pkg/devspace/helm/types"
+	"github.com/pkg/errors"
+)
+
+// Rollback rolls the helm release back to the given revision or to the previous revision if revision is 0
+func (d *DeployConfig) Rollback(revision int) error {
+	var (
+		err         error
+		releaseName = d.DeploymentConfig.Name
+	)
+
+	if d.Helm == nil {
+		d.Helm, err = helm.NewClient(d.config.Config(), d.DeploymentConfig, d.Kube, d.TillerNamespace, false, false, d.Log)
+		if err != nil {
+			return errors.Wrap(err, "new helm client")
+		}
+	}
+
+	release, err := d.findRelease(releaseName)
+	if err != nil {
+		return err
+	} else if release == nil {
+		return errors.Errorf("release %s not found", releaseName)
+	}
+
+	if revision == 0 {
+		currentRevision, err := strconv.Atoi(release.Revision)
+		if err != nil {
+			return errors.Wrapf(err, "parse revision of release %s", releaseName)
+		}
+
+		revision = currentRevision - 1
+	}
+	if revision < 1 {
+		return errors.Errorf("release %s has no previous revision", releaseName)
+	}
+
+	d.Log.StartWait(fmt.Sprintf("Rolling back release %s to revision %d", releaseName, revision))
+	err = d.Helm.Rollback(releaseName, d.DeploymentConfig.Namespace, revision, d.DeploymentConfig.Helm)
+	d.Log.StopWait()
+	if err != nil {
+		return errors.Errorf("Unable to roll back release %s: %v", releaseName, err)
+	}
+
+	// Reset the cache, so the next deploy does not skip the rolled back release
+	deployCache := d.config.Generated().GetActive().GetDeploymentCache(d.DeploymentConfig.Name)
+	deployCache.DeploymentConfigHash = ""
+	deployCache.HelmReleaseRevision = ""
+
+	d.Log.Donef("Rolled back release %s to revision %d", releaseName, revision)
+	return nil
+}
+
+func (d *DeployConfig) findRelease(releaseName string) (*helmtypes.Release, error) {
+	releases, err := d.Helm.ListReleases(d.DeploymentConfig.Helm)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, release := range releases {
+		if release.Name == releaseName {
+			return release, nil
+		}
+	}
+
+	return nil, nil
+}
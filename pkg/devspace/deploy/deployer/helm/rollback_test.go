@@ -0,0 +1,88 @@
+package helm
+
+import (
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config"
+	"github.com/loft-sh/devspace/pkg/devspace/config/generated"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	fakehelm "github.com/loft-sh/devspace/pkg/devspace/helm/testing"
+	helmtypes "github.com/loft-sh/devspace/pkg/devspace/helm/types"
+	log "github.com/loft-sh/devspace/pkg/util/log/testing"
+	"gotest.tools/assert"
+)
+
+type rollbackTestCase struct {
+	name string
+
+	deployment string
+	releases   []*helmtypes.Release
+	revision   int
+
+	expectedErr      string
+	expectedRevision string
+}
+
+func TestRollback(t *testing.T) {
+	testCases := []rollbackTestCase{
+		{
+			name:        "Release not found",
+			deployment:  "depl",
+			expectedErr: "release depl not found",
+		},
+		{
+			name:       "No previous revision",
+			deployment: "depl",
+			releases: []*helmtypes.Release{
+				{Name: "depl", Revision: "1"},
+			},
+			expectedErr:      "release depl has no previous revision",
+			expectedRevision: "1",
+		},
+		{
+			name:       "Rollback to previous revision",
+			deployment: "depl",
+			releases: []*helmtypes.Release{
+				{Name: "depl", Revision: "2"},
+			},
+			expectedRevision: "3",
+		},
+		{
+			name:       "Rollback to specific revision",
+			deployment: "depl",
+			releases: []*helmtypes.Release{
+				{Name: "depl", Revision: "4"},
+			},
+			revision:         1,
+			expectedRevision: "5",
+		},
+	}
+
+	for _, testCase := range testCases {
+		cache := generated.New()
+		cache.GetActive().GetDeploymentCache(testCase.deployment).HelmReleaseRevision = "2"
+		deployer := &DeployConfig{
+			Helm: &fakehelm.Client{
+				Releases: testCase.releases,
+			},
+			DeploymentConfig: &latest.DeploymentConfig{
+				Name: testCase.deployment,
+				Helm: &latest.HelmConfig{},
+			},
+			config: config.NewConfig(nil, latest.NewRaw(), cache, nil),
+			Log:    &log.FakeLogger{},
+		}
+
+		err := deployer.Rollback(testCase.revision)
+		if testCase.expectedErr == "" {
+			assert.NilError(t, err, "Error in testCase %s", testCase.name)
+			assert.Equal(t, cache.GetActive().GetDeploymentCache(testCase.deployment).HelmReleaseRevision, "", "Cache not reset in testCase %s", testCase.name)
+		} else {
+			assert.Error(t, err, testCase.expectedErr, "Wrong or no error in testCase %s", testCase.name)
+		}
+
+		if len(testCase.releases) > 0 {
+			assert.Equal(t, testCase.releases[0].Revision, testCase.expectedRevision, "Unexpected revision in testCase %s", testCase.name)
+		}
+	}
+}
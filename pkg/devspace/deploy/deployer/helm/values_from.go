@@ -0,0 +1,222 @@
+package helm
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer/helm/merge"
+	helmtypes "github.com/loft-sh/devspace/pkg/devspace/helm/types"
+	"github.com/pkg/errors"
+	yaml "gopkg.in/yaml.v2"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// errNotFound is returned if the source of a value does not exist
+var errNotFound = errors.New("not found")
+
+// resolveValuesFrom resolves the valuesFrom sources in order and merges them into the given values, so that
+// later sources override earlier ones. It returns the resolved values
+func (d *DeployConfig) resolveValuesFrom(values map[interface{}]interface{}, releaseName, releaseNamespace string) (map[interface{}]interface{}, error) {
+	resolvedValues := map[interface{}]interface{}{}
+	for idx, valuesFrom := range d.DeploymentConfig.Helm.ValuesFrom {
+		value, err := d.resolveValueFrom(valuesFrom, values, releaseName, releaseNamespace)
+		if err != nil {
+			if errors.Cause(err) == errNotFound && valuesFrom.Optional {
+				d.Log.Debugf("Skipping deployments[%s].helm.valuesFrom[%d]: %v", d.DeploymentConfig.Name, idx, err)
+				continue
+			}
+
+			return nil, errors.Wrapf(err, "helm.valuesFrom[%d]", idx)
+		}
+
+		newValues := map[interface{}]interface{}{}
+		if valuesFrom.TargetPath != "" {
+			setPath(newValues, valuesFrom.TargetPath, value)
+		} else {
+			if str, ok := value.(string); ok {
+				parsed := map[interface{}]interface{}{}
+				err = yaml.Unmarshal([]byte(str), &parsed)
+				if err != nil {
+					return nil, errors.Wrapf(err, "helm.valuesFrom[%d]: parse values", idx)
+				}
+
+				value = parsed
+			}
+
+			valueMap, ok := value.(map[interface{}]interface{})
+			if !ok {
+				return nil, errors.Errorf("helm.valuesFrom[%d]: value is not an object, please specify a targetPath", idx)
+			}
+
+			newValues = valueMap
+		}
+
+		merge.Values(values).MergeInto(newValues)
+		merge.Values(resolvedValues).MergeInto(newValues)
+	}
+
+	return resolvedValues, nil
+}
+
+func (d *DeployConfig) resolveValueFrom(valuesFrom *latest.HelmValuesFromConfig, values map[interface{}]interface{}, releaseName, releaseNamespace string) (interface{}, error) {
+	if valuesFrom.Secret != nil || valuesFrom.ConfigMap != nil {
+		if d.Kube == nil {
+			return nil, errors.New("a kube client is required to resolve secrets and config maps")
+		}
+
+		ref := valuesFrom.Secret
+		if ref == nil {
+			ref = valuesFrom.ConfigMap
+		}
+
+		namespace := ref.Namespace
+		if namespace == "" {
+			namespace = releaseNamespace
+		}
+
+		if valuesFrom.Secret != nil {
+			secret, err := d.Kube.KubeClient().CoreV1().Secrets(namespace).Get(context.TODO(), ref.Name, metav1.GetOptions{})
+			if err != nil {
+				if kerrors.IsNotFound(err) {
+					return nil, errors.Wrapf(errNotFound, "secret %s/%s", namespace, ref.Name)
+				}
+
+				return nil, err
+			} else if _, ok := secret.Data[ref.Key]; !ok {
+				return nil, errors.Wrapf(errNotFound, "key %s in secret %s/%s", ref.Key, namespace, ref.Name)
+			}
+
+			return string(secret.Data[ref.Key]), nil
+		}
+
+		configMap, err := d.Kube.KubeClient().CoreV1().ConfigMaps(namespace).Get(context.TODO(), ref.Name, metav1.GetOptions{})
+		if err != nil {
+			if kerrors.IsNotFound(err) {
+				return nil, errors.Wrapf(errNotFound, "config map %s/%s", namespace, ref.Name)
+			}
+
+			return nil, err
+		} else if value, ok := configMap.Data[ref.Key]; ok {
+			return value, nil
+		} else if value, ok := configMap.BinaryData[ref.Key]; ok {
+			return string(value), nil
+		}
+
+		return nil, errors.Wrapf(errNotFound, "key %s in config map %s/%s", ref.Key, namespace, ref.Name)
+	} else if valuesFrom.Release != nil {
+		namespace := valuesFrom.Release.Namespace
+		if namespace == "" {
+			namespace = releaseNamespace
+		}
+
+		releaseValues, err := d.Helm.GetValues(valuesFrom.Release.Name, namespace, d.DeploymentConfig.Helm)
+		if err != nil {
+			if errors.Cause(err) == helmtypes.ErrReleaseNotFound {
+				return nil, errors.Wrapf(errNotFound, "release %s/%s", namespace, valuesFrom.Release.Name)
+			}
+
+			return nil, errors.Wrapf(err, "get values of release %s/%s", namespace, valuesFrom.Release.Name)
+		} else if valuesFrom.Release.Key == "" {
+			return releaseValues, nil
+		}
+
+		value, found := getPath(releaseValues, valuesFrom.Release.Key)
+		if !found {
+			return nil, errors.Wrapf(errNotFound, "key %s in release %s", valuesFrom.Release.Key, valuesFrom.Release.Name)
+		}
+
+		return value, nil
+	} else if valuesFrom.Template != "" {
+		content, err := ioutil.ReadFile(valuesFrom.Template)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil, errors.Wrapf(errNotFound, "template %s", valuesFrom.Template)
+			}
+
+			return nil, errors.Wrapf(err, "read template %s", valuesFrom.Template)
+		}
+
+		t, err := template.New(valuesFrom.Template).Option("missingkey=zero").Parse(string(content))
+		if err != nil {
+			return nil, errors.Wrapf(err, "parse template %s", valuesFrom.Template)
+		}
+
+		// Templates see the values with the same precedence as the deployed values, which means that
+		// helm.values override the values files and the sources resolved so far
+		templateValues := copyValues(values)
+		if d.DeploymentConfig.Helm.Values != nil {
+			merge.Values(templateValues).MergeInto(copyValues(d.DeploymentConfig.Helm.Values))
+		}
+
+		out := &bytes.Buffer{}
+		err = t.Execute(out, map[string]interface{}{
+			"Values": templateValues,
+			"Release": map[string]interface{}{
+				"Name":      releaseName,
+				"Namespace": releaseNamespace,
+			},
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "execute template %s", valuesFrom.Template)
+		}
+
+		return out.String(), nil
+	}
+
+	return nil, errors.New("please specify either secret, configMap, release or template")
+}
+
+// getPath returns the value at the dot separated path
+func getPath(values map[interface{}]interface{}, path string) (interface{}, bool) {
+	var current interface{} = values
+	for _, key := range strings.Split(path, ".") {
+		currentMap, ok := current.(map[interface{}]interface{})
+		if !ok {
+			return nil, false
+		}
+
+		current, ok = currentMap[key]
+		if !ok {
+			return nil, false
+		}
+	}
+
+	return current, true
+}
+
+// setPath sets the value at the dot separated path and creates missing objects along the way
+func setPath(values map[interface{}]interface{}, path string, value interface{}) {
+	keys := strings.Split(path, ".")
+	current := values
+	for _, key := range keys[:len(keys)-1] {
+		next, ok := current[key].(map[interface{}]interface{})
+		if !ok {
+			next = map[interface{}]interface{}{}
+			current[key] = next
+		}
+
+		current = next
+	}
+
+	current[keys[len(keys)-1]] = value
+}
+
+// copyValues returns a deep copy of the given values, so that merging into the copy does not change them
+func copyValues(values map[interface{}]interface{}) map[interface{}]interface{} {
+	copied := map[interface{}]interface{}{}
+	for key, value := range values {
+		if valueMap, ok := value.(map[interface{}]interface{}); ok {
+			value = copyValues(valueMap)
+		}
+
+		copied[key] = value
+	}
+
+	return copied
+}
@@ -0,0 +1,226 @@
+package helm
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	fakehelm "github.com/loft-sh/devspace/pkg/devspace/helm/testing"
+	helmtypes "github.com/loft-sh/devspace/pkg/devspace/helm/types"
+	fakekube "github.com/loft-sh/devspace/pkg/devspace/kubectl/testing"
+	log "github.com/loft-sh/devspace/pkg/util/log/testing"
+	"gotest.tools/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+type resolveValuesFromTestCase struct {
+	name string
+
+	valuesFrom []*latest.HelmValuesFromConfig
+	values     map[interface{}]interface{}
+	helmValues map[interface{}]interface{}
+
+	expectedValues         map[interface{}]interface{}
+	expectedResolvedValues map[interface{}]interface{}
+	expectedErr            string
+}
+
+func TestResolveValuesFrom(t *testing.T) {
+	dir, err := ioutil.TempDir("", "values-from")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	templatePath := filepath.Join(dir, "values.yaml.tpl")
+	err = ioutil.WriteFile(templatePath, []byte("ingress:\n  host: {{ .Release.Name }}.{{ .Values.domain }}\n"), 0666)
+	assert.NilError(t, err)
+
+	testCases := []resolveValuesFromTestCase{
+		{
+			name: "Secret key at target path",
+			valuesFrom: []*latest.HelmValuesFromConfig{
+				{
+					Secret:     &latest.HelmValuesFromKeyRef{Name: "db", Key: "password"},
+					TargetPath: "postgresql.auth.password",
+				},
+			},
+			values: map[interface{}]interface{}{
+				"postgresql": map[interface{}]interface{}{"enabled": true},
+			},
+			expectedValues: map[interface{}]interface{}{
+				"postgresql": map[interface{}]interface{}{
+					"enabled": true,
+					"auth":    map[interface{}]interface{}{"password": "secret"},
+				},
+			},
+			expectedResolvedValues: map[interface{}]interface{}{
+				"postgresql": map[interface{}]interface{}{
+					"auth": map[interface{}]interface{}{"password": "secret"},
+				},
+			},
+		},
+		{
+			name: "Config map values are merged and later sources win",
+			valuesFrom: []*latest.HelmValuesFromConfig{
+				{
+					ConfigMap: &latest.HelmValuesFromKeyRef{Name: "settings", Namespace: "other", Key: "values.yaml"},
+				},
+				{
+					Release:    &latest.HelmValuesFromKeyRef{Name: "database", Key: "auth.username"},
+					TargetPath: "replicas",
+				},
+			},
+			values: map[interface{}]interface{}{},
+			expectedValues: map[interface{}]interface{}{
+				"replicas": "admin",
+				"domain":   "example.com",
+			},
+			expectedResolvedValues: map[interface{}]interface{}{
+				"replicas": "admin",
+				"domain":   "example.com",
+			},
+		},
+		{
+			name: "Template",
+			valuesFrom: []*latest.HelmValuesFromConfig{
+				{
+					Template: templatePath,
+				},
+			},
+			values: map[interface{}]interface{}{
+				"domain": "example.com",
+			},
+			expectedValues: map[interface{}]interface{}{
+				"domain":  "example.com",
+				"ingress": map[interface{}]interface{}{"host": "my-release.example.com"},
+			},
+			expectedResolvedValues: map[interface{}]interface{}{
+				"ingress": map[interface{}]interface{}{"host": "my-release.example.com"},
+			},
+		},
+		{
+			name: "Template sees helm values",
+			valuesFrom: []*latest.HelmValuesFromConfig{
+				{
+					Template: templatePath,
+				},
+			},
+			values: map[interface{}]interface{}{
+				"domain": "example.com",
+			},
+			helmValues: map[interface{}]interface{}{
+				"domain": "example.org",
+			},
+			expectedValues: map[interface{}]interface{}{
+				"domain":  "example.com",
+				"ingress": map[interface{}]interface{}{"host": "my-release.example.org"},
+			},
+			expectedResolvedValues: map[interface{}]interface{}{
+				"ingress": map[interface{}]interface{}{"host": "my-release.example.org"},
+			},
+		},
+		{
+			name: "Optional missing release is skipped",
+			valuesFrom: []*latest.HelmValuesFromConfig{
+				{
+					Release:    &latest.HelmValuesFromKeyRef{Name: "missing"},
+					TargetPath: "database",
+					Optional:   true,
+				},
+			},
+			values:                 map[interface{}]interface{}{},
+			expectedValues:         map[interface{}]interface{}{},
+			expectedResolvedValues: map[interface{}]interface{}{},
+		},
+		{
+			name: "Optional unreadable template fails",
+			valuesFrom: []*latest.HelmValuesFromConfig{
+				{
+					Template: dir,
+					Optional: true,
+				},
+			},
+			values:      map[interface{}]interface{}{},
+			expectedErr: "helm.valuesFrom[0]: read template " + dir + ": read " + dir + ": is a directory",
+		},
+		{
+			name: "Optional source is skipped",
+			valuesFrom: []*latest.HelmValuesFromConfig{
+				{
+					Secret:     &latest.HelmValuesFromKeyRef{Name: "missing", Key: "password"},
+					TargetPath: "password",
+					Optional:   true,
+				},
+			},
+			values:                 map[interface{}]interface{}{},
+			expectedValues:         map[interface{}]interface{}{},
+			expectedResolvedValues: map[interface{}]interface{}{},
+		},
+		{
+			name: "Missing secret key",
+			valuesFrom: []*latest.HelmValuesFromConfig{
+				{
+					Secret:     &latest.HelmValuesFromKeyRef{Name: "db", Key: "missing"},
+					TargetPath: "password",
+				},
+			},
+			values:      map[interface{}]interface{}{},
+			expectedErr: "helm.valuesFrom[0]: key missing in secret testNamespace/db: not found",
+		},
+		{
+			name: "Value is not an object",
+			valuesFrom: []*latest.HelmValuesFromConfig{
+				{
+					Secret: &latest.HelmValuesFromKeyRef{Name: "db", Key: "password"},
+				},
+			},
+			values:      map[interface{}]interface{}{},
+			expectedErr: "helm.valuesFrom[0]: parse values: yaml: unmarshal errors:\n  line 1: cannot unmarshal !!str `secret` into map[interface {}]interface {}",
+		},
+	}
+
+	for _, testCase := range testCases {
+		kube := fake.NewSimpleClientset(
+			&v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "testNamespace"},
+				Data:       map[string][]byte{"password": []byte("secret")},
+			},
+			&v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "settings", Namespace: "other"},
+				Data:       map[string]string{"values.yaml": "replicas: 2\ndomain: example.com\n"},
+			},
+		)
+
+		deployer := &DeployConfig{
+			Kube: &fakekube.Client{Client: kube},
+			Helm: &fakehelm.Client{
+				Releases: []*helmtypes.Release{{Name: "database"}},
+				Values: map[string]map[interface{}]interface{}{
+					"database": {"auth": map[interface{}]interface{}{"username": "admin"}},
+				},
+			},
+			DeploymentConfig: &latest.DeploymentConfig{
+				Name: "my-release",
+				Helm: &latest.HelmConfig{
+					ValuesFrom: testCase.valuesFrom,
+					Values:     testCase.helmValues,
+				},
+			},
+			Log: &log.FakeLogger{},
+		}
+
+		resolvedValues, err := deployer.resolveValuesFrom(testCase.values, "my-release", "testNamespace")
+		if testCase.expectedErr == "" {
+			assert.NilError(t, err, "Error in testCase %s", testCase.name)
+		} else {
+			assert.Error(t, err, testCase.expectedErr, "Wrong or no error in testCase %s", testCase.name)
+			continue
+		}
+
+		assert.DeepEqual(t, testCase.values, testCase.expectedValues)
+		assert.DeepEqual(t, resolvedValues, testCase.expectedResolvedValues)
+	}
+}
@@ -7,15 +7,35 @@ import (
 // Interface defines the common interface used for the deployment methods
 type Interface interface {
 	Status() (*StatusResult, error)
+	Drift() ([]*ResourceDrift, error)
 	Deploy(forceDeploy bool, builtImages map[string]string) (bool, error)
 	Render(builtImages map[string]string, out io.Writer) error
+	Rollback(revision int) error
 	Delete() error
 }
 
 // StatusResult holds the status of a deployment
 type StatusResult struct {
-	Name   string
-	Type   string
-	Target string
-	Status string
+	Name   string `json:"name"`
+	Type   string `json:"type"`
+	Target string `json:"target"`
+	Status string `json:"status"`
+}
+
+// ResourceDrift holds the differences between a resource of the last deployment and the live object
+// in the cluster
+type ResourceDrift struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+
+	// Deleted is true if the resource doesn't exist in the cluster anymore
+	Deleted bool `json:"deleted,omitempty"`
+
+	// Changed holds the paths of the fields that were changed manually
+	Changed []string `json:"changed,omitempty"`
+
+	// Unhealthy holds the reason why the resource is not ready
+	Unhealthy string `json:"unhealthy,omitempty"`
 }
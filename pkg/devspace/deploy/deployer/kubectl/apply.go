@@ -0,0 +1,134 @@
+package kubectl
+
+import (
+	"strings"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/generated"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer/util"
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// apply applies the manifest with kubectl apply or, if enabled, in-process with server-side apply. Custom resource
+// definitions and namespaces are applied before all other objects and the deployer waits until the custom resource
+// definitions are established, so custom resources and namespaced objects of the same manifest can be applied
+func (d *DeployConfig) apply(manifest string) error {
+	objects, err := stringToUnstructuredArray(manifest)
+	if err != nil {
+		return err
+	}
+
+	crds, namespaces, others := splitObjects(objects)
+	if len(crds) == 0 && len(namespaces) == 0 {
+		return d.applyObjects(manifest, objects)
+	}
+
+	for i, group := range [][]*unstructured.Unstructured{crds, namespaces, others} {
+		if len(group) == 0 {
+			continue
+		}
+
+		groupManifest, err := unstructuredArrayToString(group)
+		if err != nil {
+			return err
+		}
+
+		err = d.applyObjects(groupManifest, group)
+		if err != nil {
+			return err
+		}
+
+		// custom resources can only be applied after their definitions are established
+		if i == 0 {
+			err = d.waitForObjects(util.GetObjectReferences(crds, d.Namespace))
+			if err != nil {
+				return errors.Wrap(err, "wait for custom resource definitions")
+			}
+		}
+	}
+
+	return nil
+}
+
+// applyObjects applies the objects, of which manifest is the yaml representation
+func (d *DeployConfig) applyObjects(manifest string, objects []*unstructured.Unstructured) error {
+	if d.DeploymentConfig.Kubectl.ServerSideApply == false {
+		args := d.getCmdArgs("apply", "--force")
+		args = append(args, d.DeploymentConfig.Kubectl.ApplyArgs...)
+
+		cmd := d.commandExecuter.GetCommand(d.CmdPath, args)
+		return cmd.Run(d.Log, d.Log, strings.NewReader(manifest))
+	}
+
+	objectClient, err := d.getObjectClient()
+	if err != nil {
+		return err
+	}
+
+	return util.ApplyObjects(objectClient, objects, d.Namespace)
+}
+
+// splitObjects splits the objects into custom resource definitions, namespaces and all other objects while keeping
+// the order within each group
+func splitObjects(objects []*unstructured.Unstructured) ([]*unstructured.Unstructured, []*unstructured.Unstructured, []*unstructured.Unstructured) {
+	crds := []*unstructured.Unstructured{}
+	namespaces := []*unstructured.Unstructured{}
+	others := []*unstructured.Unstructured{}
+	for _, obj := range objects {
+		switch obj.GroupVersionKind().GroupKind() {
+		case schema.GroupKind{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"}:
+			crds = append(crds, obj)
+		case schema.GroupKind{Kind: "Namespace"}:
+			namespaces = append(namespaces, obj)
+		default:
+			others = append(others, obj)
+		}
+	}
+
+	return crds, namespaces, others
+}
+
+// prune deletes the previously applied objects that are not part of the applied objects anymore
+func (d *DeployConfig) prune(previousObjects []generated.KubectlObject, appliedObjects []generated.KubectlObject) error {
+	if len(previousObjects) == 0 {
+		return nil
+	}
+
+	objectClient, err := d.getObjectClient()
+	if err != nil {
+		return err
+	}
+
+	return util.PruneObjects(objectClient, previousObjects, appliedObjects, d.Log)
+}
+
+// getObjects returns the references of all objects in the manifest. Objects without a namespace are
+// expected to be in the namespace of the deployment
+func (d *DeployConfig) getObjects(manifest string) ([]generated.KubectlObject, error) {
+	objects, err := stringToUnstructuredArray(manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	return util.GetObjectReferences(objects, d.Namespace), nil
+}
+
+func (d *DeployConfig) getObjectClient() (kubectl.ObjectClient, error) {
+	if d.objectClient == nil {
+		if d.KubeClient == nil {
+			return nil, errors.New("kube client is required for server-side apply, pruning and waiting")
+		}
+
+		objectClient, err := kubectl.NewObjectClient(d.KubeClient.RestConfig())
+		if err != nil {
+			return nil, err
+		}
+
+		d.objectClient = objectClient
+	}
+
+	return d.objectClient, nil
+}
@@ -0,0 +1,240 @@
+package kubectl
+
+import (
+	"context"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config"
+	"github.com/loft-sh/devspace/pkg/devspace/config/generated"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	log "github.com/loft-sh/devspace/pkg/util/log/testing"
+	"gotest.tools/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/fake"
+)
+
+type fakeObjectClient struct {
+	client dynamic.Interface
+
+	// onResourceInterface is called for every requested resource interface if set
+	onResourceInterface func(gvk schema.GroupVersionKind)
+}
+
+func (f *fakeObjectClient) ResourceInterface(gvk schema.GroupVersionKind, namespace string) (dynamic.ResourceInterface, bool, error) {
+	if f.onResourceInterface != nil {
+		f.onResourceInterface(gvk)
+	}
+
+	switch gvk.Kind {
+	case "ConfigMap":
+		return f.client.Resource(schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}).Namespace(namespace), true, nil
+	case "Namespace":
+		return f.client.Resource(schema.GroupVersionResource{Version: "v1", Resource: "namespaces"}), false, nil
+	case "CustomResourceDefinition":
+		return f.client.Resource(schema.GroupVersionResource{Group: "apiextensions.k8s.io", Version: "v1", Resource: "customresourcedefinitions"}), false, nil
+	}
+
+	return nil, false, &meta.NoKindMatchError{GroupKind: gvk.GroupKind()}
+}
+
+func newObject(kind, namespace, name string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("v1")
+	obj.SetKind(kind)
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	return obj
+}
+
+func TestGetObjects(t *testing.T) {
+	deployer := &DeployConfig{
+		Namespace: "myNamespace",
+	}
+
+	objects, err := deployer.getObjects(`
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: first
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: second
+  namespace: other
+`)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, objects, []generated.KubectlObject{
+		{APIVersion: "v1", Kind: "ConfigMap", Namespace: "myNamespace", Name: "first"},
+		{APIVersion: "v1", Kind: "ConfigMap", Namespace: "other", Name: "second"},
+	})
+}
+
+func TestPrune(t *testing.T) {
+	dynamicClient := fake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		{Version: "v1", Resource: "configmaps"}: "ConfigMapList",
+		{Version: "v1", Resource: "namespaces"}: "NamespaceList",
+	},
+		newObject("ConfigMap", "myNamespace", "kept"),
+		newObject("ConfigMap", "myNamespace", "removed"),
+		newObject("Namespace", "", "removed-namespace"),
+	)
+	deployer := &DeployConfig{
+		Namespace: "myNamespace",
+		DeploymentConfig: &latest.DeploymentConfig{
+			Name:    "my-deployment",
+			Kubectl: &latest.KubectlConfig{},
+		},
+		objectClient: &fakeObjectClient{client: dynamicClient},
+		Log:          &log.FakeLogger{},
+	}
+
+	err := deployer.prune([]generated.KubectlObject{
+		{APIVersion: "v1", Kind: "Namespace", Name: "removed-namespace"},
+		{APIVersion: "v1", Kind: "ConfigMap", Namespace: "myNamespace", Name: "kept"},
+		{APIVersion: "v1", Kind: "ConfigMap", Namespace: "myNamespace", Name: "removed"},
+		{APIVersion: "v1", Kind: "ConfigMap", Namespace: "myNamespace", Name: "already-deleted"},
+		{APIVersion: "example.com/v1", Kind: "Unknown", Namespace: "myNamespace", Name: "unknown"},
+	}, []generated.KubectlObject{
+		{APIVersion: "v1", Kind: "ConfigMap", Namespace: "myNamespace", Name: "kept"},
+	})
+	assert.NilError(t, err)
+
+	configMaps, err := dynamicClient.Resource(schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}).Namespace("myNamespace").List(context.TODO(), metav1.ListOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, len(configMaps.Items), 1)
+	assert.Equal(t, configMaps.Items[0].GetName(), "kept")
+
+	namespaces, err := dynamicClient.Resource(schema.GroupVersionResource{Version: "v1", Resource: "namespaces"}).List(context.TODO(), metav1.ListOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, len(namespaces.Items), 0)
+}
+
+func TestPruneNamespaceSwitch(t *testing.T) {
+	defer useTempHistory(t)()
+
+	dynamicClient := fake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		{Version: "v1", Resource: "configmaps"}: "ConfigMapList",
+	},
+		newObject("ConfigMap", "oldNamespace", "app"),
+		newObject("ConfigMap", "oldNamespace", "removed"),
+	)
+
+	cache := generated.New()
+	deployCache := cache.GetActive().GetDeploymentCache("my-deployment")
+	deployCache.KubectlObjects = []generated.KubectlObject{
+		{APIVersion: "v1", Kind: "ConfigMap", Namespace: "oldNamespace", Name: "app"},
+		{APIVersion: "v1", Kind: "ConfigMap", Namespace: "oldNamespace", Name: "removed"},
+	}
+	deployCache.KubectlObjectsContext = "myContext"
+	deployCache.KubectlObjectsNamespace = "oldNamespace"
+
+	deployer := &DeployConfig{
+		Context:   "myContext",
+		Namespace: "myNamespace",
+		Manifests: []string{"."},
+		DeploymentConfig: &latest.DeploymentConfig{
+			Name:    "my-deployment",
+			Kubectl: &latest.KubectlConfig{Prune: true},
+		},
+		config:          config.NewConfig(nil, latest.NewRaw(), cache, nil),
+		commandExecuter: &fakeExecuter{output: "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: app\n"},
+		objectClient:    &fakeObjectClient{client: dynamicClient},
+		Log:             &log.FakeLogger{},
+	}
+
+	// deploying into another namespace keeps the objects of the previous namespace
+	_, err := deployer.Deploy(false, nil)
+	assert.NilError(t, err)
+
+	configMaps, err := dynamicClient.Resource(schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}).Namespace("oldNamespace").List(context.TODO(), metav1.ListOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, len(configMaps.Items), 2)
+	assert.DeepEqual(t, deployCache.KubectlObjects, []generated.KubectlObject{
+		{APIVersion: "v1", Kind: "ConfigMap", Namespace: "myNamespace", Name: "app"},
+	})
+	assert.Equal(t, deployCache.KubectlObjectsNamespace, "myNamespace")
+
+	// objects of the current namespace are still pruned
+	deployer.commandExecuter = &fakeExecuter{output: "apiVersion: v1\nkind: Secret\nmetadata:\n  name: app\n"}
+	_, err = dynamicClient.Resource(schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}).Namespace("myNamespace").Create(context.TODO(), newObject("ConfigMap", "myNamespace", "app"), metav1.CreateOptions{})
+	assert.NilError(t, err)
+	_, err = deployer.Deploy(false, nil)
+	assert.NilError(t, err)
+
+	configMaps, err = dynamicClient.Resource(schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}).Namespace("myNamespace").List(context.TODO(), metav1.ListOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, len(configMaps.Items), 0)
+}
+
+func TestApplyCustomResourceDefinitionsFirst(t *testing.T) {
+	manifest := `
+apiVersion: example.com/v1
+kind: Database
+metadata:
+  name: my-database
+  namespace: databases
+---
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: databases
+---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: databases.example.com
+`
+	objects, err := stringToUnstructuredArray(manifest)
+	assert.NilError(t, err)
+	crds, namespaces, others := splitObjects(objects)
+	assert.DeepEqual(t, []string{crds[0].GetKind(), namespaces[0].GetKind(), others[0].GetKind()}, []string{"CustomResourceDefinition", "Namespace", "Database"})
+
+	crd := &unstructured.Unstructured{}
+	crd.SetAPIVersion("apiextensions.k8s.io/v1")
+	crd.SetKind("CustomResourceDefinition")
+	crd.SetName("databases.example.com")
+	err = unstructured.SetNestedSlice(crd.Object, []interface{}{
+		map[string]interface{}{"type": "Established", "status": "True"},
+	}, "status", "conditions")
+	assert.NilError(t, err)
+
+	applyArgs := []string{"--namespace", "myNamespace", "apply", "--force", "-f", "-"}
+	executer := &fakeExecuter{
+		t:            t,
+		testCase:     "apply custom resource definitions first",
+		expectedPath: []string{"kubectl", "kubectl", "kubectl"},
+		expectedArgs: [][]string{applyArgs, applyArgs, applyArgs},
+	}
+	deployer := &DeployConfig{
+		CmdPath:   "kubectl",
+		Namespace: "myNamespace",
+		DeploymentConfig: &latest.DeploymentConfig{
+			Name:    "my-deployment",
+			Kubectl: &latest.KubectlConfig{},
+		},
+		commandExecuter: executer,
+		Log:             &log.FakeLogger{},
+	}
+
+	// remember how many applies were still pending while waiting for the custom resource definition
+	pendingApplies := []int{}
+	deployer.objectClient = &fakeObjectClient{
+		client: fake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{}, crd),
+		onResourceInterface: func(gvk schema.GroupVersionKind) {
+			if gvk.Kind == "CustomResourceDefinition" {
+				pendingApplies = append(pendingApplies, len(executer.expectedArgs))
+			}
+		},
+	}
+
+	err = deployer.apply(manifest)
+	assert.NilError(t, err)
+	assert.Equal(t, len(executer.expectedArgs), 0)
+	assert.DeepEqual(t, pendingApplies, []int{2})
+}
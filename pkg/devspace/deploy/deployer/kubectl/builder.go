@@ -10,6 +10,9 @@ import (
 	"os/exec"
 	"regexp"
 	"strings"
+
+	"sigs.k8s.io/kustomize/api/filesys"
+	"sigs.k8s.io/kustomize/api/krusty"
 )
 
 // Builder is the manifest builder interface
@@ -52,6 +55,39 @@ func (k *kustomizeBuilder) Build(manifest string, cmd RunCommand) ([]*unstructur
 	return stringToUnstructuredArray(string(output))
 }
 
+type nativeKustomizeBuilder struct {
+	log log.Logger
+}
+
+// NewNativeKustomizeBuilder creates a new manifest builder that renders kustomizations in-process
+// without the kustomize or kubectl binary
+func NewNativeKustomizeBuilder(log log.Logger) Builder {
+	return &nativeKustomizeBuilder{
+		log: log,
+	}
+}
+
+func (k *nativeKustomizeBuilder) Build(manifest string, cmd RunCommand) ([]*unstructured.Unstructured, error) {
+	k.log.Infof("Render manifests with kustomize %s", manifest)
+	resMap, err := krusty.MakeKustomizer(krusty.MakeDefaultOptions()).Run(filesys.MakeFsOnDisk(), manifest)
+	if err != nil {
+		return nil, errors.Wrapf(err, "kustomize %s", manifest)
+	}
+
+	// Convert the resources directly, so we don't need to parse the yaml again
+	objs := []*unstructured.Unstructured{}
+	for _, resource := range resMap.Resources() {
+		obj, err := resource.Map()
+		if err != nil {
+			return nil, errors.Wrapf(err, "convert resource %s", resource.CurId().String())
+		}
+
+		objs = append(objs, &unstructured.Unstructured{Object: obj})
+	}
+
+	return objs, nil
+}
+
 type kubectlBuilder struct {
 	path        string
 	config      *latest.DeploymentConfig
@@ -136,3 +172,18 @@ func stringToUnstructuredArray(out string) ([]*unstructured.Unstructured, error)
 	}
 	return objs, firstErr
 }
+
+// unstructuredArrayToString joins the unstructured objects into a single YAML file
+func unstructuredArrayToString(objs []*unstructured.Unstructured) (string, error) {
+	parts := []string{}
+	for _, obj := range objs {
+		out, err := yaml.Marshal(obj)
+		if err != nil {
+			return "", errors.Wrap(err, "marshal yaml")
+		}
+
+		parts = append(parts, string(out))
+	}
+
+	return strings.Join(parts, "\n---\n"), nil
+}
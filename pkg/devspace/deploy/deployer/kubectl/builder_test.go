@@ -0,0 +1,60 @@
+package kubectl
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	log "github.com/loft-sh/devspace/pkg/util/log/testing"
+	"gotest.tools/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestNativeKustomizeBuilder(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kustomize")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"kustomization.yaml": `
+namePrefix: dev-
+commonLabels:
+  app: backend
+resources:
+- deployment.yaml
+images:
+- name: backend
+  newTag: v2
+`,
+		"deployment.yaml": `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: backend
+spec:
+  template:
+    spec:
+      containers:
+      - name: backend
+        image: backend
+`,
+	}
+	for name, content := range files {
+		err = ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0666)
+		assert.NilError(t, err)
+	}
+
+	objs, err := NewNativeKustomizeBuilder(&log.FakeLogger{}).Build(dir, nil)
+	assert.NilError(t, err)
+	assert.Equal(t, len(objs), 1)
+	assert.Equal(t, objs[0].GetName(), "dev-backend")
+	assert.Equal(t, objs[0].GetLabels()["app"], "backend")
+
+	containers, _, err := unstructured.NestedSlice(objs[0].Object, "spec", "template", "spec", "containers")
+	assert.NilError(t, err)
+	assert.Equal(t, containers[0].(map[string]interface{})["image"], "backend:v2")
+
+	_, err = NewNativeKustomizeBuilder(&log.FakeLogger{}).Build(filepath.Join(dir, "missing"), nil)
+	assert.Assert(t, err != nil)
+}
@@ -0,0 +1,27 @@
+package kubectl
+
+import (
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer/util"
+	"github.com/pkg/errors"
+)
+
+// Drift compares the manifests of the last deployed revision with the live objects in the cluster
+func (d *DeployConfig) Drift() ([]*deployer.ResourceDrift, error) {
+	deployCache := d.config.Generated().GetActive().GetDeploymentCache(d.DeploymentConfig.Name)
+	if deployCache.KubectlRevision < 1 {
+		return nil, errors.Errorf("deployment %s has no deployed revision, please run 'devspace deploy' first", d.DeploymentConfig.Name)
+	}
+
+	manifests, err := loadRevision(d.config.Generated().GetActiveProfile(), d.DeploymentConfig.Name, deployCache.KubectlRevision)
+	if err != nil {
+		return nil, err
+	}
+
+	objectClient, err := d.getObjectClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return util.DetectDrift(objectClient, manifests, d.Namespace)
+}
@@ -0,0 +1,265 @@
+package kubectl
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/constants"
+	"github.com/loft-sh/devspace/pkg/util/encryption"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// HistoryPath is the relative path where the applied manifests of kubectl deployments are stored
+var HistoryPath = ".devspace/history"
+
+// HistoryKeyPath is the path of the key that encrypts the values of secrets in the history. Relative paths
+// are relative to the home directory, so the key is never part of the project
+var HistoryKeyPath = filepath.Join(constants.DefaultHomeDevSpaceFolder, "history.key")
+
+const (
+	encryptedPrefix = "DEVSPACE_ENC["
+	encryptedSuffix = "]"
+)
+
+var documentSeparator = regexp.MustCompile(`(?m)^---.*$`)
+
+// MaxHistoryRevisions is the number of revisions that are kept for each deployment
+const MaxHistoryRevisions = 10
+
+// saveRevision stores the applied manifests as the given revision and removes revisions that are too old
+func saveRevision(profile, deploymentName string, revision int, manifests string) error {
+	dir := historyDir(profile, deploymentName)
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return err
+	}
+
+	err = ensureGitIgnore()
+	if err != nil {
+		return err
+	}
+
+	key, err := historyKey()
+	if err != nil {
+		return errors.Wrap(err, "get history key")
+	}
+
+	manifests, err = transformSecretValues(manifests, func(value string) (string, error) {
+		encrypted, err := encryption.EncryptAES(key, []byte(value))
+		if err != nil {
+			return "", err
+		}
+
+		return encryptedPrefix + base64.StdEncoding.EncodeToString(encrypted) + encryptedSuffix, nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "encrypt secrets")
+	}
+
+	err = ioutil.WriteFile(filepath.Join(dir, strconv.Itoa(revision)+".yaml"), []byte(manifests), 0600)
+	if err != nil {
+		return err
+	}
+
+	revisions, err := listRevisions(profile, deploymentName)
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < len(revisions)-MaxHistoryRevisions; i++ {
+		err = os.Remove(filepath.Join(dir, strconv.Itoa(revisions[i])+".yaml"))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// loadRevision returns the manifests that were applied in the given revision
+func loadRevision(profile, deploymentName string, revision int) (string, error) {
+	manifests, err := ioutil.ReadFile(filepath.Join(historyDir(profile, deploymentName), strconv.Itoa(revision)+".yaml"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", errors.Errorf("revision %d of deployment %s not found", revision, deploymentName)
+		}
+
+		return "", err
+	}
+
+	key, err := historyKey()
+	if err != nil {
+		return "", errors.Wrap(err, "get history key")
+	}
+
+	return transformSecretValues(string(manifests), func(value string) (string, error) {
+		if strings.HasPrefix(value, encryptedPrefix) == false || strings.HasSuffix(value, encryptedSuffix) == false {
+			return value, nil
+		}
+
+		decoded, err := base64.StdEncoding.DecodeString(strings.TrimSuffix(strings.TrimPrefix(value, encryptedPrefix), encryptedSuffix))
+		if err != nil {
+			return "", err
+		}
+
+		decrypted, err := encryption.DecryptAES(key, decoded)
+		if err != nil {
+			return "", errors.Wrapf(err, "decrypt revision %d of deployment %s", revision, deploymentName)
+		}
+
+		return string(decrypted), nil
+	})
+}
+
+// transformSecretValues replaces the values of data and stringData of all secrets in the manifests with the
+// values returned by fn. All other documents are kept as they are
+func transformSecretValues(manifests string, fn func(value string) (string, error)) (string, error) {
+	parts := documentSeparator.Split(manifests, -1)
+	for i, part := range parts {
+		document := yaml.MapSlice{}
+		err := yaml.Unmarshal([]byte(part), &document)
+		if err != nil || isSecret(document) == false {
+			continue
+		}
+
+		for _, item := range document {
+			if item.Key != "data" && item.Key != "stringData" {
+				continue
+			}
+
+			values, ok := item.Value.(yaml.MapSlice)
+			if !ok {
+				continue
+			}
+
+			for j, value := range values {
+				stringValue, ok := value.Value.(string)
+				if !ok {
+					continue
+				}
+
+				values[j].Value, err = fn(stringValue)
+				if err != nil {
+					return "", err
+				}
+			}
+		}
+
+		out, err := yaml.Marshal(document)
+		if err != nil {
+			return "", err
+		}
+
+		parts[i] = string(out)
+		if i > 0 {
+			parts[i] = "\n" + parts[i]
+		}
+	}
+
+	return strings.Join(parts, "---"), nil
+}
+
+func isSecret(document yaml.MapSlice) bool {
+	for _, item := range document {
+		if item.Key == "kind" && item.Value == "Secret" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// historyKey returns the key that encrypts the values of secrets in the history and creates it if necessary
+func historyKey() ([]byte, error) {
+	keyPath := HistoryKeyPath
+	if filepath.IsAbs(keyPath) == false {
+		home, err := homedir.Dir()
+		if err != nil {
+			return nil, err
+		}
+
+		keyPath = filepath.Join(home, keyPath)
+	}
+
+	key, err := ioutil.ReadFile(keyPath)
+	if err == nil {
+		return hex.DecodeString(strings.TrimSpace(string(key)))
+	} else if os.IsNotExist(err) == false {
+		return nil, err
+	}
+
+	newKey := make([]byte, 32)
+	_, err = rand.Read(newKey)
+	if err != nil {
+		return nil, err
+	}
+
+	err = os.MkdirAll(filepath.Dir(keyPath), 0755)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ioutil.WriteFile(keyPath, []byte(hex.EncodeToString(newKey)), 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	return newKey, nil
+}
+
+// ensureGitIgnore ignores the history folder in git, even if the project does not ignore it
+func ensureGitIgnore() error {
+	gitIgnore := filepath.Join(HistoryPath, ".gitignore")
+	_, err := os.Stat(gitIgnore)
+	if os.IsNotExist(err) {
+		return ioutil.WriteFile(gitIgnore, []byte("*\n"), 0644)
+	}
+
+	return err
+}
+
+// listRevisions returns the stored revisions of the deployment in ascending order
+func listRevisions(profile, deploymentName string) ([]int, error) {
+	files, err := ioutil.ReadDir(historyDir(profile, deploymentName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []int{}, nil
+		}
+
+		return nil, err
+	}
+
+	revisions := []int{}
+	for _, file := range files {
+		revision, err := strconv.Atoi(strings.TrimSuffix(file.Name(), ".yaml"))
+		if err != nil || file.IsDir() {
+			continue
+		}
+
+		revisions = append(revisions, revision)
+	}
+
+	sort.Ints(revisions)
+	return revisions, nil
+}
+
+// historyDir returns the directory of the deployment revisions. Profiles have their own history,
+// because they are deployed with a different config
+func historyDir(profile, deploymentName string) string {
+	if profile == "" {
+		return filepath.Join(HistoryPath, "default", deploymentName)
+	}
+
+	return filepath.Join(HistoryPath, "profiles", profile, deploymentName)
+}
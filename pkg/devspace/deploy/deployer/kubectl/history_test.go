@@ -0,0 +1,188 @@
+package kubectl
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config"
+	"github.com/loft-sh/devspace/pkg/devspace/config/generated"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	log "github.com/loft-sh/devspace/pkg/util/log/testing"
+	"gotest.tools/assert"
+)
+
+// useTempHistory stores the revision history in a temporary directory and returns a function to restore it
+func useTempHistory(t *testing.T) func() {
+	dir, err := ioutil.TempDir("", "devspace-history")
+	assert.NilError(t, err)
+
+	oldHistoryPath := HistoryPath
+	oldHistoryKeyPath := HistoryKeyPath
+	HistoryPath = filepath.Join(dir, "history")
+	HistoryKeyPath = filepath.Join(dir, "history.key")
+	return func() {
+		HistoryPath = oldHistoryPath
+		HistoryKeyPath = oldHistoryKeyPath
+		os.RemoveAll(dir)
+	}
+}
+
+func TestHistory(t *testing.T) {
+	defer useTempHistory(t)()
+
+	for i := 1; i <= MaxHistoryRevisions+2; i++ {
+		err := saveRevision("", "my-deployment", i, "revision"+strconv.Itoa(i))
+		assert.NilError(t, err)
+	}
+
+	revisions, err := listRevisions("", "my-deployment")
+	assert.NilError(t, err)
+	assert.Equal(t, len(revisions), MaxHistoryRevisions)
+	assert.Equal(t, revisions[0], 3)
+
+	manifests, err := loadRevision("", "my-deployment", 5)
+	assert.NilError(t, err)
+	assert.Equal(t, manifests, "revision5")
+
+	_, err = loadRevision("", "my-deployment", 1)
+	assert.Error(t, err, "revision 1 of deployment my-deployment not found")
+
+	// Profiles have their own history
+	revisions, err = listRevisions("my-profile", "my-deployment")
+	assert.NilError(t, err)
+	assert.Equal(t, len(revisions), 0)
+}
+
+func TestHistorySecrets(t *testing.T) {
+	defer useTempHistory(t)()
+
+	manifests := `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+data:
+  key: value
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: secret
+data:
+  password: c2VjcmV0
+stringData:
+  token: my-token
+`
+	err := saveRevision("", "my-deployment", 1, manifests)
+	assert.NilError(t, err)
+
+	revisionFile := filepath.Join(HistoryPath, "default", "my-deployment", "1.yaml")
+	stat, err := os.Stat(revisionFile)
+	assert.NilError(t, err)
+	assert.Equal(t, stat.Mode().Perm(), os.FileMode(0600))
+
+	saved, err := ioutil.ReadFile(revisionFile)
+	assert.NilError(t, err)
+	assert.Assert(t, strings.Contains(string(saved), "key: value"))
+	assert.Assert(t, strings.Contains(string(saved), "c2VjcmV0") == false)
+	assert.Assert(t, strings.Contains(string(saved), "my-token") == false)
+
+	loaded, err := loadRevision("", "my-deployment", 1)
+	assert.NilError(t, err)
+	assert.Equal(t, loaded, manifests)
+
+	gitIgnore, err := ioutil.ReadFile(filepath.Join(HistoryPath, ".gitignore"))
+	assert.NilError(t, err)
+	assert.Equal(t, string(gitIgnore), "*\n")
+
+	_, err = os.Stat(filepath.Join(filepath.Dir(HistoryPath), ".gitignore"))
+	assert.Assert(t, os.IsNotExist(err))
+}
+
+type rollbackTestCase struct {
+	name string
+
+	revisions       int
+	currentRevision int
+	revision        int
+
+	expectedErr      string
+	expectedArgs     [][]string
+	expectedRevision int
+}
+
+func TestRollback(t *testing.T) {
+	testCases := []rollbackTestCase{
+		{
+			name:             "Rollback to previous revision",
+			revisions:        2,
+			currentRevision:  2,
+			expectedArgs:     [][]string{{"--context", "myContext", "--namespace", "myNamespace", "apply", "--force", "-f", "-"}},
+			expectedRevision: 3,
+		},
+		{
+			name:             "Rollback to specific revision",
+			revisions:        3,
+			currentRevision:  3,
+			revision:         1,
+			expectedArgs:     [][]string{{"--context", "myContext", "--namespace", "myNamespace", "apply", "--force", "-f", "-"}},
+			expectedRevision: 4,
+		},
+		{
+			name:             "No previous revision",
+			revisions:        1,
+			currentRevision:  1,
+			expectedErr:      "deployment my-deployment has no previous revision",
+			expectedRevision: 1,
+		},
+		{
+			name:             "Unknown revision",
+			revisions:        2,
+			currentRevision:  2,
+			revision:         5,
+			expectedErr:      "revision 5 of deployment my-deployment not found",
+			expectedRevision: 2,
+		},
+	}
+
+	for _, testCase := range testCases {
+		cleanup := useTempHistory(t)
+		for i := 1; i <= testCase.revisions; i++ {
+			err := saveRevision("", "my-deployment", i, "kind: ConfigMap\napiVersion: v1\nmetadata:\n  name: revision"+strconv.Itoa(i))
+			assert.NilError(t, err)
+		}
+
+		cache := generated.New()
+		cache.GetActive().GetDeploymentCache("my-deployment").KubectlRevision = testCase.currentRevision
+		deployer := &DeployConfig{
+			config:    config.NewConfig(nil, latest.NewRaw(), cache, nil),
+			CmdPath:   "myPath",
+			Context:   "myContext",
+			Namespace: "myNamespace",
+			DeploymentConfig: &latest.DeploymentConfig{
+				Name:    "my-deployment",
+				Kubectl: &latest.KubectlConfig{},
+			},
+			commandExecuter: &fakeExecuter{
+				t:            t,
+				testCase:     testCase.name,
+				expectedPath: []string{"myPath"},
+				expectedArgs: testCase.expectedArgs,
+			},
+			Log: &log.FakeLogger{},
+		}
+
+		err := deployer.Rollback(testCase.revision)
+		if testCase.expectedErr == "" {
+			assert.NilError(t, err, "Error in testCase %s", testCase.name)
+		} else {
+			assert.Error(t, err, testCase.expectedErr, "Wrong or no error in testCase %s", testCase.name)
+		}
+
+		assert.Equal(t, cache.GetActive().GetDeploymentCache("my-deployment").KubectlRevision, testCase.expectedRevision, "Unexpected revision in testCase %s", testCase.name)
+		cleanup()
+	}
+}
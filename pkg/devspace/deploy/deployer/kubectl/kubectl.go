@@ -1,8 +1,10 @@
 package kubectl
 
 import (
+	"fmt"
 	config2 "github.com/loft-sh/devspace/pkg/devspace/config"
 	"github.com/loft-sh/devspace/pkg/devspace/config/constants"
+	"github.com/loft-sh/devspace/pkg/devspace/config/generated"
 	"github.com/loft-sh/devspace/pkg/devspace/dependency/types"
 	"github.com/loft-sh/devspace/pkg/devspace/helm/downloader"
 	"github.com/mitchellh/go-homedir"
@@ -32,7 +34,7 @@ var (
 
 // DeployConfig holds the necessary information for kubectl deployment
 type DeployConfig struct {
-	KubeClient  kubectl.Client // This is not used yet, however the plan is to use it instead of calling kubectl via cmd
+	KubeClient  kubectl.Client // Used for server-side apply, pruning and waiting, everything else still calls kubectl via cmd
 	Name        string
 	CmdPath     string
 	Context     string
@@ -47,6 +49,7 @@ type DeployConfig struct {
 	dependencies []types.Dependency
 
 	commandExecuter commandExecuter
+	objectClient    kubectl.ObjectClient
 }
 
 // New creates a new deploy config for kubectl
@@ -246,6 +249,8 @@ func (d *DeployConfig) Deploy(forceDeploy bool, builtImages map[string]string) (
 	defer d.Log.StopWait()
 
 	wasDeployed := false
+	appliedManifests := []string{}
+	appliedObjects := []generated.KubectlObject{}
 
 	for _, manifest := range d.Manifests {
 		shouldRedeploy, replacedManifest, err := d.getReplacedManifest(manifest, builtImages)
@@ -254,12 +259,7 @@ func (d *DeployConfig) Deploy(forceDeploy bool, builtImages map[string]string) (
 		}
 
 		if shouldRedeploy || forceDeploy {
-			stringReader := strings.NewReader(replacedManifest)
-			args := d.getCmdArgs("apply", "--force")
-			args = append(args, d.DeploymentConfig.Kubectl.ApplyArgs...)
-
-			cmd := d.commandExecuter.GetCommand(d.CmdPath, args)
-			err = cmd.Run(d.Log, d.Log, stringReader)
+			err = d.apply(replacedManifest)
 			if err != nil {
 				return false, errors.Errorf("%v\nPlease make sure the command `kubectl apply` does work locally with manifest `%s`", err, manifest)
 			}
@@ -268,14 +268,107 @@ func (d *DeployConfig) Deploy(forceDeploy bool, builtImages map[string]string) (
 		} else {
 			d.Log.Infof("Skipping manifest %s", manifest)
 		}
+
+		objects, err := d.getObjects(replacedManifest)
+		if err != nil {
+			return false, err
+		}
+
+		appliedManifests = append(appliedManifests, replacedManifest)
+		appliedObjects = append(appliedObjects, objects...)
+	}
+
+	// Delete the objects that were removed from the manifests since the last deployment
+	if d.DeploymentConfig.Kubectl.Prune {
+		err = d.prune(util.PreviousObjects(deployCache, d.Context, d.Namespace), appliedObjects)
+		if err != nil {
+			return false, err
+		}
 	}
 
+	util.SetAppliedObjects(deployCache, appliedObjects, d.Context, d.Namespace)
 	deployCache.KubectlManifestsHash = manifestsHash
 	deployCache.DeploymentConfigHash = deploymentConfigHash
 
+	// Wait until the applied objects are ready
+	if wasDeployed && d.DeploymentConfig.Kubectl.Wait {
+		err = d.waitForObjects(appliedObjects)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	// Store the applied manifests, so we can roll back to them later
+	if wasDeployed {
+		d.saveRevision(deployCache, strings.Join(appliedManifests, "\n---\n"))
+	}
+
 	return wasDeployed, nil
 }
 
+// Rollback re-applies the manifests of the given revision or of the previous revision if revision is 0
+func (d *DeployConfig) Rollback(revision int) error {
+	deployCache := d.config.Generated().GetActive().GetDeploymentCache(d.DeploymentConfig.Name)
+	if revision == 0 {
+		revision = deployCache.KubectlRevision - 1
+	}
+	if revision < 1 {
+		return errors.Errorf("deployment %s has no previous revision", d.DeploymentConfig.Name)
+	}
+
+	manifests, err := loadRevision(d.config.Generated().GetActiveProfile(), d.DeploymentConfig.Name, revision)
+	if err != nil {
+		return err
+	}
+
+	d.Log.StartWait(fmt.Sprintf("Rolling back deployment %s to revision %d", d.DeploymentConfig.Name, revision))
+	defer d.Log.StopWait()
+
+	err = d.apply(manifests)
+	if err != nil {
+		return errors.Errorf("Unable to roll back deployment %s: %v", d.DeploymentConfig.Name, err)
+	}
+
+	objects, err := d.getObjects(manifests)
+	if err != nil {
+		return err
+	}
+
+	if d.DeploymentConfig.Kubectl.Prune {
+		err = d.prune(util.PreviousObjects(deployCache, d.Context, d.Namespace), objects)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Like helm, a rollback creates a new revision
+	d.saveRevision(deployCache, manifests)
+	util.SetAppliedObjects(deployCache, objects, d.Context, d.Namespace)
+	deployCache.KubectlManifestsHash = ""
+	deployCache.DeploymentConfigHash = ""
+
+	if d.DeploymentConfig.Kubectl.Wait {
+		err = d.waitForObjects(objects)
+		if err != nil {
+			return err
+		}
+	}
+
+	d.Log.Donef("Rolled back deployment %s to revision %d", d.DeploymentConfig.Name, revision)
+	return nil
+}
+
+// saveRevision stores the applied manifests as a new revision in the local history
+func (d *DeployConfig) saveRevision(deployCache *generated.DeploymentCache, manifests string) {
+	err := saveRevision(d.config.Generated().GetActiveProfile(), d.DeploymentConfig.Name, deployCache.KubectlRevision+1, manifests)
+	if err != nil {
+		d.Log.Warnf("Error saving revision of deployment %s: %v", d.DeploymentConfig.Name, err)
+		return
+	}
+
+	deployCache.KubectlRevision++
+}
+
 func (d *DeployConfig) getReplacedManifest(manifest string, builtImages map[string]string) (bool, string, error) {
 	objects, err := d.buildManifests(manifest)
 	if err != nil {
@@ -294,7 +387,7 @@ func (d *DeployConfig) getReplacedManifest(manifest string, builtImages map[stri
 		}
 
 		if d.DeploymentConfig.Kubectl.ReplaceImageTags == nil || *d.DeploymentConfig.Kubectl.ReplaceImageTags == true {
-			redeploy, err := util.ReplaceImageNamesStringMap(resource.Object, d.config, d.dependencies, builtImages, map[string]bool{"image": true})
+			redeploy, err := util.ReplaceImageNamesStringMap(resource.Object, d.config, d.dependencies, builtImages, map[string]bool{"image": true}, d.DeploymentConfig.Kubectl.PinImageDigests)
 			if err != nil {
 				return false, "", err
 			} else if redeploy {
@@ -333,8 +426,21 @@ func (d *DeployConfig) getCmdArgs(method string, additionalArgs ...string) []str
 
 func (d *DeployConfig) buildManifests(manifest string) ([]*unstructured.Unstructured, error) {
 	// Check if we should use kustomize or kubectl
-	if d.DeploymentConfig.Kubectl.Kustomize != nil && *d.DeploymentConfig.Kubectl.Kustomize == true && d.isKustomizeInstalled("kustomize") {
-		return NewKustomizeBuilder("kustomize", d.DeploymentConfig, d.Log).Build(manifest, d.commandExecuter.RunCommand)
+	if d.DeploymentConfig.Kubectl.Kustomize != nil && *d.DeploymentConfig.Kubectl.Kustomize == true {
+		// The kustomize binary is only used if it is configured or kustomize args are specified
+		if d.DeploymentConfig.Kubectl.KustomizeBinaryPath != "" {
+			return NewKustomizeBuilder(d.DeploymentConfig.Kubectl.KustomizeBinaryPath, d.DeploymentConfig, d.Log).Build(manifest, d.commandExecuter.RunCommand)
+		} else if len(d.DeploymentConfig.Kubectl.KustomizeArgs) > 0 {
+			if d.isKustomizeInstalled("kustomize") {
+				return NewKustomizeBuilder("kustomize", d.DeploymentConfig, d.Log).Build(manifest, d.commandExecuter.RunCommand)
+			}
+
+			// Fallback to kubectl, which does not support the kustomize args
+			d.Log.Warnf("kustomize binary not found, building %s with kubectl and ignoring kustomizeArgs %s", manifest, strings.Join(d.DeploymentConfig.Kubectl.KustomizeArgs, " "))
+			return NewKubectlBuilder(d.CmdPath, d.DeploymentConfig, d.Context, d.Namespace, d.IsInCluster).Build(manifest, d.commandExecuter.RunCommand)
+		}
+
+		return NewNativeKustomizeBuilder(d.Log).Build(manifest, d.commandExecuter.RunCommand)
 	}
 
 	// Build with kubectl
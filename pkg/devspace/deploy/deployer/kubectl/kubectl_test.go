@@ -352,6 +352,8 @@ type deployTestCase struct {
 }
 
 func TestDeploy(t *testing.T) {
+	defer useTempHistory(t)()
+
 	testCases := []deployTestCase{
 		deployTestCase{
 			name:             "deploy one manifest",
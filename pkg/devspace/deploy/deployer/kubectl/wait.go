@@ -0,0 +1,106 @@
+package kubectl
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/loft-sh/devspace/pkg/devspace/analyze"
+	"github.com/loft-sh/devspace/pkg/devspace/config/generated"
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// DefaultWaitTimeout is the time devspace waits for the applied objects to become ready if no timeout is specified
+const DefaultWaitTimeout = 5 * time.Minute
+
+// waitInterval is the interval in which the applied objects are checked
+var waitInterval = 2 * time.Second
+
+// waitForObjects waits until all given objects are ready. If the objects don't become ready in time, the
+// namespaces of the objects are analyzed to find out why
+func (d *DeployConfig) waitForObjects(objects []generated.KubectlObject) error {
+	objectClient, err := d.getObjectClient()
+	if err != nil {
+		return err
+	}
+
+	timeout := DefaultWaitTimeout
+	if d.DeploymentConfig.Kubectl.Timeout != nil {
+		timeout = time.Duration(*d.DeploymentConfig.Kubectl.Timeout) * time.Second
+	}
+
+	d.Log.StartWait(fmt.Sprintf("Waiting for deployment %s to become ready", d.DeploymentConfig.Name))
+	defer d.Log.StopWait()
+
+	pending := objects
+	message := ""
+	err = wait.PollImmediate(waitInterval, timeout, func() (bool, error) {
+		stillPending := []generated.KubectlObject{}
+		for _, ref := range pending {
+			resourceClient, _, err := objectClient.ResourceInterface(schema.FromAPIVersionAndKind(ref.APIVersion, ref.Kind), ref.Namespace)
+			if err != nil {
+				return false, errors.Wrapf(err, "get %s %s", ref.Kind, ref.Name)
+			}
+
+			obj, err := resourceClient.Get(context.TODO(), ref.Name, metav1.GetOptions{})
+			if err != nil {
+				return false, errors.Wrapf(err, "get %s %s", ref.Kind, ref.Name)
+			}
+
+			ready, reason, err := kubectl.IsReady(obj)
+			if err != nil {
+				return false, errors.Wrapf(err, "%s %s", ref.Kind, ref.Name)
+			} else if ready == false {
+				message = fmt.Sprintf("%s %s: %s", ref.Kind, ref.Name, reason)
+				stillPending = append(stillPending, ref)
+			}
+		}
+
+		pending = stillPending
+		if len(pending) > 0 {
+			d.Log.StartWait(fmt.Sprintf("Waiting for deployment %s to become ready (%s)", d.DeploymentConfig.Name, message))
+			return false, nil
+		}
+
+		return true, nil
+	})
+	if err == wait.ErrWaitTimeout {
+		return errors.Errorf("timed out waiting for deployment %s to become ready (%s)%s", d.DeploymentConfig.Name, message, d.analyze(pending))
+	} else if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// analyze returns a report of the problems within the namespaces of the given objects
+func (d *DeployConfig) analyze(objects []generated.KubectlObject) string {
+	if d.KubeClient == nil {
+		return ""
+	}
+
+	report := ""
+	analyzed := map[string]bool{}
+	for _, obj := range objects {
+		if obj.Namespace == "" || analyzed[obj.Namespace] {
+			continue
+		}
+
+		analyzed[obj.Namespace] = true
+		items, err := analyze.NewAnalyzer(d.KubeClient, d.Log).CreateReport(obj.Namespace, analyze.Options{})
+		if err != nil {
+			d.Log.Warnf("Error analyzing namespace %s: %v", obj.Namespace, err)
+			continue
+		} else if len(items) == 0 {
+			continue
+		}
+
+		report += analyze.ReportToString(items)
+	}
+
+	return report
+}
@@ -0,0 +1,42 @@
+package kubectl
+
+import (
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/generated"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	log "github.com/loft-sh/devspace/pkg/util/log/testing"
+	"gotest.tools/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/fake"
+)
+
+func TestWaitForObjects(t *testing.T) {
+	timeout := int64(1)
+	deployer := &DeployConfig{
+		DeploymentConfig: &latest.DeploymentConfig{
+			Name: "my-deployment",
+			Kubectl: &latest.KubectlConfig{
+				Wait:    true,
+				Timeout: &timeout,
+			},
+		},
+		objectClient: &fakeObjectClient{
+			client: fake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{},
+				newObject("ConfigMap", "myNamespace", "ready"),
+			),
+		},
+		Log: &log.FakeLogger{},
+	}
+
+	err := deployer.waitForObjects([]generated.KubectlObject{
+		{APIVersion: "v1", Kind: "ConfigMap", Namespace: "myNamespace", Name: "ready"},
+	})
+	assert.NilError(t, err)
+
+	err = deployer.waitForObjects([]generated.KubectlObject{
+		{APIVersion: "v1", Kind: "ConfigMap", Namespace: "myNamespace", Name: "missing"},
+	})
+	assert.ErrorContains(t, err, "get ConfigMap missing")
+}
@@ -0,0 +1,174 @@
+package util
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/generated"
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
+	"github.com/loft-sh/devspace/pkg/util/log"
+
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// ApplyObjects applies the objects in-process with server-side apply. Namespaced objects without a namespace
+// are applied to the given namespace
+func ApplyObjects(objectClient kubectl.ObjectClient, objects []*unstructured.Unstructured, namespace string) error {
+	force := true
+	for _, obj := range objects {
+		objNamespace := obj.GetNamespace()
+		if objNamespace == "" {
+			objNamespace = namespace
+		}
+
+		resourceClient, namespaced, err := objectClient.ResourceInterface(obj.GroupVersionKind(), objNamespace)
+		if err != nil {
+			return errors.Wrapf(err, "apply %s %s", obj.GetKind(), obj.GetName())
+		} else if namespaced {
+			obj.SetNamespace(objNamespace)
+		}
+
+		data, err := json.Marshal(obj)
+		if err != nil {
+			return err
+		}
+
+		_, err = resourceClient.Patch(context.TODO(), obj.GetName(), types.ApplyPatchType, data, metav1.PatchOptions{
+			FieldManager: kubectl.FieldManager,
+			Force:        &force,
+		})
+		if err != nil {
+			return errors.Wrapf(err, "apply %s %s", obj.GetKind(), obj.GetName())
+		}
+	}
+
+	return nil
+}
+
+// GetObjectReferences returns the references of the given objects. Objects without a namespace are
+// expected to be in the given namespace
+func GetObjectReferences(objects []*unstructured.Unstructured, namespace string) []generated.KubectlObject {
+	refs := []generated.KubectlObject{}
+	for _, obj := range objects {
+		objNamespace := obj.GetNamespace()
+		if objNamespace == "" {
+			objNamespace = namespace
+		}
+
+		refs = append(refs, generated.KubectlObject{
+			APIVersion: obj.GetAPIVersion(),
+			Kind:       obj.GetKind(),
+			Namespace:  objNamespace,
+			Name:       obj.GetName(),
+		})
+	}
+
+	return refs
+}
+
+// PreviousObjects returns the cached objects of a deployment if they were applied to the given kube context and
+// namespace. Objects that were applied to another target belong to another deployment of the project, e.g.
+// in another namespace, and are neither pruned nor considered deployed
+func PreviousObjects(deployCache *generated.DeploymentCache, kubeContext, namespace string) []generated.KubectlObject {
+	if deployCache.KubectlObjectsContext != kubeContext || deployCache.KubectlObjectsNamespace != namespace {
+		return nil
+	}
+
+	return deployCache.KubectlObjects
+}
+
+// SetAppliedObjects stores the applied objects of a deployment together with the target they were applied to
+func SetAppliedObjects(deployCache *generated.DeploymentCache, objects []generated.KubectlObject, kubeContext, namespace string) {
+	deployCache.KubectlObjects = objects
+	deployCache.KubectlObjectsContext = kubeContext
+	deployCache.KubectlObjectsNamespace = namespace
+}
+
+// objectKey identifies an object independent of its api version, because the same object can be served
+// in several api versions
+type objectKey struct {
+	GroupKind schema.GroupKind
+	Namespace string
+	Name      string
+}
+
+func keyOf(obj generated.KubectlObject) objectKey {
+	return objectKey{
+		GroupKind: schema.FromAPIVersionAndKind(obj.APIVersion, obj.Kind).GroupKind(),
+		Namespace: obj.Namespace,
+		Name:      obj.Name,
+	}
+}
+
+// PruneObjects deletes the previously applied objects that are not part of the applied objects anymore. Objects
+// are compared by group, kind, namespace and name, so an object that moved to another api version is kept
+func PruneObjects(objectClient kubectl.ObjectClient, previousObjects []generated.KubectlObject, appliedObjects []generated.KubectlObject, log log.Logger) error {
+	applied := map[objectKey]bool{}
+	for _, obj := range appliedObjects {
+		applied[keyOf(obj)] = true
+	}
+
+	// Delete the objects in reverse order
+	for i := len(previousObjects) - 1; i >= 0; i-- {
+		obj := previousObjects[i]
+		if applied[keyOf(obj)] {
+			continue
+		}
+
+		deleted, err := deleteObject(objectClient, obj, log)
+		if err != nil {
+			return errors.Wrapf(err, "prune %s %s", obj.Kind, objectName(obj))
+		} else if deleted {
+			log.Infof("Pruned %s %s", obj.Kind, objectName(obj))
+		}
+	}
+
+	return nil
+}
+
+// DeleteObjects deletes the given objects in reverse order
+func DeleteObjects(objectClient kubectl.ObjectClient, objects []generated.KubectlObject, log log.Logger) error {
+	for i := len(objects) - 1; i >= 0; i-- {
+		obj := objects[i]
+		_, err := deleteObject(objectClient, obj, log)
+		if err != nil {
+			return errors.Wrapf(err, "delete %s %s", obj.Kind, objectName(obj))
+		}
+	}
+
+	return nil
+}
+
+// deleteObject deletes a single object and returns true if the object existed
+func deleteObject(objectClient kubectl.ObjectClient, obj generated.KubectlObject, log log.Logger) (bool, error) {
+	resourceClient, _, err := objectClient.ResourceInterface(schema.FromAPIVersionAndKind(obj.APIVersion, obj.Kind), obj.Namespace)
+	if err != nil {
+		log.Warnf("Couldn't delete %s %s: %v", obj.Kind, obj.Name, err)
+		return false, nil
+	}
+
+	propagationPolicy := metav1.DeletePropagationBackground
+	err = resourceClient.Delete(context.TODO(), obj.Name, metav1.DeleteOptions{PropagationPolicy: &propagationPolicy})
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return true, nil
+}
+
+func objectName(obj generated.KubectlObject) string {
+	if obj.Namespace != "" {
+		return obj.Namespace + "/" + obj.Name
+	}
+
+	return obj.Name
+}
@@ -0,0 +1,51 @@
+package util
+
+import (
+	"context"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/generated"
+	"github.com/loft-sh/devspace/pkg/util/log"
+
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/fake"
+)
+
+func TestPruneObjects(t *testing.T) {
+	newObject := func(apiVersion, kind, name string) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{}
+		obj.SetAPIVersion(apiVersion)
+		obj.SetKind(kind)
+		obj.SetNamespace("myNamespace")
+		obj.SetName(name)
+		return obj
+	}
+
+	dynamicClient := fake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		{Group: "autoscaling", Version: "v2", Resource: "horizontalpodautoscalers"}: "HorizontalPodAutoscalerList",
+		{Version: "v1", Resource: "configmaps"}:                                     "ConfigMapList",
+	},
+		newObject("autoscaling/v2", "HorizontalPodAutoscaler", "moved"),
+		newObject("v1", "ConfigMap", "removed"),
+	)
+
+	err := PruneObjects(&fakeObjectClient{client: dynamicClient}, []generated.KubectlObject{
+		{APIVersion: "autoscaling/v2beta2", Kind: "HorizontalPodAutoscaler", Namespace: "myNamespace", Name: "moved"},
+		{APIVersion: "v1", Kind: "ConfigMap", Namespace: "myNamespace", Name: "removed"},
+	}, []generated.KubectlObject{
+		{APIVersion: "autoscaling/v2", Kind: "HorizontalPodAutoscaler", Namespace: "myNamespace", Name: "moved"},
+	}, log.Discard)
+	assert.NilError(t, err)
+
+	autoscalers, err := dynamicClient.Resource(schema.GroupVersionResource{Group: "autoscaling", Version: "v2", Resource: "horizontalpodautoscalers"}).Namespace("myNamespace").List(context.TODO(), metav1.ListOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, len(autoscalers.Items), 1, "object that moved to another api version was pruned")
+
+	configMaps, err := dynamicClient.Resource(schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}).Namespace("myNamespace").List(context.TODO(), metav1.ListOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, len(configMaps.Items), 0)
+}
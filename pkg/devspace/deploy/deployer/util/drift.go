@@ -0,0 +1,239 @@
+package util
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/ghodss/yaml"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer"
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	yamlutil "k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// DetectDrift compares the objects of the deployed manifests with the live objects in the cluster and returns
+// the resources that were deleted, manually changed or are unhealthy. Objects without a namespace are expected
+// to be in the given namespace. Fields that are not part of the manifests, like defaults or the status, are ignored
+func DetectDrift(objectClient kubectl.ObjectClient, manifests string, namespace string) ([]*deployer.ResourceDrift, error) {
+	objects, err := parseManifests(manifests)
+	if err != nil {
+		return nil, err
+	}
+
+	drifts := []*deployer.ResourceDrift{}
+	for _, obj := range objects {
+		objNamespace := obj.GetNamespace()
+		if objNamespace == "" {
+			objNamespace = namespace
+		}
+
+		drift := &deployer.ResourceDrift{
+			APIVersion: obj.GetAPIVersion(),
+			Kind:       obj.GetKind(),
+			Name:       obj.GetName(),
+		}
+
+		resourceClient, namespaced, err := objectClient.ResourceInterface(obj.GroupVersionKind(), objNamespace)
+		if err != nil {
+			// The kind was removed from the cluster, e.g. because the custom resource definition was deleted
+			if meta.IsNoMatchError(err) {
+				drift.Deleted = true
+				drifts = append(drifts, drift)
+				continue
+			}
+
+			return nil, errors.Wrapf(err, "get %s %s", obj.GetKind(), obj.GetName())
+		} else if namespaced {
+			drift.Namespace = objNamespace
+		}
+
+		live, err := resourceClient.Get(context.TODO(), obj.GetName(), metav1.GetOptions{})
+		if err != nil {
+			if kerrors.IsNotFound(err) {
+				drift.Deleted = true
+				drifts = append(drifts, drift)
+				continue
+			}
+
+			return nil, errors.Wrapf(err, "get %s %s", obj.GetKind(), obj.GetName())
+		}
+
+		drift.Changed = compareObjects(obj, live)
+
+		ready, reason, err := kubectl.IsReady(live)
+		if err != nil {
+			drift.Unhealthy = err.Error()
+		} else if ready == false {
+			drift.Unhealthy = reason
+		}
+
+		if len(drift.Changed) > 0 || drift.Unhealthy != "" {
+			drifts = append(drifts, drift)
+		}
+	}
+
+	return drifts, nil
+}
+
+// parseManifests parses the objects of the given multi document manifests. Numbers are parsed as int64 like
+// in the objects that are returned by the api server
+func parseManifests(manifests string) ([]*unstructured.Unstructured, error) {
+	objects := []*unstructured.Unstructured{}
+	reader := yamlutil.NewYAMLReader(bufio.NewReader(bytes.NewReader([]byte(manifests))))
+	for {
+		document, err := reader.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, errors.Wrap(err, "read manifests")
+		}
+
+		data, err := yaml.YAMLToJSON(document)
+		if err != nil {
+			return nil, errors.Wrap(err, "parse manifests")
+		} else if len(bytes.TrimSpace(data)) == 0 || string(bytes.TrimSpace(data)) == "null" {
+			continue
+		}
+
+		obj, _, err := unstructured.UnstructuredJSONScheme.Decode(data, nil, nil)
+		if err != nil {
+			return nil, errors.Wrap(err, "parse manifests")
+		}
+
+		switch t := obj.(type) {
+		case *unstructured.Unstructured:
+			objects = append(objects, t)
+		case *unstructured.UnstructuredList:
+			err = t.EachListItem(func(item runtime.Object) error {
+				objects = append(objects, item.(*unstructured.Unstructured))
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return objects, nil
+}
+
+// compareObjects returns the paths of the fields of the desired object that differ in the live object
+func compareObjects(desired *unstructured.Unstructured, live *unstructured.Unstructured) []string {
+	desired = desired.DeepCopy()
+	unstructured.RemoveNestedField(desired.Object, "status")
+	unstructured.RemoveNestedField(desired.Object, "metadata", "namespace")
+
+	// The api server only returns the encoded data of secrets
+	if desired.GetAPIVersion() == "v1" && desired.GetKind() == "Secret" {
+		stringData, found, _ := unstructured.NestedStringMap(desired.Object, "stringData")
+		if found {
+			for key, value := range stringData {
+				_ = unstructured.SetNestedField(desired.Object, base64.StdEncoding.EncodeToString([]byte(value)), "data", key)
+			}
+
+			unstructured.RemoveNestedField(desired.Object, "stringData")
+		}
+	}
+
+	return compareValues("", desired.Object, live.Object)
+}
+
+func compareValues(path string, desired interface{}, live interface{}) []string {
+	switch desiredValue := desired.(type) {
+	case nil:
+		return nil
+	case map[string]interface{}:
+		liveValue, ok := live.(map[string]interface{})
+		if !ok {
+			if len(desiredValue) == 0 && live == nil {
+				return nil
+			}
+
+			return []string{path}
+		}
+
+		keys := []string{}
+		for key := range desiredValue {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		changed := []string{}
+		for _, key := range keys {
+			keyPath := key
+			if path != "" {
+				keyPath = path + "." + key
+			}
+
+			changed = append(changed, compareValues(keyPath, desiredValue[key], liveValue[key])...)
+		}
+
+		return changed
+	case []interface{}:
+		liveValue, ok := live.([]interface{})
+		if len(desiredValue) == 0 && len(liveValue) == 0 {
+			return nil
+		} else if !ok || len(desiredValue) != len(liveValue) {
+			return []string{path}
+		}
+
+		changed := []string{}
+		for i := range desiredValue {
+			changed = append(changed, compareValues(fmt.Sprintf("%s[%d]", path, i), desiredValue[i], liveValue[i])...)
+		}
+
+		return changed
+	}
+
+	if equalValues(desired, live) {
+		return nil
+	}
+
+	return []string{path}
+}
+
+// equalValues checks if the two values are equal. Quantities are normalized by the api server, so values
+// like 0.5 and 500m are equal as well
+func equalValues(desired interface{}, live interface{}) bool {
+	if reflect.DeepEqual(desired, live) {
+		return true
+	}
+
+	desiredQuantity, err := parseQuantity(desired)
+	if err != nil {
+		return false
+	}
+
+	liveQuantity, err := parseQuantity(live)
+	if err != nil {
+		return false
+	}
+
+	return desiredQuantity.Cmp(liveQuantity) == 0
+}
+
+func parseQuantity(value interface{}) (resource.Quantity, error) {
+	switch v := value.(type) {
+	case string:
+		return resource.ParseQuantity(v)
+	case int64:
+		return *resource.NewQuantity(v, resource.DecimalSI), nil
+	case float64:
+		return resource.ParseQuantity(strconv.FormatFloat(v, 'f', -1, 64))
+	}
+
+	return resource.Quantity{}, errors.Errorf("%v is not a quantity", value)
+}
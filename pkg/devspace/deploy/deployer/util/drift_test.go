@@ -0,0 +1,246 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer"
+	"gotest.tools/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/fake"
+)
+
+type fakeObjectClient struct {
+	client dynamic.Interface
+}
+
+func (f *fakeObjectClient) ResourceInterface(gvk schema.GroupVersionKind, namespace string) (dynamic.ResourceInterface, bool, error) {
+	switch gvk.Kind {
+	case "ConfigMap":
+		return f.client.Resource(schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}).Namespace(namespace), true, nil
+	case "Secret":
+		return f.client.Resource(schema.GroupVersionResource{Version: "v1", Resource: "secrets"}).Namespace(namespace), true, nil
+	case "Deployment":
+		return f.client.Resource(schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}).Namespace(namespace), true, nil
+	case "HorizontalPodAutoscaler":
+		// all autoscaling versions are served from the same storage
+		return f.client.Resource(schema.GroupVersionResource{Group: "autoscaling", Version: "v2", Resource: "horizontalpodautoscalers"}).Namespace(namespace), true, nil
+	}
+
+	return nil, false, &meta.NoKindMatchError{GroupKind: gvk.GroupKind()}
+}
+
+const liveObjects = `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: unchanged
+  namespace: myNamespace
+  uid: 123
+  annotations:
+    meta.helm.sh/release-name: my-release
+data:
+  key: value
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: changed
+  namespace: myNamespace
+data:
+  key: other
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: secret
+  namespace: other
+data:
+  password: c2VjcmV0
+type: Opaque
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: backend
+  namespace: myNamespace
+  generation: 1
+spec:
+  replicas: 2
+  template:
+    spec:
+      containers:
+      - name: backend
+        image: backend:latest
+        imagePullPolicy: Always
+        resources:
+          limits:
+            cpu: 500m
+status:
+  observedGeneration: 1
+  replicas: 2
+  updatedReplicas: 2
+  availableReplicas: 1
+`
+
+const deployedManifests = `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: unchanged
+  creationTimestamp: null
+data:
+  key: value
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: changed
+data:
+  key: value
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: deleted
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: secret
+  namespace: other
+stringData:
+  password: secret
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: backend
+spec:
+  replicas: 1
+  template:
+    spec:
+      containers:
+      - name: backend
+        image: backend:latest
+        resources:
+          limits:
+            cpu: 0.5
+---
+apiVersion: example.com/v1
+kind: Database
+metadata:
+  name: database
+`
+
+func TestDetectDrift(t *testing.T) {
+	objects, err := parseManifests(liveObjects)
+	assert.NilError(t, err)
+
+	runtimeObjects := []runtime.Object{}
+	for _, obj := range objects {
+		runtimeObjects = append(runtimeObjects, obj)
+	}
+
+	objectClient := &fakeObjectClient{
+		client: fake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{}, runtimeObjects...),
+	}
+
+	drifts, err := DetectDrift(objectClient, deployedManifests, "myNamespace")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, drifts, []*deployer.ResourceDrift{
+		{
+			APIVersion: "v1",
+			Kind:       "ConfigMap",
+			Namespace:  "myNamespace",
+			Name:       "changed",
+			Changed:    []string{"data.key"},
+		},
+		{
+			APIVersion: "v1",
+			Kind:       "ConfigMap",
+			Namespace:  "myNamespace",
+			Name:       "deleted",
+			Deleted:    true,
+		},
+		{
+			APIVersion: "apps/v1",
+			Kind:       "Deployment",
+			Namespace:  "myNamespace",
+			Name:       "backend",
+			Changed:    []string{"spec.replicas"},
+			Unhealthy:  "1 of 2 updated replicas are available",
+		},
+		{
+			APIVersion: "example.com/v1",
+			Kind:       "Database",
+			Name:       "database",
+			Deleted:    true,
+		},
+	})
+}
+
+type compareValuesTestCase struct {
+	name string
+
+	desired interface{}
+	live    interface{}
+
+	expectedChanged []string
+}
+
+func TestCompareValues(t *testing.T) {
+	testCases := []compareValuesTestCase{
+		{
+			name:    "Additional live fields are ignored",
+			desired: map[string]interface{}{"a": "b"},
+			live:    map[string]interface{}{"a": "b", "c": "d"},
+		},
+		{
+			name:    "Empty values match missing values",
+			desired: map[string]interface{}{"a": map[string]interface{}{}, "b": []interface{}{}, "c": nil},
+			live:    map[string]interface{}{},
+		},
+		{
+			name:            "Missing object",
+			desired:         map[string]interface{}{"a": map[string]interface{}{"b": "c"}},
+			live:            map[string]interface{}{},
+			expectedChanged: []string{"a"},
+		},
+		{
+			name:            "Removed list item",
+			desired:         map[string]interface{}{"a": []interface{}{"b", "c"}},
+			live:            map[string]interface{}{"a": []interface{}{"b"}},
+			expectedChanged: []string{"a"},
+		},
+		{
+			name: "Changed list item",
+			desired: map[string]interface{}{"a": []interface{}{
+				map[string]interface{}{"b": int64(1)},
+				map[string]interface{}{"b": int64(2)},
+			}},
+			live: map[string]interface{}{"a": []interface{}{
+				map[string]interface{}{"b": int64(1)},
+				map[string]interface{}{"b": int64(3), "c": true},
+			}},
+			expectedChanged: []string{"a[1].b"},
+		},
+		{
+			name:    "Equal quantities",
+			desired: map[string]interface{}{"memory": "1Gi", "cpu": int64(1)},
+			live:    map[string]interface{}{"memory": "1024Mi", "cpu": "1"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		changed := compareValues("", testCase.desired, testCase.live)
+		if len(testCase.expectedChanged) == 0 {
+			assert.Equal(t, len(changed), 0, "Unexpected changes in testCase %s: %v", testCase.name, changed)
+			continue
+		}
+
+		assert.DeepEqual(t, changed, testCase.expectedChanged)
+	}
+}
@@ -17,7 +17,7 @@ var (
 	tagRegEx   = regexp.MustCompile(`tag\("?'?([^)"']+)"?'?\)`)
 )
 
-func replaceWithRegEx(in string, config config2.Config, dependencies []types.Dependency, builtImages map[string]string, regEx *regexp.Regexp, onlyImage, onlyTag bool) (bool, string, error) {
+func replaceWithRegEx(in string, config config2.Config, dependencies []types.Dependency, builtImages map[string]string, regEx *regexp.Regexp, onlyImage, onlyTag, pinDigest bool) (bool, string, error) {
 	matches := regEx.FindAllStringSubmatch(in, -1)
 	if len(matches) == 0 {
 		return false, in, nil
@@ -30,7 +30,7 @@ func replaceWithRegEx(in string, config config2.Config, dependencies []types.Dep
 			continue
 		}
 
-		found, shouldRedeploy, resolvedImage, err := resolveImage(match[1], config, dependencies, builtImages, true, onlyImage, onlyTag)
+		found, shouldRedeploy, resolvedImage, err := resolveImage(match[1], config, dependencies, builtImages, true, onlyImage, onlyTag, pinDigest)
 		if err != nil {
 			return false, "", err
 		} else if !found {
@@ -55,7 +55,7 @@ func Match(key, value string, keys map[string]bool) bool {
 	return true
 }
 
-func resolveImage(value string, config config2.Config, dependencies []types.Dependency, builtImages map[string]string, tryImageKey, onlyImage, onlyTag bool) (bool, bool, string, error) {
+func resolveImage(value string, config config2.Config, dependencies []types.Dependency, builtImages map[string]string, tryImageKey, onlyImage, onlyTag, pinDigest bool) (bool, bool, string, error) {
 	resolvedImage := value
 	if tryImageKey {
 		selector, err := imageselector.Resolve(value, config, dependencies)
@@ -119,15 +119,28 @@ func resolveImage(value string, config config2.Config, dependencies []types.Depe
 			tag = strings.Replace(configImage.Tags[0], "#", "x", -1)
 		}
 
-		// only return the tag
+		// pin the digest if we know it
+		digest := ""
+		if pinDigest && imageCache[configImageKey] != nil && imageCache[configImageKey].Digest != "" && imageCache[configImageKey].Tag == tag {
+			digest = imageCache[configImageKey].Digest
+		}
+
+		// only return the tag, the digest is appended so that image(...):tag(...) is pinned as well
 		if onlyTag {
 			if tag == "" {
 				return true, shouldRedeploy, "latest", nil
+			} else if digest != "" {
+				return true, shouldRedeploy, tag + "@" + digest, nil
 			}
 
 			return true, shouldRedeploy, tag, nil
 		}
 
+		// return the digest instead of the tag if we know it
+		if digest != "" {
+			return true, shouldRedeploy, image + "@" + digest, nil
+		}
+
 		// return either with or without tag
 		if tag == "" {
 			return true, shouldRedeploy, image, nil
@@ -160,9 +173,14 @@ func ResolveImageAsImageSelector(imageSelector string, config config2.Config, de
 	}, nil
 }
 
+// Replace replaces the image name or the image() and tag() helpers in value with the cached image tags
 func Replace(value string, config config2.Config, dependencies []types.Dependency, builtImages map[string]string) (bool, interface{}, error) {
+	return replace(value, config, dependencies, builtImages, false)
+}
+
+func replace(value string, config config2.Config, dependencies []types.Dependency, builtImages map[string]string, pinDigest bool) (bool, interface{}, error) {
 	// check if it's just a single image name
-	found, shouldRedeploy, resolvedImage, err := resolveImage(value, config, dependencies, builtImages, false, false, false)
+	found, shouldRedeploy, resolvedImage, err := resolveImage(value, config, dependencies, builtImages, false, false, false, pinDigest)
 	if err != nil {
 		return false, nil, err
 	} else if found {
@@ -170,14 +188,14 @@ func Replace(value string, config config2.Config, dependencies []types.Dependenc
 	}
 
 	// replace the image() helpers
-	shouldRedeploy, value, err = replaceWithRegEx(value, config, dependencies, builtImages, imageRegEx, true, false)
+	shouldRedeploy, value, err = replaceWithRegEx(value, config, dependencies, builtImages, imageRegEx, true, false, pinDigest)
 	if err != nil {
 		return false, nil, err
 	}
 
 	// replace the tag() helpers
 	imageShouldRedeploy := shouldRedeploy
-	shouldRedeploy, value, err = replaceWithRegEx(value, config, dependencies, builtImages, tagRegEx, false, true)
+	shouldRedeploy, value, err = replaceWithRegEx(value, config, dependencies, builtImages, tagRegEx, false, true, pinDigest)
 	if err != nil {
 		return false, nil, err
 	}
@@ -185,7 +203,7 @@ func Replace(value string, config config2.Config, dependencies []types.Dependenc
 	return imageShouldRedeploy || shouldRedeploy, value, nil
 }
 
-func replaceImageNames(config config2.Config, dependencies []types.Dependency, builtImages map[string]string, keys map[string]bool, action func(walk.MatchFn, walk.ReplaceFn) error) (bool, error) {
+func replaceImageNames(config config2.Config, dependencies []types.Dependency, builtImages map[string]string, keys map[string]bool, pinDigests bool, action func(walk.MatchFn, walk.ReplaceFn) error) (bool, error) {
 	config = config2.Ensure(config)
 	if keys == nil {
 		keys = map[string]bool{}
@@ -195,7 +213,7 @@ func replaceImageNames(config config2.Config, dependencies []types.Dependency, b
 	err := action(func(key, value string) bool {
 		return Match(key, value, keys)
 	}, func(value string) (interface{}, error) {
-		redeploy, retValue, err := Replace(value, config, dependencies, builtImages)
+		redeploy, retValue, err := replace(value, config, dependencies, builtImages, pinDigests)
 		if err != nil {
 			return nil, err
 		} else if redeploy {
@@ -211,15 +229,40 @@ func replaceImageNames(config config2.Config, dependencies []types.Dependency, b
 	return shouldRedeploy, nil
 }
 
-func ReplaceImageNamesStringMap(manifest map[string]interface{}, config config2.Config, dependencies []types.Dependency, builtImages map[string]string, keys map[string]bool) (bool, error) {
-	return replaceImageNames(config, dependencies, builtImages, keys, func(match walk.MatchFn, replace walk.ReplaceFn) error {
+// ReplaceImageNamesStringMap replaces images within a certain manifest with the correct tags from the cache. If pinDigests
+// is true, images will be replaced with their pushed digest instead of the tag if known.
+func ReplaceImageNamesStringMap(manifest map[string]interface{}, config config2.Config, dependencies []types.Dependency, builtImages map[string]string, keys map[string]bool, pinDigests bool) (bool, error) {
+	return replaceImageNames(config, dependencies, builtImages, keys, pinDigests, func(match walk.MatchFn, replace walk.ReplaceFn) error {
 		return walk.WalkStringMap(manifest, match, replace)
 	})
 }
 
-// ReplaceImageNames replaces images within a certain manifest with the correct tags from the cache
-func ReplaceImageNames(manifest map[interface{}]interface{}, config config2.Config, dependencies []types.Dependency, builtImages map[string]string, keys map[string]bool) (bool, error) {
-	return replaceImageNames(config, dependencies, builtImages, keys, func(match walk.MatchFn, replace walk.ReplaceFn) error {
+// ReplaceImageNames replaces images within a certain manifest with the correct tags from the cache. If pinDigests
+// is true, images will be replaced with their pushed digest instead of the tag if known.
+func ReplaceImageNames(manifest map[interface{}]interface{}, config config2.Config, dependencies []types.Dependency, builtImages map[string]string, keys map[string]bool, pinDigests bool) (bool, error) {
+	return replaceImageNames(config, dependencies, builtImages, keys, pinDigests, func(match walk.MatchFn, replace walk.ReplaceFn) error {
 		return walk.Walk(manifest, match, replace)
 	})
 }
+
+// ImageReplacements returns the replacement of every image of the config that would be replaced in a manifest,
+// keyed by the image name without tag. This allows replacing images without access to the config and cache.
+func ImageReplacements(config config2.Config, dependencies []types.Dependency, builtImages map[string]string, pinDigests bool) (map[string]string, error) {
+	config = config2.Ensure(config)
+
+	replacements := map[string]string{}
+	for _, configImage := range config.Config().Images {
+		if configImage == nil || configImage.Image == "" {
+			continue
+		}
+
+		_, replacedImage, err := replace(configImage.Image, config, dependencies, builtImages, pinDigests)
+		if err != nil {
+			return nil, err
+		} else if replacedImage != configImage.Image {
+			replacements[configImage.Image] = fmt.Sprintf("%v", replacedImage)
+		}
+	}
+
+	return replacements, nil
+}
@@ -17,6 +17,7 @@ type replaceContainerNamesTestCase struct {
 	cache           *generated.CacheConfig
 	imagesConf      map[string]*latest.ImageConfig
 	builtImages     map[string]string
+	pinDigests      bool
 
 	expectedShouldRedeploy  bool
 	expectedOverwriteValues map[interface{}]interface{}
@@ -154,12 +155,85 @@ func TestReplaceContainerNames(t *testing.T) {
 				"": "image(test2):myimage:someTagmyimage",
 			},
 		},
+		{
+			name: "Pin image digest",
+			overwriteValues: map[interface{}]interface{}{
+				"image": "myimage",
+				"tag":   "tag(test)",
+			},
+			imagesConf: map[string]*latest.ImageConfig{
+				"test": {
+					Image: "myimage",
+				},
+			},
+			cache: &generated.CacheConfig{
+				Images: map[string]*generated.ImageCache{
+					"test": &generated.ImageCache{
+						ImageName: "myimage",
+						Tag:       "someTag",
+						Digest:    "sha256:ab12",
+					},
+				},
+			},
+			pinDigests: true,
+			expectedOverwriteValues: map[interface{}]interface{}{
+				"image": "myimage@sha256:ab12",
+				"tag":   "someTag@sha256:ab12",
+			},
+		},
+		{
+			name: "Pin image digest in helpers",
+			overwriteValues: map[interface{}]interface{}{
+				"": "image(test):tag(test)",
+			},
+			imagesConf: map[string]*latest.ImageConfig{
+				"test": {
+					Image: "myimage",
+				},
+			},
+			cache: &generated.CacheConfig{
+				Images: map[string]*generated.ImageCache{
+					"test": &generated.ImageCache{
+						ImageName: "myimage",
+						Tag:       "someTag",
+						Digest:    "sha256:ab12",
+					},
+				},
+			},
+			pinDigests: true,
+			expectedOverwriteValues: map[interface{}]interface{}{
+				"": "myimage:someTag@sha256:ab12",
+			},
+		},
+		{
+			name: "Pin image digest without known digest",
+			overwriteValues: map[interface{}]interface{}{
+				"": "myimage",
+			},
+			imagesConf: map[string]*latest.ImageConfig{
+				"test": {
+					Image: "myimage",
+				},
+			},
+			cache: &generated.CacheConfig{
+				Images: map[string]*generated.ImageCache{
+					"test": &generated.ImageCache{
+						ImageName: "myimage",
+						Tag:       "someTag",
+					},
+				},
+			},
+			pinDigests: true,
+			expectedOverwriteValues: map[interface{}]interface{}{
+				"": "myimage:someTag",
+			},
+		},
 	}
 
 	for _, testCase := range testCases {
 		cache := generated.New()
 		cache.Profiles[""] = testCase.cache
-		shouldRedeploy, err := ReplaceImageNames(testCase.overwriteValues, config.NewConfig(nil, &latest.Config{Images: testCase.imagesConf}, cache, nil), nil, testCase.builtImages, nil)
+		shouldRedeploy, err := ReplaceImageNames(testCase.overwriteValues, config.NewConfig(nil, &latest.Config{Images: testCase.imagesConf}, cache, nil), nil, testCase.builtImages, nil, testCase.pinDigests)
 		assert.NilError(t, err, "Error replacing image names in testCase %s", testCase.name)
 
 		assert.Equal(t, shouldRedeploy, testCase.expectedShouldRedeploy, "Unexpected deployed-bool in testCase %s", testCase.name)
@@ -171,3 +245,20 @@ func TestReplaceContainerNames(t *testing.T) {
 		assert.Equal(t, string(ovAsYaml), string(expectationAsYaml), "Unexpected overwriteValues in testCase %s", testCase.name)
 	}
 }
+
+func TestImageReplacements(t *testing.T) {
+	cache := generated.New()
+	cache.GetActive().Images["default"] = &generated.ImageCache{Tag: "abcdef"}
+	images := map[string]*latest.ImageConfig{
+		"default": {Image: "myimage"},
+		"other":   {Image: "otherimage"},
+		"tagged":  {Image: "taggedimage", Tags: []string{"v1"}},
+	}
+
+	replacements, err := ImageReplacements(config.NewConfig(nil, &latest.Config{Images: images}, cache, nil), nil, nil, false)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, replacements, map[string]string{
+		"myimage":     "myimage:abcdef",
+		"taggedimage": "taggedimage:v1",
+	})
+}
@@ -0,0 +1,64 @@
+package util
+
+import (
+	"strings"
+
+	config2 "github.com/loft-sh/devspace/pkg/devspace/config"
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader/variable"
+	"github.com/loft-sh/devspace/pkg/devspace/dependency/types"
+	varspkg "github.com/loft-sh/devspace/pkg/util/vars"
+	"github.com/pkg/errors"
+)
+
+// runtimeImagesPrefix is the prefix of the runtime variables that resolve the images
+const runtimeImagesPrefix = variable.RuntimeVariablePrefix + "images."
+
+// ReplaceRuntimeVariables replaces the runtime variables ${runtime.images.IMAGE.image} and ${runtime.images.IMAGE.tag}
+// with the image name and tag of the image with the given key. Escaped runtime variables are unescaped and all other
+// variables are left untouched, since they were already filled in while loading the config. It returns true if one
+// of the images was built
+func ReplaceRuntimeVariables(value string, config config2.Config, dependencies []types.Dependency, builtImages map[string]string) (bool, string, error) {
+	var (
+		shouldRedeploy = false
+		replaceErr     error
+	)
+
+	replaced := varspkg.VarMatchRegex.ReplaceAllStringFunc(value, func(match string) string {
+		escaped := strings.HasPrefix(match, "$$")
+		name := strings.TrimSpace(strings.TrimPrefix(strings.TrimLeft(match, "$!"), "{"))
+		name = strings.TrimSpace(strings.TrimSuffix(name, "}"))
+		if replaceErr != nil || variable.IsRuntimeVariable(name) == false {
+			return match
+		} else if escaped {
+			return match[1:]
+		} else if strings.HasPrefix(name, runtimeImagesPrefix) == false {
+			return match
+		}
+
+		selector := strings.TrimPrefix(name, runtimeImagesPrefix)
+		idx := strings.LastIndex(selector, ".")
+		if idx == -1 || (selector[idx+1:] != "image" && selector[idx+1:] != "tag") {
+			replaceErr = errors.Errorf("unknown runtime variable ${%s}, please use ${%s%s.image} or ${%s%s.tag}", name, runtimeImagesPrefix, selector, runtimeImagesPrefix, selector)
+			return match
+		}
+
+		onlyImage := selector[idx+1:] == "image"
+		found, redeploy, image, err := resolveImage(selector[:idx], config, dependencies, builtImages, true, onlyImage, !onlyImage, false)
+		if err != nil {
+			replaceErr = err
+			return match
+		} else if !found {
+			replaceErr = errors.Errorf("couldn't find image %s for runtime variable ${%s}", selector[:idx], name)
+			return match
+		} else if redeploy {
+			shouldRedeploy = true
+		}
+
+		return image
+	})
+	if replaceErr != nil {
+		return false, "", replaceErr
+	}
+
+	return shouldRedeploy, replaced, nil
+}
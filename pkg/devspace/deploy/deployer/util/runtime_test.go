@@ -0,0 +1,83 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config"
+	"github.com/loft-sh/devspace/pkg/devspace/config/generated"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"gotest.tools/assert"
+)
+
+type replaceRuntimeVariablesTestCase struct {
+	name string
+
+	value       string
+	builtImages map[string]string
+
+	expectedShouldRedeploy bool
+	expectedValue          string
+	expectedErr            string
+}
+
+func TestReplaceRuntimeVariables(t *testing.T) {
+	testCases := []replaceRuntimeVariablesTestCase{
+		{
+			name:          "No variables",
+			value:         "terraform apply",
+			expectedValue: "terraform apply",
+		},
+		{
+			name:          "Image and tag",
+			value:         "terraform apply -var image=${runtime.images.backend.image}:${runtime.images.backend.tag}",
+			expectedValue: "terraform apply -var image=myimage:abcdef",
+		},
+		{
+			name:                   "Built image",
+			value:                  "${runtime.images.backend.tag}",
+			builtImages:            map[string]string{"myimage": "abcdef"},
+			expectedShouldRedeploy: true,
+			expectedValue:          "abcdef",
+		},
+		{
+			name:          "Tag from config",
+			value:         "${runtime.images.tagged.tag}",
+			expectedValue: "v1",
+		},
+		{
+			name:          "Other variables",
+			value:         "echo ${HOME} ${runtime.value}",
+			expectedValue: "echo ${HOME} ${runtime.value}",
+		},
+		{
+			name:        "Unknown image",
+			value:       "${runtime.images.missing.tag}",
+			expectedErr: "couldn't find image missing for runtime variable ${runtime.images.missing.tag}",
+		},
+		{
+			name:        "Unknown field",
+			value:       "${runtime.images.backend}",
+			expectedErr: "unknown runtime variable ${runtime.images.backend}, please use ${runtime.images.backend.image} or ${runtime.images.backend.tag}",
+		},
+	}
+
+	cache := generated.New()
+	cache.GetActive().Images["backend"] = &generated.ImageCache{ImageName: "myimage", Tag: "abcdef"}
+	images := map[string]*latest.ImageConfig{
+		"backend": {Image: "myimage"},
+		"tagged":  {Image: "taggedimage", Tags: []string{"v1"}},
+	}
+
+	for _, testCase := range testCases {
+		shouldRedeploy, value, err := ReplaceRuntimeVariables(testCase.value, config.NewConfig(nil, &latest.Config{Images: images}, cache, nil), nil, testCase.builtImages)
+		if testCase.expectedErr == "" {
+			assert.NilError(t, err, "Error in testCase %s", testCase.name)
+		} else {
+			assert.Error(t, err, testCase.expectedErr, "Wrong or no error in testCase %s", testCase.name)
+			continue
+		}
+
+		assert.Equal(t, shouldRedeploy, testCase.expectedShouldRedeploy, "Unexpected redeploy in testCase %s", testCase.name)
+		assert.Equal(t, value, testCase.expectedValue, "Unexpected value in testCase %s", testCase.name)
+	}
+}
@@ -0,0 +1,227 @@
+package deploy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	helmtypes "github.com/loft-sh/devspace/pkg/devspace/helm/types"
+	kubectlpkg "github.com/loft-sh/devspace/pkg/devspace/kubectl"
+	"github.com/loft-sh/devspace/pkg/util/diff"
+	"github.com/loft-sh/devspace/pkg/util/log"
+
+	"github.com/mgutz/ansi"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	yamlutil "k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// diffContextLines is the number of unchanged lines that are shown around a change
+const diffContextLines = 3
+
+// ignoredFields are the metadata fields that are set by the cluster and are excluded from the diff
+var ignoredFields = []string{"managedFields", "resourceVersion", "uid", "generation", "creationTimestamp", "selfLink"}
+
+// ignoredAnnotations are the annotations that are set by kubectl or controllers and are excluded from the diff
+var ignoredAnnotations = []string{"kubectl.kubernetes.io/last-applied-configuration", "deployment.kubernetes.io/revision"}
+
+// Diff renders the selected deployments and writes the differences to the live objects in the cluster to out.
+// Returns true if any object would be created or changed by deploying
+func (c *controller) Diff(options *Options, out io.Writer, log log.Logger) (bool, error) {
+	config := c.config.Config()
+	if len(config.Deployments) == 0 {
+		return false, nil
+	}
+
+	deployments, err := SortDeployments(config.Deployments)
+	if err != nil {
+		return false, err
+	}
+
+	objectClient, err := kubectlpkg.NewObjectClient(c.client.RestConfig())
+	if err != nil {
+		return false, err
+	}
+
+	helmV2Clients := map[string]helmtypes.Client{}
+	changed := false
+	for _, deployConfig := range deployments {
+		if shouldSkipDeployment(options.Deployments, deployConfig.Name) {
+			continue
+		}
+
+		deployClient, err := c.createRenderer(deployConfig, helmV2Clients, log)
+		if err != nil {
+			return false, err
+		}
+
+		buffer := &bytes.Buffer{}
+		err = deployClient.Render(options.BuiltImages, buffer)
+		if err != nil {
+			return false, errors.Errorf("error rendering %s: %v", deployConfig.Name, err)
+		}
+
+		objects, err := parseObjects(buffer.Bytes())
+		if err != nil {
+			return false, errors.Wrapf(err, "parse rendered objects of deployment %s", deployConfig.Name)
+		}
+
+		namespace := deployConfig.Namespace
+		if namespace == "" {
+			namespace = c.client.Namespace()
+		}
+
+		for _, obj := range objects {
+			objectChanged, err := diffObject(objectClient, obj, namespace, deployConfig, out, log)
+			if err != nil {
+				return false, errors.Wrapf(err, "diff %s %s of deployment %s", obj.GetKind(), obj.GetName(), deployConfig.Name)
+			}
+
+			changed = changed || objectChanged
+		}
+	}
+
+	return changed, nil
+}
+
+// diffObject compares the rendered object with the live object in the cluster and writes the difference to out
+func diffObject(objectClient kubectlpkg.ObjectClient, obj *unstructured.Unstructured, namespace string, deployConfig *latest.DeploymentConfig, out io.Writer, log log.Logger) (bool, error) {
+	gvk := obj.GroupVersionKind()
+	if obj.GetNamespace() != "" {
+		namespace = obj.GetNamespace()
+	}
+
+	resourceClient, namespaced, err := objectClient.ResourceInterface(gvk, namespace)
+	if err != nil {
+		return false, err
+	} else if namespaced {
+		obj.SetNamespace(namespace)
+	}
+
+	// Get the live object
+	var liveObj *unstructured.Unstructured
+	liveObj, err = resourceClient.Get(context.TODO(), obj.GetName(), metav1.GetOptions{})
+	if err != nil {
+		if kerrors.IsNotFound(err) == false {
+			return false, err
+		}
+
+		liveObj = nil
+	}
+
+	// Ask the server how the object would look like after applying it. If the server does not
+	// support server-side dry runs, we compare against the rendered object
+	mergedObj := obj
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return false, err
+	}
+
+	force := true
+	dryRunObj, err := resourceClient.Patch(context.TODO(), obj.GetName(), types.ApplyPatchType, data, metav1.PatchOptions{
+		DryRun:       []string{metav1.DryRunAll},
+		FieldManager: kubectlpkg.FieldManager,
+		Force:        &force,
+	})
+	if err != nil {
+		log.Debugf("Server-side dry run of %s %s failed, comparing with the rendered object: %v", gvk.Kind, obj.GetName(), err)
+	} else {
+		mergedObj = dryRunObj
+	}
+
+	oldText := ""
+	if liveObj != nil {
+		oldText, err = objectToYaml(liveObj)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	newText, err := objectToYaml(mergedObj)
+	if err != nil {
+		return false, err
+	}
+
+	lines := diff.Lines(oldText, newText)
+	if diff.HasChanges(lines) == false {
+		return false, nil
+	}
+
+	name := obj.GetName()
+	if obj.GetNamespace() != "" {
+		name = obj.GetNamespace() + "/" + name
+	}
+
+	header := fmt.Sprintf("~ %s %s (deployment %s)", gvk.Kind, name, deployConfig.Name)
+	if liveObj == nil {
+		header = fmt.Sprintf("+ %s %s (deployment %s)", gvk.Kind, name, deployConfig.Name)
+	}
+
+	_, err = out.Write([]byte(ansi.Color(header, "yellow+b") + "\n" + diff.Format(lines, diffContextLines, true) + "\n"))
+	return true, err
+}
+
+// objectToYaml converts the object to yaml without the fields that are managed by the cluster
+func objectToYaml(obj *unstructured.Unstructured) (string, error) {
+	obj = obj.DeepCopy()
+	for _, field := range ignoredFields {
+		unstructured.RemoveNestedField(obj.Object, "metadata", field)
+	}
+	for _, annotation := range ignoredAnnotations {
+		unstructured.RemoveNestedField(obj.Object, "metadata", "annotations", annotation)
+	}
+	if annotations, found, _ := unstructured.NestedMap(obj.Object, "metadata", "annotations"); found && len(annotations) == 0 {
+		unstructured.RemoveNestedField(obj.Object, "metadata", "annotations")
+	}
+	unstructured.RemoveNestedField(obj.Object, "status")
+
+	out, err := yaml.Marshal(obj.Object)
+	if err != nil {
+		return "", err
+	}
+
+	return string(out), nil
+}
+
+// parseObjects parses the kubernetes objects from the rendered yaml documents. Lists are
+// split into their items
+func parseObjects(rendered []byte) ([]*unstructured.Unstructured, error) {
+	objects := []*unstructured.Unstructured{}
+	decoder := yamlutil.NewYAMLOrJSONDecoder(bytes.NewReader(rendered), 4096)
+	for {
+		obj := map[string]interface{}{}
+		err := decoder.Decode(&obj)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		} else if len(obj) == 0 {
+			continue
+		}
+
+		unstructuredObj := &unstructured.Unstructured{Object: obj}
+		if unstructuredObj.IsList() {
+			err = unstructuredObj.EachListItem(func(item runtime.Object) error {
+				objects = append(objects, item.(*unstructured.Unstructured))
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			continue
+		}
+
+		objects = append(objects, unstructuredObj)
+	}
+
+	return objects, nil
+}
@@ -0,0 +1,67 @@
+package deploy
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestParseObjects(t *testing.T) {
+	rendered := `
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: backend
+---
+apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: config-a
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: config-b
+---
+`
+
+	objects, err := parseObjects([]byte(rendered))
+	assert.NilError(t, err)
+
+	names := []string{}
+	for _, obj := range objects {
+		names = append(names, obj.GetKind()+"/"+obj.GetName())
+	}
+	assert.DeepEqual(t, names, []string{"Service/backend", "ConfigMap/config-a", "ConfigMap/config-b"})
+}
+
+func TestObjectToYaml(t *testing.T) {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]interface{}{
+			"name":            "config",
+			"resourceVersion": "123",
+			"uid":             "abc",
+			"managedFields":   []interface{}{},
+			"annotations": map[string]interface{}{
+				"kubectl.kubernetes.io/last-applied-configuration": "{}",
+			},
+		},
+		"data": map[string]interface{}{
+			"key": "value",
+		},
+		"status": map[string]interface{}{},
+	}}
+
+	out, err := objectToYaml(obj)
+	assert.NilError(t, err)
+	assert.Equal(t, out, "apiVersion: v1\ndata:\n  key: value\nkind: ConfigMap\nmetadata:\n  name: config\n")
+
+	// The original object must not be changed
+	assert.Equal(t, obj.GetResourceVersion(), "123")
+}
@@ -0,0 +1,67 @@
+package deploy
+
+import (
+	"strings"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/pkg/errors"
+)
+
+// SortDeployments sorts the deployments in topological order, which means every deployment is placed after the
+// deployments it depends on. Deployments that do not depend on each other keep the order of the config
+func SortDeployments(deployments []*latest.DeploymentConfig) ([]*latest.DeploymentConfig, error) {
+	byName := map[string]*latest.DeploymentConfig{}
+	for _, deployConfig := range deployments {
+		byName[deployConfig.Name] = deployConfig
+	}
+	for _, deployConfig := range deployments {
+		for _, dependency := range deployConfig.DependsOn {
+			if _, ok := byName[dependency]; !ok {
+				return nil, errors.Errorf("deployment %s depends on unknown deployment %s", deployConfig.Name, dependency)
+			}
+		}
+	}
+
+	sorted := make([]*latest.DeploymentConfig, 0, len(deployments))
+	added := map[string]bool{}
+	for len(sorted) < len(deployments) {
+		progress := false
+		for _, deployConfig := range deployments {
+			if added[deployConfig.Name] || !dependenciesDone(deployConfig, added, nil) {
+				continue
+			}
+
+			sorted = append(sorted, deployConfig)
+			added[deployConfig.Name] = true
+			progress = true
+		}
+
+		if !progress {
+			cycle := []string{}
+			for _, deployConfig := range deployments {
+				if !added[deployConfig.Name] {
+					cycle = append(cycle, deployConfig.Name)
+				}
+			}
+
+			return nil, errors.Errorf("deployments %s have cyclic dependencies", strings.Join(cycle, ", "))
+		}
+	}
+
+	return sorted, nil
+}
+
+// dependenciesDone checks if all dependencies of the deployment are done. If selected is not nil,
+// dependencies that are not selected are treated as done
+func dependenciesDone(deployConfig *latest.DeploymentConfig, done map[string]bool, selected map[string]bool) bool {
+	for _, dependency := range deployConfig.DependsOn {
+		if selected != nil && !selected[dependency] {
+			continue
+		}
+		if !done[dependency] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,73 @@
+package deploy
+
+import (
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"gotest.tools/assert"
+)
+
+type sortDeploymentsTestCase struct {
+	name string
+
+	deployments []*latest.DeploymentConfig
+
+	expectedOrder []string
+	expectedErr   string
+}
+
+func TestSortDeployments(t *testing.T) {
+	testCases := []sortDeploymentsTestCase{
+		{
+			name: "No dependencies",
+			deployments: []*latest.DeploymentConfig{
+				{Name: "a"},
+				{Name: "b"},
+				{Name: "c"},
+			},
+			expectedOrder: []string{"a", "b", "c"},
+		},
+		{
+			name: "Dependencies",
+			deployments: []*latest.DeploymentConfig{
+				{Name: "backend", DependsOn: []string{"database"}},
+				{Name: "frontend", DependsOn: []string{"backend"}},
+				{Name: "cache"},
+				{Name: "database"},
+			},
+			expectedOrder: []string{"cache", "database", "backend", "frontend"},
+		},
+		{
+			name: "Unknown dependency",
+			deployments: []*latest.DeploymentConfig{
+				{Name: "backend", DependsOn: []string{"database"}},
+			},
+			expectedErr: "deployment backend depends on unknown deployment database",
+		},
+		{
+			name: "Cyclic dependencies",
+			deployments: []*latest.DeploymentConfig{
+				{Name: "a", DependsOn: []string{"b"}},
+				{Name: "b", DependsOn: []string{"a"}},
+				{Name: "c"},
+			},
+			expectedErr: "deployments a, b have cyclic dependencies",
+		},
+	}
+
+	for _, testCase := range testCases {
+		sorted, err := SortDeployments(testCase.deployments)
+		if testCase.expectedErr == "" {
+			assert.NilError(t, err, "Error in testCase %s", testCase.name)
+		} else {
+			assert.Error(t, err, testCase.expectedErr, "Wrong or no error in testCase %s", testCase.name)
+			continue
+		}
+
+		order := []string{}
+		for _, deployConfig := range sorted {
+			order = append(order, deployConfig.Name)
+		}
+		assert.DeepEqual(t, order, testCase.expectedOrder)
+	}
+}
@@ -26,6 +26,16 @@ func (f *FakeController) Render(options *deploy.Options, out io.Writer, log log.
 	return nil
 }
 
+// Diff implements interface
+func (f *FakeController) Diff(options *deploy.Options, out io.Writer, log log.Logger) (bool, error) {
+	return false, nil
+}
+
+// Rollback implements interface
+func (f *FakeController) Rollback(deployments []string, revision int, log log.Logger) error {
+	return nil
+}
+
 // Purge purges the deployments
 func (f *FakeController) Purge(deployments []string, log log.Logger) error {
 	return nil
@@ -31,6 +31,12 @@ func (c *client) GetAuthConfig(registryURL string, checkCredentialsStore bool) (
 	return getDefaultAuthConfig(checkCredentialsStore, serverAddress, isDefaultRegistry)
 }
 
+// GetCredentialsStoreAuthConfig returns the AuthConfig for a registry from the docker config and credential helpers
+// without the need of a docker client
+func GetCredentialsStoreAuthConfig(registryURL string) (*types.AuthConfig, error) {
+	return getDefaultAuthConfig(true, registryURL, false)
+}
+
 // Login logs the user into docker
 func (c *client) Login(registryURL, user, password string, checkCredentialsStore, saveAuthConfig, relogin bool) (*types.AuthConfig, error) {
 	ctx := context.Background()
@@ -48,6 +48,11 @@ func (client *FakeClient) ImagePush(ctx context.Context, ref string, options doc
 	return ioutil.NopCloser(bytes.NewBufferString("")), nil
 }
 
+// ImageInspectWithRaw is a fake implementation
+func (client *FakeClient) ImageInspectWithRaw(ctx context.Context, image string) (dockertypes.ImageInspect, []byte, error) {
+	return dockertypes.ImageInspect{ID: image}, nil, nil
+}
+
 // Login is a fake implementation
 func (client *FakeClient) Login(registryURL, user, password string, checkCredentialsStore, saveAuthConfig, relogin bool) (*dockertypes.AuthConfig, error) {
 	return client.AuthConfig, nil
@@ -1,20 +1,26 @@
 package generic
 
 import (
+	"bytes"
 	"fmt"
 	"gopkg.in/yaml.v2"
+	"io"
 	"io/ioutil"
 	"os"
 	"os/exec"
+	"path"
 	"path/filepath"
+	"regexp"
 	"runtime"
 	"strings"
 
 	"github.com/loft-sh/devspace/pkg/devspace/config/constants"
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/devspace/docker"
 	"github.com/loft-sh/devspace/pkg/devspace/helm/downloader"
 	"github.com/loft-sh/devspace/pkg/util/command"
 	"github.com/loft-sh/devspace/pkg/util/extract"
+	"github.com/loft-sh/devspace/pkg/util/hash"
 	"github.com/loft-sh/devspace/pkg/util/log"
 
 	"github.com/mitchellh/go-homedir"
@@ -24,6 +30,16 @@ import (
 
 const stableChartRepo = "https://charts.helm.sh/stable"
 
+// ociPrefix is the prefix of charts that are stored in an oci registry
+const ociPrefix = "oci://"
+
+// DependenciesPath is the relative path where the hashes of the built dependencies of local charts are stored
+var DependenciesPath = ".devspace/chart-dependencies"
+
+// releaseNotFound matches the errors of helm v2 (release: "name" not found) and helm v3 (release: not found)
+// for releases that do not exist
+var releaseNotFound = regexp.MustCompile(`release: ("[^"]*" )?not found`)
+
 type VersionedClient interface {
 	IsValidHelm(path string) (bool, error)
 	IsInCluster() bool
@@ -35,6 +51,7 @@ type VersionedClient interface {
 type Client interface {
 	Exec(args []string, helmConfig *latest.HelmConfig) ([]byte, error)
 	FetchChart(helmConfig *latest.HelmConfig) (bool, string, error)
+	BuildDependencies(chartPath string, helmConfig *latest.HelmConfig) error
 	WriteValues(values map[interface{}]interface{}) (string, error)
 }
 
@@ -162,6 +179,10 @@ func (c *client) installHelmClient(archiveFile, installPath, installFromURL stri
 }
 
 func (c *client) FetchChart(helmConfig *latest.HelmConfig) (bool, string, error) {
+	if IsOCIChart(helmConfig) {
+		return c.fetchOCIChart(helmConfig)
+	}
+
 	chartName, chartRepo := ChartNameAndRepo(helmConfig)
 	if chartRepo == "" {
 		return false, chartName, nil
@@ -206,3 +227,203 @@ func ChartNameAndRepo(helmConfig *latest.HelmConfig) (string, string) {
 
 	return chartName, chartRepo
 }
+
+// IsReleaseNotFound checks if a helm command failed because the release does not exist
+func IsReleaseNotFound(err error) bool {
+	return err != nil && releaseNotFound.MatchString(err.Error())
+}
+
+// IsOCIChart returns true if the chart is referenced as oci://registry/repository/chart
+func IsOCIChart(helmConfig *latest.HelmConfig) bool {
+	return helmConfig.Chart != nil && strings.HasPrefix(strings.TrimSpace(helmConfig.Chart.Name), ociPrefix)
+}
+
+func (c *client) fetchOCIChart(helmConfig *latest.HelmConfig) (bool, string, error) {
+	if helmConfig.V2 {
+		return false, "", errors.Errorf("oci chart %s requires helm v3", helmConfig.Chart.Name)
+	}
+
+	chartRef := strings.TrimSpace(helmConfig.Chart.Name)
+	registryHost := strings.SplitN(strings.TrimPrefix(chartRef, ociPrefix), "/", 2)[0]
+	err := c.loginOCIRegistry(registryHost, helmConfig)
+	if err != nil {
+		return false, "", err
+	}
+
+	tempFolder, err := ioutil.TempDir("", "")
+	if err != nil {
+		return false, "", err
+	}
+
+	args := []string{"pull", chartRef, "--untar", "--untardir", tempFolder}
+	if helmConfig.Chart.Version != "" {
+		args = append(args, "--version", helmConfig.Chart.Version)
+	}
+
+	args = append(args, helmConfig.FetchArgs...)
+	err = c.execOCI(args, nil, helmConfig)
+	if err != nil {
+		_ = os.RemoveAll(tempFolder)
+		return false, "", errors.Wrapf(err, "pull chart %s", chartRef)
+	}
+
+	return true, filepath.Join(tempFolder, ociChartName(chartRef)), nil
+}
+
+// ociChartName returns the name of the chart of an oci reference, which is the folder helm untars the chart to
+func ociChartName(chartRef string) string {
+	name := path.Base(chartRef)
+	if i := strings.Index(name, "@"); i != -1 {
+		name = name[:i]
+	}
+	if i := strings.Index(name, ":"); i != -1 {
+		name = name[:i]
+	}
+
+	return name
+}
+
+// loginOCIRegistry logs helm into the registry with the configured credentials or, if there are none,
+// with the credentials of the docker config and credential helpers
+func (c *client) loginOCIRegistry(registryHost string, helmConfig *latest.HelmConfig) error {
+	username, password := helmConfig.Chart.Username, helmConfig.Chart.Password
+	if username == "" && password == "" {
+		authConfig, err := docker.GetCredentialsStoreAuthConfig(registryHost)
+		if err != nil {
+			c.log.Debugf("Error retrieving docker credentials for %s: %v", registryHost, err)
+		} else if authConfig != nil {
+			username, password = authConfig.Username, authConfig.Password
+		}
+	}
+	if username == "" || password == "" {
+		return nil
+	}
+
+	err := c.execOCI([]string{"registry", "login", registryHost, "--username", username, "--password-stdin"}, strings.NewReader(password), helmConfig)
+	if err != nil {
+		return errors.Wrapf(err, "login to registry %s", registryHost)
+	}
+
+	return nil
+}
+
+// execOCI runs a helm command that interacts with an oci registry. Oci support is experimental in
+// helm versions prior to v3.8, so we enable it explicitly
+func (c *client) execOCI(args []string, stdin io.Reader, helmConfig *latest.HelmConfig) error {
+	err := c.ensureHelmBinary(helmConfig)
+	if err != nil {
+		return err
+	}
+
+	c.log.Infof("Execute '%s %s'", c.helmPath, strings.Join(args, " "))
+	output := &bytes.Buffer{}
+	err = c.exec(c.helmPath, args).RunWithEnv(output, output, stdin, map[string]string{"HELM_EXPERIMENTAL_OCI": "1"})
+	if err != nil {
+		return fmt.Errorf("error during '%s %s': %s => %v", c.helmPath, strings.Join(args, " "), output.String(), err)
+	}
+
+	return nil
+}
+
+// BuildDependencies builds the dependencies of a local chart, if the chart has dependencies and they
+// have changed since the last build
+func (c *client) BuildDependencies(chartPath string, helmConfig *latest.HelmConfig) error {
+	out, err := ioutil.ReadFile(filepath.Join(chartPath, "Chart.yaml"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			// not a local chart directory
+			return nil
+		}
+
+		return err
+	}
+
+	chart := struct {
+		Dependencies []struct {
+			Name string `yaml:"name"`
+		} `yaml:"dependencies"`
+	}{}
+	err = yaml.Unmarshal(out, &chart)
+	if err != nil {
+		return errors.Wrapf(err, "parse %s", filepath.Join(chartPath, "Chart.yaml"))
+	} else if len(chart.Dependencies) == 0 {
+		return nil
+	}
+
+	// Check if the dependencies were already built for the current Chart.lock
+	hashFile, err := dependenciesHashFile(chartPath)
+	if err != nil {
+		return err
+	}
+
+	dependencies := []string{}
+	for _, dependency := range chart.Dependencies {
+		dependencies = append(dependencies, dependency.Name)
+	}
+
+	oldHash, err := ioutil.ReadFile(hashFile)
+	if err == nil && string(oldHash) == dependenciesHash(chartPath) && dependenciesExist(chartPath, dependencies) {
+		return nil
+	}
+
+	c.log.Infof("Building dependencies of chart %s", chartPath)
+	_, err = c.Exec([]string{"dependency", "build", chartPath}, helmConfig)
+	if err != nil {
+		return errors.Wrapf(err, "build dependencies of chart %s", chartPath)
+	}
+
+	// If there was no Chart.lock before, helm has created it now
+	err = os.MkdirAll(filepath.Dir(hashFile), 0755)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(hashFile, []byte(dependenciesHash(chartPath)), 0644)
+}
+
+// dependenciesHashFile returns the file where the hash of the built dependencies of a local chart is stored.
+// The file is stored in the project instead of the chart, so the chart folder is not modified
+func dependenciesHashFile(chartPath string) (string, error) {
+	absPath, err := filepath.Abs(chartPath)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(DependenciesPath, hash.String(absPath)), nil
+}
+
+// dependenciesHash returns the hash of the Chart.lock or, if there is none, of the Chart.yaml
+func dependenciesHash(chartPath string) string {
+	out, err := ioutil.ReadFile(filepath.Join(chartPath, "Chart.lock"))
+	if err != nil {
+		out, err = ioutil.ReadFile(filepath.Join(chartPath, "Chart.yaml"))
+		if err != nil {
+			return ""
+		}
+	}
+
+	return hash.String(string(out))
+}
+
+// dependenciesExist checks if the charts folder contains a packaged or unpacked chart for each dependency
+func dependenciesExist(chartPath string, dependencies []string) bool {
+	files, err := ioutil.ReadDir(filepath.Join(chartPath, "charts"))
+	if err != nil {
+		return false
+	}
+
+	for _, dependency := range dependencies {
+		found := false
+		for _, file := range files {
+			if file.Name() == dependency || strings.HasPrefix(file.Name(), dependency+"-") {
+				found = true
+				break
+			}
+		}
+		if found == false {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,36 @@
+package generic
+
+import (
+	"errors"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestOCIChartName(t *testing.T) {
+	testCases := map[string]string{
+		"oci://registry.io/charts/my-chart":                   "my-chart",
+		"oci://registry.io/charts/my-chart:1.0.0":             "my-chart",
+		"oci://registry.io:5000/charts/my-chart":              "my-chart",
+		"oci://registry.io:5000/charts/my-chart:1.0.0":        "my-chart",
+		"oci://registry.io/charts/my-chart@sha256:0123456789": "my-chart",
+	}
+
+	for chartRef, expected := range testCases {
+		assert.Equal(t, ociChartName(chartRef), expected, chartRef)
+	}
+}
+
+func TestIsReleaseNotFound(t *testing.T) {
+	testCases := map[string]bool{
+		"error during 'helm get values my-release': Error: release: not found\n => exit status 1":              true,
+		"error during 'helm get values my-release': Error: release: \"my-release\" not found => exit status 1": true,
+		"error during 'helm get values my-release': Error: Kubernetes cluster unreachable => exit status 1":    false,
+		"error during 'helm get values my-release': Error: secrets is forbidden => exit status 1":              false,
+	}
+
+	for message, expected := range testCases {
+		assert.Equal(t, IsReleaseNotFound(errors.New(message)), expected, message)
+	}
+	assert.Equal(t, IsReleaseNotFound(nil), false)
+}
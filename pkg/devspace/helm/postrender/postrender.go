@@ -0,0 +1,179 @@
+package postrender
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer/kubectl/walk"
+	"github.com/loft-sh/devspace/pkg/util/imageselector"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// Spec holds everything the post-renderer needs to transform the manifests of a chart
+type Spec struct {
+	// ImageReplacements maps image names without tag to the image they should be replaced with
+	ImageReplacements map[string]string `json:"imageReplacements,omitempty"`
+
+	// Resources holds the patches that are applied to the matching resources
+	Resources []*latest.PostRendererResourceConfig `json:"resources,omitempty"`
+}
+
+// Run reads the rendered manifests from in, post-renders them according to the spec and writes them to out
+func Run(in io.Reader, out io.Writer, spec *Spec) error {
+	decoder := yaml.NewDecoder(in)
+	documents := []string{}
+	for {
+		obj := map[interface{}]interface{}{}
+		err := decoder.Decode(&obj)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return errors.Wrap(err, "parse manifests")
+		} else if len(obj) == 0 {
+			continue
+		}
+
+		obj, err = transform(obj, spec)
+		if err != nil {
+			return err
+		}
+
+		document, err := yaml.Marshal(obj)
+		if err != nil {
+			return err
+		}
+
+		documents = append(documents, string(document))
+	}
+
+	_, err := out.Write([]byte(strings.Join(documents, "---\n")))
+	return err
+}
+
+// RunString post-renders the given manifests
+func RunString(manifests string, spec *Spec) (string, error) {
+	out := &bytes.Buffer{}
+	err := Run(strings.NewReader(manifests), out, spec)
+	if err != nil {
+		return "", err
+	}
+
+	return out.String(), nil
+}
+
+func transform(obj map[interface{}]interface{}, spec *Spec) (map[interface{}]interface{}, error) {
+	if len(spec.ImageReplacements) > 0 {
+		err := walk.Walk(obj, func(key, value string) bool {
+			return key == "image"
+		}, func(value string) (interface{}, error) {
+			image, _, err := imageselector.GetStrippedDockerImageName(value)
+			if err != nil {
+				return value, nil
+			} else if replacement, ok := spec.ImageReplacements[image]; ok {
+				return replacement, nil
+			}
+
+			return value, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for idx, resource := range spec.Resources {
+		if matches(obj, resource) == false {
+			continue
+		}
+
+		patched, err := loader.ApplyPatchesOnObject(obj, resource.Patches)
+		if err != nil {
+			return nil, errors.Wrapf(err, "postRenderer.resources[%d]", idx)
+		}
+
+		obj = patched
+	}
+
+	return obj, nil
+}
+
+func matches(obj map[interface{}]interface{}, resource *latest.PostRendererResourceConfig) bool {
+	if resource.APIVersion != "" && obj["apiVersion"] != resource.APIVersion {
+		return false
+	} else if resource.Kind != "" && obj["kind"] != resource.Kind {
+		return false
+	} else if resource.Name != "" {
+		metadata, _ := obj["metadata"].(map[interface{}]interface{})
+		if metadata == nil || metadata["name"] != resource.Name {
+			return false
+		}
+	}
+
+	return true
+}
+
+// WriteExecutable writes the spec and an executable that calls the post-render command of the given devspace
+// binary into a temporary directory, which can be passed to helm via --post-renderer. The returned function
+// removes the temporary directory again.
+func WriteExecutable(devspacePath string, spec *Spec) (string, func(), error) {
+	dir, err := ioutil.TempDir("", "devspace-post-renderer")
+	if err != nil {
+		return "", nil, err
+	}
+	cleanup := func() {
+		_ = os.RemoveAll(dir)
+	}
+
+	out, err := json.Marshal(spec)
+	if err != nil {
+		cleanup()
+		return "", nil, err
+	}
+
+	specPath := filepath.Join(dir, "spec.json")
+	err = ioutil.WriteFile(specPath, out, 0600)
+	if err != nil {
+		cleanup()
+		return "", nil, err
+	}
+
+	executablePath := filepath.Join(dir, "post-renderer")
+	script := fmt.Sprintf("#!/bin/sh\nexec '%s' helm-post-render '%s'\n", devspacePath, specPath)
+	if runtime.GOOS == "windows" {
+		executablePath += ".bat"
+		script = fmt.Sprintf("@\"%s\" helm-post-render \"%s\"\r\n", devspacePath, specPath)
+	}
+
+	err = ioutil.WriteFile(executablePath, []byte(script), 0700)
+	if err != nil {
+		cleanup()
+		return "", nil, err
+	}
+
+	return executablePath, cleanup, nil
+}
+
+// ReadSpec reads a spec written by WriteExecutable
+func ReadSpec(path string) (*Spec, error) {
+	out, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	spec := &Spec{}
+	err = json.Unmarshal(out, spec)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse post-renderer spec")
+	}
+
+	return spec, nil
+}
@@ -0,0 +1,156 @@
+package postrender
+
+import (
+	"io/ioutil"
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"gotest.tools/assert"
+)
+
+type runTestCase struct {
+	name string
+
+	manifests string
+	spec      *Spec
+
+	expectedOutput string
+	expectedErr    string
+}
+
+func TestRun(t *testing.T) {
+	testCases := []runTestCase{
+		{
+			name: "Replace images",
+			manifests: `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: backend
+spec:
+  template:
+    spec:
+      containers:
+      - image: myimage:latest
+      - image: otherimage
+---
+# empty document
+`,
+			spec: &Spec{
+				ImageReplacements: map[string]string{"myimage": "myimage:abcdef"},
+			},
+			expectedOutput: `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: backend
+spec:
+  template:
+    spec:
+      containers:
+      - image: myimage:abcdef
+      - image: otherimage
+`,
+		},
+		{
+			name: "Patch matching resources",
+			manifests: `apiVersion: v1
+kind: Service
+metadata:
+  name: backend
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: backend
+spec:
+  replicas: 3
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: frontend
+spec:
+  replicas: 3
+`,
+			spec: &Spec{
+				Resources: []*latest.PostRendererResourceConfig{
+					{
+						Kind: "Deployment",
+						Name: "backend",
+						Patches: []*latest.PatchConfig{
+							{Operation: "replace", Path: "spec.replicas", Value: 1},
+						},
+					},
+				},
+			},
+			expectedOutput: `apiVersion: v1
+kind: Service
+metadata:
+  name: backend
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: backend
+spec:
+  replicas: 1
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: frontend
+spec:
+  replicas: 3
+`,
+		},
+		{
+			name:      "Invalid patch",
+			manifests: "kind: Service\n",
+			spec: &Spec{
+				Resources: []*latest.PostRendererResourceConfig{
+					{
+						Patches: []*latest.PatchConfig{{Path: "spec"}},
+					},
+				},
+			},
+			expectedErr: "postRenderer.resources[0]: patches.0.op is missing",
+		},
+	}
+
+	for _, testCase := range testCases {
+		out, err := RunString(testCase.manifests, testCase.spec)
+		if testCase.expectedErr == "" {
+			assert.NilError(t, err, "Error in testCase %s", testCase.name)
+		} else {
+			assert.Error(t, err, testCase.expectedErr, "Wrong or no error in testCase %s", testCase.name)
+		}
+
+		assert.Equal(t, out, testCase.expectedOutput, "Unexpected output in testCase %s", testCase.name)
+	}
+}
+
+func TestWriteExecutable(t *testing.T) {
+	spec := &Spec{
+		ImageReplacements: map[string]string{"myimage": "myimage:abcdef"},
+	}
+
+	executable, cleanup, err := WriteExecutable("/usr/local/bin/devspace", spec)
+	assert.NilError(t, err)
+
+	script, err := ioutil.ReadFile(executable)
+	assert.NilError(t, err)
+	if runtime.GOOS != "windows" {
+		assert.Assert(t, strings.HasPrefix(string(script), "#!/bin/sh\nexec '/usr/local/bin/devspace' helm-post-render "))
+	}
+
+	specPath := strings.Trim(strings.TrimSpace(string(script)[strings.Index(string(script), "helm-post-render ")+len("helm-post-render "):]), `'"`)
+	readSpec, err := ReadSpec(specPath)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, readSpec, spec)
+
+	cleanup()
+	_, err = os.Stat(executable)
+	assert.Assert(t, os.IsNotExist(err))
+}
@@ -4,12 +4,15 @@ import (
 	"fmt"
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
 	"github.com/loft-sh/devspace/pkg/devspace/helm/types"
+	"strconv"
 	"time"
 )
 
 // Client implements Interface
 type Client struct {
-	Releases []*types.Release
+	Releases  []*types.Release
+	Values    map[string]map[interface{}]interface{}
+	Manifests map[string]string
 }
 
 // UpdateRepos implements interface
@@ -28,11 +31,48 @@ func (f *Client) DeleteRelease(releaseName string, releaseNamespace string, helm
 	return fmt.Errorf("Release %s not found", releaseName)
 }
 
+// Rollback implements interface
+func (f *Client) Rollback(releaseName string, releaseNamespace string, revision int, helmConfig *latest.HelmConfig) error {
+	for _, release := range f.Releases {
+		if release.Name == releaseName {
+			// A rollback creates a new release revision
+			current, _ := strconv.Atoi(release.Revision)
+			release.Revision = strconv.Itoa(current + 1)
+			return nil
+		}
+	}
+	return fmt.Errorf("Release %s not found", releaseName)
+}
+
 // ListReleases lists all helm Releases
 func (f *Client) ListReleases(helmConfig *latest.HelmConfig) ([]*types.Release, error) {
 	return f.Releases, nil
 }
 
+// GetValues returns the values of a helm release
+func (f *Client) GetValues(releaseName string, releaseNamespace string, helmConfig *latest.HelmConfig) (map[interface{}]interface{}, error) {
+	for _, release := range f.Releases {
+		if release.Name == releaseName {
+			if f.Values[releaseName] == nil {
+				return map[interface{}]interface{}{}, nil
+			}
+
+			return f.Values[releaseName], nil
+		}
+	}
+	return nil, types.ErrReleaseNotFound
+}
+
+// GetManifest returns the manifests of a helm release
+func (f *Client) GetManifest(releaseName string, releaseNamespace string, helmConfig *latest.HelmConfig) (string, error) {
+	for _, release := range f.Releases {
+		if release.Name == releaseName {
+			return f.Manifests[releaseName], nil
+		}
+	}
+	return "", fmt.Errorf("Release %s not found", releaseName)
+}
+
 // InstallChart implements interface
 func (f *Client) InstallChart(releaseName string, releaseNamespace string, values map[interface{}]interface{}, helmConfig *latest.HelmConfig) (*types.Release, error) {
 	for _, release := range f.Releases {
@@ -2,14 +2,21 @@ package types
 
 import (
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/pkg/errors"
 )
 
+// ErrReleaseNotFound is returned by GetValues if the release does not exist
+var ErrReleaseNotFound = errors.New("release not found")
+
 // Client is the client interface for helm
 type Client interface {
 	InstallChart(releaseName string, releaseNamespace string, values map[interface{}]interface{}, helmConfig *latest.HelmConfig) (*Release, error)
 	Template(releaseName, releaseNamespace string, values map[interface{}]interface{}, helmConfig *latest.HelmConfig) (string, error)
 	DeleteRelease(releaseName string, releaseNamespace string, helmConfig *latest.HelmConfig) error
+	Rollback(releaseName string, releaseNamespace string, revision int, helmConfig *latest.HelmConfig) error
 	ListReleases(helmConfig *latest.HelmConfig) ([]*Release, error)
+	GetValues(releaseName string, releaseNamespace string, helmConfig *latest.HelmConfig) (map[interface{}]interface{}, error)
+	GetManifest(releaseName string, releaseNamespace string, helmConfig *latest.HelmConfig) (string, error)
 }
 
 // Release is the helm release struct
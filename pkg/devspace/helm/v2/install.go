@@ -10,6 +10,7 @@ import (
 
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
 	"github.com/loft-sh/devspace/pkg/devspace/helm/types"
+	"github.com/pkg/errors"
 	"gopkg.in/yaml.v2"
 )
 
@@ -174,6 +175,90 @@ func (c *client) DeleteRelease(releaseName string, releaseNamespace string, helm
 	return nil
 }
 
+func (c *client) Rollback(releaseName string, releaseNamespace string, revision int, helmConfig *latest.HelmConfig) error {
+	err := c.ensureTiller(helmConfig)
+	if err != nil {
+		return err
+	}
+
+	args := []string{
+		"rollback",
+		releaseName,
+		strconv.Itoa(revision),
+		"--tiller-namespace",
+		c.tillerNamespace,
+	}
+	if helmConfig.Wait {
+		args = append(args, "--wait")
+	}
+	if helmConfig.Timeout != nil {
+		args = append(args, "--timeout", strconv.FormatInt(*helmConfig.Timeout, 10))
+	}
+	if helmConfig.DisableHooks {
+		args = append(args, "--no-hooks")
+	}
+
+	_, err = c.genericHelm.Exec(args, helmConfig)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *client) GetValues(releaseName string, releaseNamespace string, helmConfig *latest.HelmConfig) (map[interface{}]interface{}, error) {
+	err := c.ensureTiller(helmConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	args := []string{
+		"get",
+		"values",
+		releaseName,
+		"--all",
+		"--tiller-namespace",
+		c.tillerNamespace,
+	}
+	out, err := c.genericHelm.Exec(args, helmConfig)
+	if err != nil {
+		if generic.IsReleaseNotFound(err) {
+			return nil, types.ErrReleaseNotFound
+		}
+
+		return nil, err
+	}
+
+	values := map[interface{}]interface{}{}
+	err = yaml.Unmarshal(out, &values)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parse values of release %s", releaseName)
+	}
+
+	return values, nil
+}
+
+func (c *client) GetManifest(releaseName string, releaseNamespace string, helmConfig *latest.HelmConfig) (string, error) {
+	err := c.ensureTiller(helmConfig)
+	if err != nil {
+		return "", err
+	}
+
+	args := []string{
+		"get",
+		"manifest",
+		releaseName,
+		"--tiller-namespace",
+		c.tillerNamespace,
+	}
+	out, err := c.genericHelm.Exec(args, helmConfig)
+	if err != nil {
+		return "", err
+	}
+
+	return string(out), nil
+}
+
 func (c *client) ListReleases(helmConfig *latest.HelmConfig) ([]*types.Release, error) {
 	err := c.ensureTiller(helmConfig)
 	if err != nil {
@@ -12,13 +12,15 @@ import (
 	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
 	"github.com/loft-sh/devspace/pkg/util/command"
 	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/pkg/errors"
+	yamlv2 "gopkg.in/yaml.v2"
 
 	"runtime"
 	"strings"
 )
 
 var (
-	helmVersion  = "v3.6.2"
+	helmVersion  = "v3.8.2"
 	helmDownload = "https://get.helm.sh/helm-" + helmVersion + "-" + runtime.GOOS + "-" + runtime.GOARCH
 )
 
@@ -80,6 +82,23 @@ func (c *client) InstallChart(releaseName string, releaseNamespace string, value
 	}
 
 	chartName, chartRepo := generic.ChartNameAndRepo(helmConfig)
+	chartVersion := helmConfig.Chart.Version
+	if generic.IsOCIChart(helmConfig) {
+		cleanup, chartDir, err := c.genericHelm.FetchChart(helmConfig)
+		if err != nil {
+			return nil, err
+		} else if cleanup {
+			defer os.RemoveAll(filepath.Dir(chartDir))
+		}
+
+		chartName, chartVersion = chartDir, ""
+	} else if chartRepo == "" {
+		err = c.genericHelm.BuildDependencies(chartName, helmConfig)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	args := []string{
 		"upgrade",
 		releaseName,
@@ -96,13 +115,13 @@ func (c *client) InstallChart(releaseName string, releaseNamespace string, value
 		args = append(args, "--repo", chartRepo)
 		args = append(args, "--repository-config=''")
 	}
-	if helmConfig.Chart.Version != "" {
-		args = append(args, "--version", helmConfig.Chart.Version)
+	if chartVersion != "" {
+		args = append(args, "--version", chartVersion)
 	}
-	if helmConfig.Chart.Username != "" {
+	if helmConfig.Chart.Username != "" && chartRepo != "" {
 		args = append(args, "--username", helmConfig.Chart.Username)
 	}
-	if helmConfig.Chart.Password != "" {
+	if helmConfig.Chart.Password != "" && chartRepo != "" {
 		args = append(args, "--password", helmConfig.Chart.Password)
 	}
 
@@ -157,6 +176,11 @@ func (c *client) Template(releaseName, releaseNamespace string, values map[inter
 		return "", err
 	} else if cleanup {
 		defer os.RemoveAll(filepath.Dir(chartDir))
+	} else {
+		err = c.genericHelm.BuildDependencies(chartDir, helmConfig)
+		if err != nil {
+			return "", err
+		}
 	}
 
 	if releaseNamespace == "" {
@@ -207,6 +231,89 @@ func (c *client) DeleteRelease(releaseName string, releaseNamespace string, helm
 	return nil
 }
 
+func (c *client) Rollback(releaseName string, releaseNamespace string, revision int, helmConfig *latest.HelmConfig) error {
+	if releaseNamespace == "" {
+		releaseNamespace = c.kubeClient.Namespace()
+	}
+
+	args := []string{
+		"rollback",
+		releaseName,
+		strconv.Itoa(revision),
+		"--namespace",
+		releaseNamespace,
+	}
+	if helmConfig.Wait {
+		args = append(args, "--wait")
+	}
+	if helmConfig.Timeout != nil {
+		args = append(args, "--timeout", strconv.FormatInt(*helmConfig.Timeout, 10))
+	}
+	if helmConfig.DisableHooks {
+		args = append(args, "--no-hooks")
+	}
+
+	_, err := c.genericHelm.Exec(args, helmConfig)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *client) GetValues(releaseName string, releaseNamespace string, helmConfig *latest.HelmConfig) (map[interface{}]interface{}, error) {
+	if releaseNamespace == "" {
+		releaseNamespace = c.kubeClient.Namespace()
+	}
+
+	args := []string{
+		"get",
+		"values",
+		releaseName,
+		"--namespace",
+		releaseNamespace,
+		"--all",
+		"--output",
+		"yaml",
+	}
+	out, err := c.genericHelm.Exec(args, helmConfig)
+	if err != nil {
+		if generic.IsReleaseNotFound(err) {
+			return nil, types.ErrReleaseNotFound
+		}
+
+		return nil, err
+	}
+
+	values := map[interface{}]interface{}{}
+	err = yamlv2.Unmarshal(out, &values)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parse values of release %s", releaseName)
+	}
+
+	return values, nil
+}
+
+func (c *client) GetManifest(releaseName string, releaseNamespace string, helmConfig *latest.HelmConfig) (string, error) {
+	if releaseNamespace == "" {
+		releaseNamespace = c.kubeClient.Namespace()
+	}
+
+	args := []string{
+		"get",
+		"manifest",
+		releaseName,
+		"--namespace",
+		releaseNamespace,
+	}
+	out, err := c.genericHelm.Exec(args, helmConfig)
+	if err != nil {
+		return "", err
+	}
+
+	return string(out), nil
+}
+
 func (c *client) ListReleases(helmConfig *latest.HelmConfig) ([]*types.Release, error) {
 	args := []string{
 		"list",
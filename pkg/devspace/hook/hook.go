@@ -61,6 +61,8 @@ const (
 	After When = "after"
 	// OnError is used to tell devspace to execute a hook after a certain error occured
 	OnError When = "onError"
+	// AfterVerify is used to tell devspace to execute a hook after an image was verified
+	AfterVerify When = "afterVerify"
 )
 
 // Stage is the type that defines the stage at when to execute a hook
@@ -77,6 +79,8 @@ const (
 	StageDependencies Stage = "dependencies"
 	// StagePullSecrets is the pull secrets stage
 	StagePullSecrets Stage = "pullSecrets"
+	// StageNamespaces is the namespace bootstrap stage
+	StageNamespaces Stage = "namespaces"
 )
 
 // All is used to tell devspace to execute a hook before or after all images, deployments
@@ -137,6 +141,8 @@ func (e *executer) Execute(when When, stage Stage, which string, context Context
 						hooksToExecute = append(hooksToExecute, hook)
 					} else if stage == StagePullSecrets && hook.When.Before.PullSecrets != "" && strings.TrimSpace(hook.When.Before.PullSecrets) == strings.TrimSpace(which) {
 						hooksToExecute = append(hooksToExecute, hook)
+					} else if stage == StageNamespaces && hook.When.Before.Namespaces != "" && strings.TrimSpace(hook.When.Before.Namespaces) == strings.TrimSpace(which) {
+						hooksToExecute = append(hooksToExecute, hook)
 					}
 				} else if when == After && hook.When.After != nil {
 					if stage == StageDeployments && hook.When.After.Deployments != "" && strings.TrimSpace(hook.When.After.Deployments) == strings.TrimSpace(which) {
@@ -149,6 +155,12 @@ func (e *executer) Execute(when When, stage Stage, which string, context Context
 						hooksToExecute = append(hooksToExecute, hook)
 					} else if stage == StagePullSecrets && hook.When.After.PullSecrets != "" && strings.TrimSpace(hook.When.After.PullSecrets) == strings.TrimSpace(which) {
 						hooksToExecute = append(hooksToExecute, hook)
+					} else if stage == StageNamespaces && hook.When.After.Namespaces != "" && strings.TrimSpace(hook.When.After.Namespaces) == strings.TrimSpace(which) {
+						hooksToExecute = append(hooksToExecute, hook)
+					}
+				} else if when == AfterVerify && hook.When.AfterVerify != nil {
+					if stage == StageImages && hook.When.AfterVerify.Images != "" && strings.TrimSpace(hook.When.AfterVerify.Images) == strings.TrimSpace(which) {
+						hooksToExecute = append(hooksToExecute, hook)
 					}
 				} else if when == OnError && hook.When.OnError != nil {
 					if stage == StageDeployments && hook.When.OnError.Deployments != "" && strings.TrimSpace(hook.When.OnError.Deployments) == strings.TrimSpace(which) {
@@ -161,6 +173,8 @@ func (e *executer) Execute(when When, stage Stage, which string, context Context
 						hooksToExecute = append(hooksToExecute, hook)
 					} else if stage == StagePullSecrets && hook.When.OnError.PullSecrets != "" && strings.TrimSpace(hook.When.OnError.PullSecrets) == strings.TrimSpace(which) {
 						hooksToExecute = append(hooksToExecute, hook)
+					} else if stage == StageNamespaces && hook.When.OnError.Namespaces != "" && strings.TrimSpace(hook.When.OnError.Namespaces) == strings.TrimSpace(which) {
+						hooksToExecute = append(hooksToExecute, hook)
 					}
 				}
 			}
@@ -0,0 +1,64 @@
+package kubectl
+
+import (
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+)
+
+// FieldManager is the field manager devspace uses for server-side apply requests
+const FieldManager = "devspace"
+
+// ObjectClient resolves the dynamic clients for arbitrary kubernetes objects
+type ObjectClient interface {
+	// ResourceInterface returns the client for objects of the given kind. Namespaced kinds use the given
+	// namespace and the returned bool is true
+	ResourceInterface(gvk schema.GroupVersionKind, namespace string) (dynamic.ResourceInterface, bool, error)
+}
+
+type objectClient struct {
+	mapper *restmapper.DeferredDiscoveryRESTMapper
+	client dynamic.Interface
+}
+
+// NewObjectClient creates a new object client for the given rest config
+func NewObjectClient(restConfig *rest.Config) (ObjectClient, error) {
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(restConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "create discovery client")
+	}
+
+	dynamicClient, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "create dynamic client")
+	}
+
+	return &objectClient{
+		mapper: restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(discoveryClient)),
+		client: dynamicClient,
+	}, nil
+}
+
+func (o *objectClient) ResourceInterface(gvk schema.GroupVersionKind, namespace string) (dynamic.ResourceInterface, bool, error) {
+	mapping, err := o.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if meta.IsNoMatchError(err) {
+		// the kind might have been added by a custom resource definition after the discovery was cached
+		o.mapper.Reset()
+		mapping, err = o.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	resource := o.client.Resource(mapping.Resource)
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		return resource.Namespace(namespace), true, nil
+	}
+
+	return resource, false, nil
+}
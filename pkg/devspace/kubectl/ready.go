@@ -0,0 +1,164 @@
+package kubectl
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// IsReady checks if the given object is ready. It returns a reason if the object is not ready yet
+// and an error if the object will never become ready
+func IsReady(obj *unstructured.Unstructured) (bool, string, error) {
+	generation := obj.GetGeneration()
+	observedGeneration, found, _ := unstructured.NestedInt64(obj.Object, "status", "observedGeneration")
+	if found && observedGeneration < generation {
+		return false, "waiting for the spec update to be observed", nil
+	}
+
+	switch obj.GroupVersionKind().GroupKind() {
+	case schema.GroupKind{Group: "apps", Kind: "Deployment"}:
+		return isDeploymentReady(obj)
+	case schema.GroupKind{Group: "apps", Kind: "StatefulSet"}:
+		return isStatefulSetReady(obj)
+	case schema.GroupKind{Group: "apps", Kind: "DaemonSet"}:
+		return isDaemonSetReady(obj)
+	case schema.GroupKind{Group: "batch", Kind: "Job"}:
+		return isJobReady(obj)
+	case schema.GroupKind{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"}:
+		return isCustomResourceDefinitionReady(obj)
+	}
+
+	// Other objects are ready if they have no ready condition or the ready condition is true
+	status, message, found := getCondition(obj, "Ready")
+	if found && status != "True" {
+		if message == "" {
+			message = "waiting for the ready condition"
+		}
+
+		return false, message, nil
+	}
+
+	return true, "", nil
+}
+
+func isDeploymentReady(obj *unstructured.Unstructured) (bool, string, error) {
+	status, message, found := getCondition(obj, "Progressing")
+	if found && status == "False" {
+		return false, "", errors.Errorf("rollout failed: %s", message)
+	}
+
+	replicas := getReplicas(obj)
+	updatedReplicas, _, _ := unstructured.NestedInt64(obj.Object, "status", "updatedReplicas")
+	statusReplicas, _, _ := unstructured.NestedInt64(obj.Object, "status", "replicas")
+	availableReplicas, _, _ := unstructured.NestedInt64(obj.Object, "status", "availableReplicas")
+	if updatedReplicas < replicas {
+		return false, fmt.Sprintf("%d of %d updated replicas are available", updatedReplicas, replicas), nil
+	} else if statusReplicas > updatedReplicas {
+		return false, fmt.Sprintf("%d old replicas are pending termination", statusReplicas-updatedReplicas), nil
+	} else if availableReplicas < updatedReplicas {
+		return false, fmt.Sprintf("%d of %d updated replicas are available", availableReplicas, updatedReplicas), nil
+	}
+
+	return true, "", nil
+}
+
+func isStatefulSetReady(obj *unstructured.Unstructured) (bool, string, error) {
+	strategy, _, _ := unstructured.NestedString(obj.Object, "spec", "updateStrategy", "type")
+	if strategy == "OnDelete" {
+		return true, "", nil
+	}
+
+	replicas := getReplicas(obj)
+	readyReplicas, _, _ := unstructured.NestedInt64(obj.Object, "status", "readyReplicas")
+	if readyReplicas < replicas {
+		return false, fmt.Sprintf("%d of %d replicas are ready", readyReplicas, replicas), nil
+	}
+
+	partition, found, _ := unstructured.NestedInt64(obj.Object, "spec", "updateStrategy", "rollingUpdate", "partition")
+	if found && partition > 0 {
+		updatedReplicas, _, _ := unstructured.NestedInt64(obj.Object, "status", "updatedReplicas")
+		if updatedReplicas < replicas-partition {
+			return false, fmt.Sprintf("%d of %d replicas are updated", updatedReplicas, replicas-partition), nil
+		}
+
+		return true, "", nil
+	}
+
+	currentRevision, _, _ := unstructured.NestedString(obj.Object, "status", "currentRevision")
+	updateRevision, _, _ := unstructured.NestedString(obj.Object, "status", "updateRevision")
+	if currentRevision != updateRevision {
+		return false, "waiting for the rolling update to complete", nil
+	}
+
+	return true, "", nil
+}
+
+func isDaemonSetReady(obj *unstructured.Unstructured) (bool, string, error) {
+	desired, _, _ := unstructured.NestedInt64(obj.Object, "status", "desiredNumberScheduled")
+	updated, _, _ := unstructured.NestedInt64(obj.Object, "status", "updatedNumberScheduled")
+	available, _, _ := unstructured.NestedInt64(obj.Object, "status", "numberAvailable")
+	if updated < desired {
+		return false, fmt.Sprintf("%d of %d updated pods are scheduled", updated, desired), nil
+	} else if available < desired {
+		return false, fmt.Sprintf("%d of %d updated pods are available", available, desired), nil
+	}
+
+	return true, "", nil
+}
+
+func isJobReady(obj *unstructured.Unstructured) (bool, string, error) {
+	status, message, found := getCondition(obj, "Failed")
+	if found && status == "True" {
+		return false, "", errors.Errorf("job failed: %s", message)
+	}
+
+	status, _, found = getCondition(obj, "Complete")
+	if found && status == "True" {
+		return true, "", nil
+	}
+
+	return false, "waiting for the job to complete", nil
+}
+
+func isCustomResourceDefinitionReady(obj *unstructured.Unstructured) (bool, string, error) {
+	status, message, found := getCondition(obj, "NamesAccepted")
+	if found && status == "False" {
+		return false, "", errors.Errorf("names not accepted: %s", message)
+	}
+
+	status, _, found = getCondition(obj, "Established")
+	if found && status == "True" {
+		return true, "", nil
+	}
+
+	return false, "waiting for the custom resource definition to be established", nil
+}
+
+// getReplicas returns the desired replicas of the object, which default to 1
+func getReplicas(obj *unstructured.Unstructured) int64 {
+	replicas, found, _ := unstructured.NestedInt64(obj.Object, "spec", "replicas")
+	if found == false {
+		return 1
+	}
+
+	return replicas
+}
+
+// getCondition returns the status and message of the condition with the given type
+func getCondition(obj *unstructured.Unstructured, conditionType string) (string, string, bool) {
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, condition := range conditions {
+		conditionMap, ok := condition.(map[string]interface{})
+		if !ok || conditionMap["type"] != conditionType {
+			continue
+		}
+
+		status, _ := conditionMap["status"].(string)
+		message, _ := conditionMap["message"].(string)
+		return status, message, true
+	}
+
+	return "", "", false
+}
@@ -0,0 +1,185 @@
+package kubectl
+
+import (
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"gotest.tools/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+type isReadyTestCase struct {
+	name string
+
+	object string
+
+	expectedReady  bool
+	expectedReason string
+	expectedErr    string
+}
+
+func TestIsReady(t *testing.T) {
+	testCases := []isReadyTestCase{
+		{
+			name: "Deployment spec not observed",
+			object: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  generation: 2
+status:
+  observedGeneration: 1`,
+			expectedReason: "waiting for the spec update to be observed",
+		},
+		{
+			name: "Deployment rolling out",
+			object: `
+apiVersion: apps/v1
+kind: Deployment
+spec:
+  replicas: 3
+status:
+  replicas: 3
+  updatedReplicas: 3
+  availableReplicas: 1`,
+			expectedReason: "1 of 3 updated replicas are available",
+		},
+		{
+			name: "Deployment progress deadline exceeded",
+			object: `
+apiVersion: apps/v1
+kind: Deployment
+status:
+  conditions:
+  - type: Progressing
+    status: "False"
+    message: progress deadline exceeded`,
+			expectedErr: "rollout failed: progress deadline exceeded",
+		},
+		{
+			name: "Deployment ready",
+			object: `
+apiVersion: apps/v1
+kind: Deployment
+status:
+  replicas: 1
+  updatedReplicas: 1
+  availableReplicas: 1`,
+			expectedReady: true,
+		},
+		{
+			name: "StatefulSet updating",
+			object: `
+apiVersion: apps/v1
+kind: StatefulSet
+spec:
+  replicas: 2
+status:
+  readyReplicas: 2
+  currentRevision: a
+  updateRevision: b`,
+			expectedReason: "waiting for the rolling update to complete",
+		},
+		{
+			name: "DaemonSet ready",
+			object: `
+apiVersion: apps/v1
+kind: DaemonSet
+status:
+  desiredNumberScheduled: 2
+  updatedNumberScheduled: 2
+  numberAvailable: 2`,
+			expectedReady: true,
+		},
+		{
+			name: "Job running",
+			object: `
+apiVersion: batch/v1
+kind: Job`,
+			expectedReason: "waiting for the job to complete",
+		},
+		{
+			name: "Job failed",
+			object: `
+apiVersion: batch/v1
+kind: Job
+status:
+  conditions:
+  - type: Failed
+    status: "True"
+    message: backoff limit exceeded`,
+			expectedErr: "job failed: backoff limit exceeded",
+		},
+		{
+			name: "CustomResourceDefinition not established",
+			object: `
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+status:
+  conditions:
+  - type: NamesAccepted
+    status: "True"`,
+			expectedReason: "waiting for the custom resource definition to be established",
+		},
+		{
+			name: "CustomResourceDefinition names conflict",
+			object: `
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+status:
+  conditions:
+  - type: NamesAccepted
+    status: "False"
+    message: plural name is already in use`,
+			expectedErr: "names not accepted: plural name is already in use",
+		},
+		{
+			name: "CustomResourceDefinition established",
+			object: `
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+status:
+  conditions:
+  - type: Established
+    status: "True"`,
+			expectedReady: true,
+		},
+		{
+			name: "Custom resource not ready",
+			object: `
+apiVersion: example.com/v1
+kind: Database
+status:
+  conditions:
+  - type: Ready
+    status: "False"
+    message: provisioning`,
+			expectedReason: "provisioning",
+		},
+		{
+			name: "ConfigMap",
+			object: `
+apiVersion: v1
+kind: ConfigMap`,
+			expectedReady: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		data, err := yaml.YAMLToJSON([]byte(testCase.object))
+		assert.NilError(t, err, "Error parsing object in testCase %s", testCase.name)
+		obj := &unstructured.Unstructured{}
+		err = obj.UnmarshalJSON(data)
+		assert.NilError(t, err, "Error parsing object in testCase %s", testCase.name)
+
+		ready, reason, err := IsReady(obj)
+		if testCase.expectedErr == "" {
+			assert.NilError(t, err, "Error in testCase %s", testCase.name)
+		} else {
+			assert.Error(t, err, testCase.expectedErr, "Wrong or no error in testCase %s", testCase.name)
+		}
+
+		assert.Equal(t, ready, testCase.expectedReady, "Unexpected ready in testCase %s", testCase.name)
+		assert.Equal(t, reason, testCase.expectedReason, "Unexpected reason in testCase %s", testCase.name)
+	}
+}
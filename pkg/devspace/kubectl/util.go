@@ -26,6 +26,10 @@ const ClusterRoleBindingName = "devspace-user"
 const minikubeContext = "minikube"
 const dockerDesktopContext = "docker-desktop"
 const dockerForDesktopContext = "docker-for-desktop"
+const microk8sContext = "microk8s"
+const rancherDesktopContext = "rancher-desktop"
+const kindContextPrefix = "kind-"
+const k3dContextPrefix = "k3d-"
 
 // WaitStatus are the status to wait
 var WaitStatus = []string{
@@ -286,5 +290,47 @@ func (client *client) IsLocalKubernetes() bool {
 
 // IsLocalKubernetes returns true if the context belongs to a local Kubernetes cluster
 func IsLocalKubernetes(context string) bool {
-	return context == minikubeContext || context == dockerDesktopContext || context == dockerForDesktopContext
+	return context == minikubeContext ||
+		context == dockerDesktopContext ||
+		context == dockerForDesktopContext ||
+		context == microk8sContext ||
+		context == rancherDesktopContext ||
+		IsKindContext(context) ||
+		IsK3dContext(context)
+}
+
+// IsMinikubeContext returns true if the context belongs to a minikube cluster
+func IsMinikubeContext(context string) bool {
+	return context == minikubeContext
+}
+
+// IsMicrok8sContext returns true if the context belongs to a microk8s cluster
+func IsMicrok8sContext(context string) bool {
+	return context == microk8sContext
+}
+
+// IsRancherDesktopContext returns true if the context belongs to a rancher desktop cluster
+func IsRancherDesktopContext(context string) bool {
+	return context == rancherDesktopContext
+}
+
+// IsKindContext returns true if the context belongs to a kind cluster
+func IsKindContext(context string) bool {
+	return strings.HasPrefix(context, kindContextPrefix) && len(context) > len(kindContextPrefix)
+}
+
+// IsK3dContext returns true if the context belongs to a k3d cluster
+func IsK3dContext(context string) bool {
+	return strings.HasPrefix(context, k3dContextPrefix) && len(context) > len(k3dContextPrefix)
+}
+
+// GetLocalClusterName returns the name of the kind or k3d cluster the context belongs to
+func GetLocalClusterName(context string) string {
+	if IsKindContext(context) {
+		return strings.TrimPrefix(context, kindContextPrefix)
+	} else if IsK3dContext(context) {
+		return strings.TrimPrefix(context, k3dContextPrefix)
+	}
+
+	return context
 }
@@ -0,0 +1,252 @@
+package preview
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/loft-sh/devspace/pkg/util/hash"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// ProjectLabel marks a namespace as preview namespace and holds the project the preview belongs to
+	ProjectLabel = "devspace.sh/preview"
+	// BranchLabel holds the sanitized git branch of the preview
+	BranchLabel = "devspace.sh/preview-branch"
+
+	// BranchAnnotation holds the unmodified git branch of the preview
+	BranchAnnotation = "devspace.sh/preview-branch"
+	// LastDeployedAnnotation holds the time the preview was last deployed
+	LastDeployedAnnotation = "devspace.sh/preview-last-deployed"
+	// TTLAnnotation holds the duration after the last deployment when the preview expires
+	TTLAnnotation = "devspace.sh/preview-ttl"
+)
+
+// maxNameLength is the maximum length of namespace names and label values
+const maxNameLength = 63
+
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// Preview describes a preview namespace
+type Preview struct {
+	Namespace    string
+	Project      string
+	Branch       string
+	LastDeployed time.Time
+	TTL          time.Duration
+}
+
+// Expired returns true if the preview was not deployed within its ttl. Previews without ttl never expire
+func (p *Preview) Expired(now time.Time) bool {
+	if p.TTL <= 0 {
+		return false
+	}
+
+	return now.Sub(p.LastDeployed) > p.TTL
+}
+
+// Sanitize converts the given string into a valid namespace name and label value. Names that are too long
+// are shortened and suffixed with a hash, so different branches with a common prefix get different names
+func Sanitize(name string) string {
+	sanitized := invalidNameChars.ReplaceAllString(strings.ToLower(name), "-")
+	sanitized = strings.Trim(sanitized, "-")
+	if len(sanitized) > maxNameLength {
+		sanitized = strings.Trim(sanitized[:maxNameLength-9], "-") + "-" + hash.String(name)[:8]
+	}
+
+	return sanitized
+}
+
+// NamespaceName returns the name of the preview namespace for the given project and branch
+func NamespaceName(project, branch string) string {
+	return Sanitize(project + "-" + branch)
+}
+
+// Ensure creates the preview namespace or, if it exists, updates its last deployment time and ttl
+func Ensure(client kubernetes.Interface, preview *Preview) error {
+	labels := map[string]string{
+		ProjectLabel: Sanitize(preview.Project),
+		BranchLabel:  Sanitize(preview.Branch),
+	}
+	annotations := map[string]string{
+		BranchAnnotation:       preview.Branch,
+		LastDeployedAnnotation: preview.LastDeployed.UTC().Format(time.RFC3339),
+		TTLAnnotation:          preview.TTL.String(),
+	}
+
+	namespace, err := client.CoreV1().Namespaces().Get(context.TODO(), preview.Namespace, metav1.GetOptions{})
+	if err != nil {
+		if kerrors.IsNotFound(err) == false {
+			return errors.Wrap(err, "get namespace")
+		}
+
+		_, err = client.CoreV1().Namespaces().Create(context.TODO(), &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        preview.Namespace,
+				Labels:      labels,
+				Annotations: annotations,
+			},
+		}, metav1.CreateOptions{})
+		if err != nil {
+			return errors.Wrap(err, "create namespace")
+		}
+
+		return nil
+	} else if namespace.Labels[ProjectLabel] == "" {
+		return errors.Errorf("namespace %s already exists and is not a preview namespace", preview.Namespace)
+	} else if namespace.Labels[ProjectLabel] != labels[ProjectLabel] {
+		return errors.Errorf("namespace %s is a preview namespace of project %s", preview.Namespace, namespace.Labels[ProjectLabel])
+	}
+
+	if namespace.Labels == nil {
+		namespace.Labels = map[string]string{}
+	}
+	if namespace.Annotations == nil {
+		namespace.Annotations = map[string]string{}
+	}
+	for k, v := range labels {
+		namespace.Labels[k] = v
+	}
+	for k, v := range annotations {
+		namespace.Annotations[k] = v
+	}
+
+	_, err = client.CoreV1().Namespaces().Update(context.TODO(), namespace, metav1.UpdateOptions{})
+	if err != nil {
+		return errors.Wrap(err, "update namespace")
+	}
+
+	return nil
+}
+
+// List returns the previews of the given project sorted by namespace. If project is empty, the previews of
+// all projects are returned
+func List(client kubernetes.Interface, project string) ([]*Preview, error) {
+	selector := ProjectLabel
+	if project != "" {
+		selector = ProjectLabel + "=" + Sanitize(project)
+	}
+
+	namespaces, err := client.CoreV1().Namespaces().List(context.TODO(), metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, errors.Wrap(err, "list namespaces")
+	}
+
+	previews := []*Preview{}
+	for _, namespace := range namespaces.Items {
+		preview := &Preview{
+			Namespace:    namespace.Name,
+			Project:      namespace.Labels[ProjectLabel],
+			Branch:       namespace.Annotations[BranchAnnotation],
+			LastDeployed: namespace.CreationTimestamp.Time,
+		}
+		if preview.Branch == "" {
+			preview.Branch = namespace.Labels[BranchLabel]
+		}
+		if lastDeployed, err := time.Parse(time.RFC3339, namespace.Annotations[LastDeployedAnnotation]); err == nil {
+			preview.LastDeployed = lastDeployed
+		}
+		if ttl, err := time.ParseDuration(namespace.Annotations[TTLAnnotation]); err == nil {
+			preview.TTL = ttl
+		}
+
+		previews = append(previews, preview)
+	}
+
+	sort.Slice(previews, func(i, j int) bool {
+		return previews[i].Namespace < previews[j].Namespace
+	})
+	return previews, nil
+}
+
+// Delete deletes the preview namespace with the given name. Namespaces that are not preview namespaces are not deleted
+func Delete(client kubernetes.Interface, name string) error {
+	namespace, err := client.CoreV1().Namespaces().Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		return errors.Wrap(err, "get namespace")
+	} else if namespace.Labels[ProjectLabel] == "" {
+		return errors.Errorf("namespace %s is not a preview namespace", name)
+	}
+
+	propagationPolicy := metav1.DeletePropagationBackground
+	err = client.CoreV1().Namespaces().Delete(context.TODO(), name, metav1.DeleteOptions{PropagationPolicy: &propagationPolicy})
+	if err != nil && kerrors.IsNotFound(err) == false {
+		return errors.Wrap(err, "delete namespace")
+	}
+
+	return nil
+}
+
+// DeleteExpired deletes the expired previews of the given project and returns the deleted namespaces
+func DeleteExpired(client kubernetes.Interface, project string, now time.Time) ([]string, error) {
+	previews, err := List(client, project)
+	if err != nil {
+		return nil, err
+	}
+
+	deleted := []string{}
+	for _, preview := range previews {
+		if preview.Expired(now) == false {
+			continue
+		}
+
+		err = Delete(client, preview.Namespace)
+		if err != nil {
+			return deleted, err
+		}
+
+		deleted = append(deleted, preview.Namespace)
+	}
+
+	return deleted, nil
+}
+
+// CopyPullSecrets copies the image pull secrets from one namespace into another and returns the names of the copied secrets
+func CopyPullSecrets(client kubernetes.Interface, fromNamespace, toNamespace string) ([]string, error) {
+	secrets, err := client.CoreV1().Secrets(fromNamespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "list secrets")
+	}
+
+	copied := []string{}
+	for _, secret := range secrets.Items {
+		if secret.Type != corev1.SecretTypeDockerConfigJson && secret.Type != corev1.SecretTypeDockercfg {
+			continue
+		}
+
+		newSecret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        secret.Name,
+				Namespace:   toNamespace,
+				Labels:      secret.Labels,
+				Annotations: secret.Annotations,
+			},
+			Type: secret.Type,
+			Data: secret.Data,
+		}
+
+		_, err = client.CoreV1().Secrets(toNamespace).Create(context.TODO(), newSecret, metav1.CreateOptions{})
+		if err != nil {
+			if kerrors.IsAlreadyExists(err) == false {
+				return copied, errors.Wrapf(err, "create secret %s", secret.Name)
+			}
+
+			_, err = client.CoreV1().Secrets(toNamespace).Update(context.TODO(), newSecret, metav1.UpdateOptions{})
+			if err != nil {
+				return copied, errors.Wrapf(err, "update secret %s", secret.Name)
+			}
+		}
+
+		copied = append(copied, secret.Name)
+	}
+
+	return copied, nil
+}
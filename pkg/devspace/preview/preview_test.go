@@ -0,0 +1,133 @@
+package preview
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+type sanitizeTestCase struct {
+	name string
+
+	input          string
+	expectedOutput string
+}
+
+func TestSanitize(t *testing.T) {
+	testCases := []sanitizeTestCase{
+		{
+			name:           "Valid name",
+			input:          "my-project-main",
+			expectedOutput: "my-project-main",
+		},
+		{
+			name:           "Slashes and upper case",
+			input:          "my-project-Feature/JIRA-123_login",
+			expectedOutput: "my-project-feature-jira-123-login",
+		},
+		{
+			name:           "Leading and trailing invalid characters",
+			input:          "_my-project-fix.",
+			expectedOutput: "my-project-fix",
+		},
+		{
+			name:           "Too long",
+			input:          "my-project-" + strings.Repeat("a", 60),
+			expectedOutput: "my-project-" + strings.Repeat("a", 43) + "-28c21fe8",
+		},
+	}
+
+	for _, testCase := range testCases {
+		output := Sanitize(testCase.input)
+		assert.Equal(t, output, testCase.expectedOutput, "Unexpected output in testCase %s", testCase.name)
+		assert.Assert(t, len(output) <= maxNameLength, "Output too long in testCase %s", testCase.name)
+	}
+}
+
+func TestPreviews(t *testing.T) {
+	now := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	client := fake.NewSimpleClientset(&corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "no-preview",
+		},
+	})
+
+	for _, preview := range []*Preview{
+		{Namespace: "my-project-main", Project: "my-project", Branch: "main", LastDeployed: now.Add(-time.Hour), TTL: 72 * time.Hour},
+		{Namespace: "my-project-feature-old", Project: "my-project", Branch: "feature/old", LastDeployed: now.Add(-96 * time.Hour), TTL: 72 * time.Hour},
+		{Namespace: "my-project-feature-keep", Project: "my-project", Branch: "feature/keep", LastDeployed: now.Add(-96 * time.Hour)},
+		{Namespace: "other-main", Project: "other", Branch: "main", LastDeployed: now.Add(-96 * time.Hour), TTL: time.Hour},
+	} {
+		err := Ensure(client, preview)
+		assert.NilError(t, err)
+	}
+
+	// Updating an existing preview refreshes the last deployment
+	err := Ensure(client, &Preview{Namespace: "my-project-main", Project: "my-project", Branch: "main", LastDeployed: now, TTL: 24 * time.Hour})
+	assert.NilError(t, err)
+
+	// Existing namespaces of other projects are not taken over
+	err = Ensure(client, &Preview{Namespace: "no-preview", Project: "my-project", Branch: "preview"})
+	assert.Error(t, err, "namespace no-preview already exists and is not a preview namespace")
+	err = Ensure(client, &Preview{Namespace: "other-main", Project: "my-project", Branch: "main"})
+	assert.Error(t, err, "namespace other-main is a preview namespace of project other")
+
+	previews, err := List(client, "my-project")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, previews, []*Preview{
+		{Namespace: "my-project-feature-keep", Project: "my-project", Branch: "feature/keep", LastDeployed: now.Add(-96 * time.Hour)},
+		{Namespace: "my-project-feature-old", Project: "my-project", Branch: "feature/old", LastDeployed: now.Add(-96 * time.Hour), TTL: 72 * time.Hour},
+		{Namespace: "my-project-main", Project: "my-project", Branch: "main", LastDeployed: now, TTL: 24 * time.Hour},
+	})
+
+	deleted, err := DeleteExpired(client, "my-project", now)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, deleted, []string{"my-project-feature-old"})
+
+	err = Delete(client, "no-preview")
+	assert.Error(t, err, "namespace no-preview is not a preview namespace")
+
+	previews, err = List(client, "")
+	assert.NilError(t, err)
+	namespaces := []string{}
+	for _, preview := range previews {
+		namespaces = append(namespaces, preview.Namespace)
+	}
+	assert.DeepEqual(t, namespaces, []string{"my-project-feature-keep", "my-project-main", "other-main"})
+}
+
+func TestCopyPullSecrets(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "registry", Namespace: "default"},
+			Type:       corev1.SecretTypeDockerConfigJson,
+			Data:       map[string][]byte{corev1.DockerConfigJsonKey: []byte("{}")},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "password", Namespace: "default"},
+			Type:       corev1.SecretTypeOpaque,
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "registry", Namespace: "preview"},
+			Type:       corev1.SecretTypeDockerConfigJson,
+			Data:       map[string][]byte{corev1.DockerConfigJsonKey: []byte("old")},
+		},
+	)
+
+	copied, err := CopyPullSecrets(client, "default", "preview")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, copied, []string{"registry"})
+
+	secret, err := client.CoreV1().Secrets("preview").Get(context.TODO(), "registry", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, string(secret.Data[corev1.DockerConfigJsonKey]), "{}")
+
+	_, err = client.CoreV1().Secrets("preview").Get(context.TODO(), "password", metav1.GetOptions{})
+	assert.Assert(t, err != nil)
+}
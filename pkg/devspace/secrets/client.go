@@ -0,0 +1,175 @@
+package secrets
+
+import (
+	"context"
+	"io/ioutil"
+	"reflect"
+
+	config2 "github.com/loft-sh/devspace/pkg/devspace/config"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
+	"github.com/loft-sh/devspace/pkg/util/log"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Client creates the kubernetes secrets of the encrypted secret files in the config
+type Client interface {
+	ApplySecrets() error
+}
+
+// NewClient creates a new secrets client
+func NewClient(config config2.Config, kubeClient kubectl.Client, log log.Logger) Client {
+	var latest *latest.Config
+	if config != nil {
+		latest = config.Config()
+	}
+
+	return &client{
+		config:     latest,
+		kubeClient: kubeClient,
+		getKey:     GetKey,
+		log:        log,
+	}
+}
+
+type client struct {
+	config     *latest.Config
+	kubeClient kubectl.Client
+	getKey     func() ([]byte, error)
+	log        log.Logger
+}
+
+// ApplySecrets decrypts the configured secret files and creates or updates the contained secrets
+func (c *client) ApplySecrets() error {
+	if c.config == nil || len(c.config.Secrets) == 0 {
+		return nil
+	}
+
+	key, err := c.getKey()
+	if err != nil {
+		return err
+	}
+
+	for _, secretConfig := range c.config.Secrets {
+		secrets, err := readSecrets(secretConfig.Path, key)
+		if err != nil {
+			return errors.Wrapf(err, "read secrets from %s", secretConfig.Path)
+		}
+
+		for _, secret := range secrets {
+			if secret.Namespace == "" {
+				secret.Namespace = secretConfig.Namespace
+			}
+			if secret.Namespace == "" {
+				secret.Namespace = c.kubeClient.Namespace()
+			}
+
+			err = c.applySecret(secret)
+			if err != nil {
+				return errors.Wrapf(err, "apply secret %s from %s", secret.Name, secretConfig.Path)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c *client) applySecret(secret *corev1.Secret) error {
+	secrets := c.kubeClient.KubeClient().CoreV1().Secrets(secret.Namespace)
+	existing, err := secrets.Get(context.TODO(), secret.Name, metav1.GetOptions{})
+	if err != nil {
+		if kerrors.IsNotFound(err) == false {
+			return err
+		}
+
+		_, err = secrets.Create(context.TODO(), secret, metav1.CreateOptions{})
+		if err != nil {
+			return err
+		}
+
+		c.log.Donef("Created secret %s in namespace %s", secret.Name, secret.Namespace)
+		return nil
+	}
+
+	changed := false
+	if reflect.DeepEqual(existing.Data, secret.Data) == false {
+		existing.Data = secret.Data
+		changed = true
+	}
+	if secret.Type != "" && existing.Type != secret.Type {
+		existing.Type = secret.Type
+		changed = true
+	}
+	for k, v := range secret.Labels {
+		if existing.Labels == nil {
+			existing.Labels = map[string]string{}
+		}
+		if existing.Labels[k] != v {
+			existing.Labels[k] = v
+			changed = true
+		}
+	}
+	for k, v := range secret.Annotations {
+		if existing.Annotations == nil {
+			existing.Annotations = map[string]string{}
+		}
+		if existing.Annotations[k] != v {
+			existing.Annotations[k] = v
+			changed = true
+		}
+	}
+	if changed == false {
+		return nil
+	}
+
+	_, err = secrets.Update(context.TODO(), existing, metav1.UpdateOptions{})
+	if err != nil {
+		return err
+	}
+
+	c.log.Donef("Updated secret %s in namespace %s", secret.Name, secret.Namespace)
+	return nil
+}
+
+// readSecrets reads and decrypts the secrets of the given file. String data is merged into the data of
+// the secrets, so they can be compared with the secrets in the cluster
+func readSecrets(path string, key []byte) ([]*corev1.Secret, error) {
+	encrypted, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	decrypted, err := Decrypt(encrypted, key)
+	if err != nil {
+		return nil, err
+	}
+
+	secrets := []*corev1.Secret{}
+	for _, part := range documentSeparator.Split(string(decrypted), -1) {
+		secret := &corev1.Secret{}
+		err = yaml.Unmarshal([]byte(part), secret)
+		if err != nil {
+			return nil, err
+		} else if secret.Name == "" {
+			return nil, errors.New("secret name is required")
+		}
+
+		for k, v := range secret.StringData {
+			if secret.Data == nil {
+				secret.Data = map[string][]byte{}
+			}
+
+			secret.Data[k] = []byte(v)
+		}
+
+		secret.StringData = nil
+		secrets = append(secrets, secret)
+	}
+
+	return secrets, nil
+}
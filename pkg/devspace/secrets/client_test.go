@@ -0,0 +1,59 @@
+package secrets
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	fakekube "github.com/loft-sh/devspace/pkg/devspace/kubectl/testing"
+	"github.com/loft-sh/devspace/pkg/util/log"
+
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestApplySecrets(t *testing.T) {
+	dir, err := ioutil.TempDir("", "test")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	key := []byte("my-key")
+	encrypted, err := Encrypt([]byte(testManifest), nil, key)
+	assert.NilError(t, err)
+	path := filepath.Join(dir, "secrets.yaml")
+	err = ioutil.WriteFile(path, encrypted, 0644)
+	assert.NilError(t, err)
+
+	kubeClient := fake.NewSimpleClientset(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "token", Namespace: "testNamespace"},
+		Data:       map[string][]byte{"token": []byte("old")},
+	})
+	client := &client{
+		config: &latest.Config{
+			Secrets: []*latest.SecretConfig{
+				{Path: path},
+			},
+		},
+		kubeClient: &fakekube.Client{Client: kubeClient},
+		getKey: func() ([]byte, error) {
+			return key, nil
+		},
+		log: log.Discard,
+	}
+
+	err = client.ApplySecrets()
+	assert.NilError(t, err)
+
+	secret, err := kubeClient.CoreV1().Secrets("testNamespace").Get(context.TODO(), "database", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, secret.Data, map[string][]byte{"user": []byte("admin"), "password": []byte("secret")})
+
+	secret, err = kubeClient.CoreV1().Secrets("testNamespace").Get(context.TODO(), "token", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, secret.Data, map[string][]byte{"token": []byte("token")})
+}
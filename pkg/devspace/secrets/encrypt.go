@@ -0,0 +1,244 @@
+package secrets
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/loft-sh/devspace/pkg/util/encryption"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/scrypt"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	encryptedPrefix = "DEVSPACE_ENC["
+	encryptedSuffix = "]"
+)
+
+// The parameters of scrypt, which derives the encryption key from the key of the user. Every encrypted value
+// starts with the random salt the key was derived with
+const (
+	saltLength = 16
+	scryptN    = 32768
+	scryptR    = 8
+	scryptP    = 1
+	keyLength  = 32
+)
+
+// dataFields are the fields of a secret whose values are encrypted
+var dataFields = []string{"data", "stringData"}
+
+var documentSeparator = regexp.MustCompile(`(?m)^---.*$`)
+
+// Encrypt encrypts the values of data and stringData of all secrets in the given yaml, while the rest of
+// the secrets stays readable. Values that are already encrypted are kept. If previous is not nil, values
+// that did not change compared to the previous encrypted yaml keep their previous encrypted value, so only
+// changed values show up in a diff
+func Encrypt(manifest []byte, previous []byte, key []byte) ([]byte, error) {
+	keys := newKeyDeriver(key)
+	previousValues := map[string]string{}
+	if previous != nil {
+		err := walkValues(previous, func(path, value string) (string, error) {
+			if isEncrypted(value) {
+				decrypted, err := decryptValue(value, keys)
+				if err != nil {
+					return "", err
+				}
+
+				previousValues[path+"="+decrypted] = value
+			}
+
+			return value, nil
+		}, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// all new values of the manifest share a salt, so the key is only derived once
+	salt := make([]byte, saltLength)
+	_, err := io.ReadFull(rand.Reader, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	out := []byte{}
+	err = walkValues(manifest, func(path, value string) (string, error) {
+		if isEncrypted(value) {
+			return value, nil
+		} else if encrypted, ok := previousValues[path+"="+value]; ok {
+			return encrypted, nil
+		}
+
+		return encryptValue(value, salt, keys)
+	}, &out)
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// Decrypt decrypts the encrypted values of all secrets in the given yaml
+func Decrypt(manifest []byte, key []byte) ([]byte, error) {
+	keys := newKeyDeriver(key)
+	out := []byte{}
+	err := walkValues(manifest, func(path, value string) (string, error) {
+		if isEncrypted(value) == false {
+			return value, nil
+		}
+
+		return decryptValue(value, keys)
+	}, &out)
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// walkValues calls fn for every value of data and stringData of the secrets in the manifest. If out is not
+// nil, the manifest with the values returned by fn is written to out
+func walkValues(manifest []byte, fn func(path, value string) (string, error), out *[]byte) error {
+	documents := []string{}
+	for i, part := range documentSeparator.Split(string(manifest), -1) {
+		document := yaml.MapSlice{}
+		err := yaml.Unmarshal([]byte(part), &document)
+		if err != nil {
+			return errors.Wrapf(err, "parse document %d", i)
+		} else if len(document) == 0 {
+			continue
+		}
+
+		kind := ""
+		for _, item := range document {
+			if item.Key == "kind" {
+				kind, _ = item.Value.(string)
+			}
+		}
+		if kind != "Secret" {
+			return errors.Errorf("document %d is not a Secret", i)
+		}
+
+		for _, item := range document {
+			field, _ := item.Key.(string)
+			if contains(dataFields, field) == false || item.Value == nil {
+				continue
+			}
+
+			values, ok := item.Value.(yaml.MapSlice)
+			if !ok {
+				return errors.Errorf("document %d: %s is not a map", i, field)
+			}
+
+			for j, value := range values {
+				stringValue, ok := value.Value.(string)
+				if !ok {
+					return errors.Errorf("document %d: %s.%v is not a string", i, field, value.Key)
+				}
+
+				path := fmt.Sprintf("%d.%s.%v", len(documents), field, value.Key)
+				newValue, err := fn(path, stringValue)
+				if err != nil {
+					return errors.Wrapf(err, "document %d: %s.%v", i, field, value.Key)
+				}
+
+				values[j].Value = newValue
+			}
+		}
+
+		outDocument, err := yaml.Marshal(document)
+		if err != nil {
+			return err
+		}
+
+		documents = append(documents, string(outDocument))
+	}
+
+	if out != nil {
+		*out = []byte(strings.Join(documents, "---\n"))
+	}
+	return nil
+}
+
+func isEncrypted(value string) bool {
+	return strings.HasPrefix(value, encryptedPrefix) && strings.HasSuffix(value, encryptedSuffix)
+}
+
+func encryptValue(value string, salt []byte, keys *keyDeriver) (string, error) {
+	key, err := keys.deriveKey(salt)
+	if err != nil {
+		return "", err
+	}
+
+	encrypted, err := encryption.EncryptAES(key, []byte(value))
+	if err != nil {
+		return "", err
+	}
+
+	return encryptedPrefix + base64.StdEncoding.EncodeToString(append(salt, encrypted...)) + encryptedSuffix, nil
+}
+
+func decryptValue(value string, keys *keyDeriver) (string, error) {
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimSuffix(strings.TrimPrefix(value, encryptedPrefix), encryptedSuffix))
+	if err != nil {
+		return "", errors.Wrap(err, "decode value")
+	} else if len(decoded) <= saltLength {
+		return "", errors.New("decode value: value is too short")
+	}
+
+	key, err := keys.deriveKey(decoded[:saltLength])
+	if err != nil {
+		return "", err
+	}
+
+	decrypted, err := encryption.DecryptAES(key, decoded[saltLength:])
+	if err != nil {
+		return "", errors.Wrap(err, "decrypt value (wrong key?)")
+	}
+
+	return string(decrypted), nil
+}
+
+// keyDeriver derives the encryption keys from the key of the user with scrypt. Deriving a key is slow on
+// purpose, so the derived keys are cached by salt
+type keyDeriver struct {
+	key     []byte
+	derived map[string][]byte
+}
+
+func newKeyDeriver(key []byte) *keyDeriver {
+	return &keyDeriver{
+		key:     key,
+		derived: map[string][]byte{},
+	}
+}
+
+func (k *keyDeriver) deriveKey(salt []byte) ([]byte, error) {
+	if key, ok := k.derived[string(salt)]; ok {
+		return key, nil
+	}
+
+	key, err := scrypt.Key(k.key, salt, scryptN, scryptR, scryptP, keyLength)
+	if err != nil {
+		return nil, errors.Wrap(err, "derive key")
+	}
+
+	k.derived[string(salt)] = key
+	return key, nil
+}
+
+func contains(arr []string, str string) bool {
+	for _, s := range arr {
+		if s == str {
+			return true
+		}
+	}
+
+	return false
+}
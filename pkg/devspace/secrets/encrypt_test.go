@@ -0,0 +1,62 @@
+package secrets
+
+import (
+	"strings"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+const testManifest = `apiVersion: v1
+kind: Secret
+metadata:
+  name: database
+stringData:
+  user: admin
+  password: secret
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: token
+data:
+  token: dG9rZW4=
+`
+
+func TestEncryptDecrypt(t *testing.T) {
+	key := []byte("my-key")
+	encrypted, err := Encrypt([]byte(testManifest), nil, key)
+	assert.NilError(t, err)
+	assert.Assert(t, strings.Contains(string(encrypted), "name: database\n"))
+	assert.Assert(t, strings.Contains(string(encrypted), "password: DEVSPACE_ENC["))
+	assert.Assert(t, strings.Contains(string(encrypted), "secret") == false)
+
+	// encrypting again keeps the encrypted values
+	encryptedAgain, err := Encrypt(encrypted, nil, key)
+	assert.NilError(t, err)
+	assert.Equal(t, string(encryptedAgain), string(encrypted))
+
+	decrypted, err := Decrypt(encrypted, key)
+	assert.NilError(t, err)
+	assert.Equal(t, string(decrypted), testManifest)
+
+	// only changed values are encrypted again
+	edited := strings.Replace(string(decrypted), "password: secret", "password: changed", 1)
+	reencrypted, err := Encrypt([]byte(edited), encrypted, key)
+	assert.NilError(t, err)
+	changed := 0
+	encryptedLines := strings.Split(string(encrypted), "\n")
+	for i, line := range strings.Split(string(reencrypted), "\n") {
+		if line != encryptedLines[i] {
+			assert.Assert(t, strings.HasPrefix(line, "  password: DEVSPACE_ENC["))
+			changed++
+		}
+	}
+	assert.Equal(t, changed, 1)
+
+	_, err = Decrypt(encrypted, []byte("wrong-key"))
+	assert.ErrorContains(t, err, "decrypt value (wrong key?)")
+
+	_, err = Encrypt([]byte("apiVersion: v1\nkind: ConfigMap\n"), nil, key)
+	assert.Error(t, err, "document 0 is not a Secret")
+}
@@ -0,0 +1,43 @@
+package secrets
+
+import (
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// KeyEnv is the env variable that holds the key to encrypt and decrypt secret files
+	KeyEnv = "DEVSPACE_SECRETS_KEY"
+	// KeychainService is the service name under which the key is looked up in the keychain of the os
+	KeychainService = "devspace-secrets"
+)
+
+// keychainCommands are the commands that print the key from the keychain of the os
+var keychainCommands = map[string][]string{
+	"darwin": {"security", "find-generic-password", "-s", KeychainService, "-w"},
+	"linux":  {"secret-tool", "lookup", "service", KeychainService},
+}
+
+// GetKey returns the key to encrypt and decrypt secret files. The key is read from the DEVSPACE_SECRETS_KEY
+// env variable or, if it is not set, from the keychain of the os
+func GetKey() ([]byte, error) {
+	if key := os.Getenv(KeyEnv); key != "" {
+		return []byte(key), nil
+	}
+
+	if command, ok := keychainCommands[runtime.GOOS]; ok {
+		out, err := exec.Command(command[0], command[1:]...).Output()
+		if err == nil {
+			key := strings.TrimRight(string(out), "\r\n")
+			if key != "" {
+				return []byte(key), nil
+			}
+		}
+	}
+
+	return nil, errors.Errorf("no key found to encrypt or decrypt secrets, please set the %s environment variable or add the key with the service name %s to your keychain", KeyEnv, KeychainService)
+}
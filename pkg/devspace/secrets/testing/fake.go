@@ -0,0 +1,9 @@
+package testing
+
+// Client is a fake implementation of the Client interface
+type Client struct{}
+
+// ApplySecrets is a fake implementation of the function
+func (c *Client) ApplySecrets() error {
+	return nil
+}
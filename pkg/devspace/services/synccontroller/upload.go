@@ -0,0 +1,125 @@
+package synccontroller
+
+import (
+	"io"
+	"runtime"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
+	"github.com/loft-sh/devspace/pkg/devspace/services/inject"
+	"github.com/loft-sh/devspace/pkg/devspace/sync"
+	logpkg "github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+)
+
+// UploadOptions holds the options for a single upload
+type UploadOptions struct {
+	LocalPath     string
+	ContainerPath string
+	ExcludePaths  []string
+
+	Log logpkg.Logger
+}
+
+// Upload mirrors the local path into the container path once via the devspacehelper. Only changed files are
+// transferred and files that do not exist locally anymore are removed from the container
+func Upload(client kubectl.Client, pod *v1.Pod, container string, options *UploadOptions) error {
+	if options.Log == nil {
+		options.Log = logpkg.Discard
+	}
+
+	err := inject.InjectDevSpaceHelper(client, pod, container, "", options.Log)
+	if err != nil {
+		return err
+	}
+
+	syncClient, err := sync.NewSync(options.LocalPath, sync.Options{
+		ExcludePaths:         options.ExcludePaths,
+		InitialSyncCompareBy: latest.InitialSyncCompareByMTime,
+		InitialSync:          latest.InitialSyncStrategyMirrorLocal,
+		DownstreamDisabled:   true,
+		Log:                  options.Log,
+	})
+	if err != nil {
+		return errors.Wrap(err, "create sync")
+	}
+
+	// Start upstream
+	upstreamArgs := []string{inject.DevSpaceHelperContainerPath, "sync", "upstream"}
+	if runtime.GOOS == "darwin" || runtime.GOOS == "linux" {
+		upstreamArgs = append(upstreamArgs, "--override-permissions")
+	}
+	for _, exclude := range syncClient.Options.ExcludePaths {
+		upstreamArgs = append(upstreamArgs, "--exclude", exclude)
+	}
+	upstreamArgs = append(upstreamArgs, options.ContainerPath)
+
+	upStdinReader, upStdinWriter := io.Pipe()
+	upStdoutReader, upStdoutWriter := io.Pipe()
+
+	go func() {
+		err := inject.StartStream(client, pod, container, upstreamArgs, upStdinReader, upStdoutWriter)
+		if err != nil {
+			syncClient.Stop(errors.Errorf("Sync - connection lost to pod %s/%s: %v", pod.Namespace, pod.Name, err))
+		}
+	}()
+
+	err = syncClient.InitUpstream(upStdoutReader, upStdinWriter)
+	if err != nil {
+		return errors.Wrap(err, "init upstream")
+	}
+
+	// Start downstream, which is needed to retrieve the files in the container
+	downstreamArgs := []string{inject.DevSpaceHelperContainerPath, "sync", "downstream"}
+	for _, exclude := range syncClient.Options.ExcludePaths {
+		downstreamArgs = append(downstreamArgs, "--exclude", exclude)
+	}
+	downstreamArgs = append(downstreamArgs, options.ContainerPath)
+
+	downStdinReader, downStdinWriter := io.Pipe()
+	downStdoutReader, downStdoutWriter := io.Pipe()
+
+	go func() {
+		err := inject.StartStream(client, pod, container, downstreamArgs, downStdinReader, downStdoutWriter)
+		if err != nil {
+			syncClient.Stop(errors.Errorf("Sync - connection lost to pod %s/%s: %v", pod.Namespace, pod.Name, err))
+		}
+	}()
+
+	err = syncClient.InitDownstream(downStdoutReader, downStdinWriter)
+	if err != nil {
+		return errors.Wrap(err, "init downstream")
+	}
+
+	var (
+		onInitUploadDone   = make(chan struct{})
+		onInitDownloadDone = make(chan struct{})
+		onError            = make(chan error, 1)
+		onDone             = make(chan struct{})
+	)
+
+	err = syncClient.Start(onInitUploadDone, onInitDownloadDone, onDone, onError)
+	if err != nil {
+		return errors.Errorf("Sync error: %v", err)
+	}
+	defer syncClient.Stop(nil)
+
+	// wait until the initial upload is done
+	uploadDone := false
+	downloadDone := false
+	for !uploadDone || !downloadDone {
+		select {
+		case err := <-onError:
+			return errors.Wrap(err, "upload")
+		case <-onInitUploadDone:
+			uploadDone = true
+		case <-onInitDownloadDone:
+			downloadDone = true
+		case <-onDone:
+			return errors.New("sync stopped unexpectedly")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,195 @@
+package diff
+
+import (
+	"strings"
+
+	"github.com/mgutz/ansi"
+)
+
+// Operation defines if a line was kept, inserted or deleted
+type Operation int
+
+const (
+	// Equal is a line that exists in both texts
+	Equal Operation = iota
+	// Insert is a line that only exists in the new text
+	Insert
+	// Delete is a line that only exists in the old text
+	Delete
+)
+
+// Line is a single line of a diff
+type Line struct {
+	Operation Operation
+	Text      string
+}
+
+// Lines returns the line based difference between the old and the new text
+func Lines(oldText, newText string) []Line {
+	return diffLines(splitLines(oldText), splitLines(newText), []Line{})
+}
+
+// diffLines appends the difference between a and b to lines. It uses the linear space variant of Myers'
+// algorithm, which splits the texts at the middle of a shortest edit script and diffs both halves
+func diffLines(a, b []string, lines []Line) []Line {
+	// Lines at the start and end that are equal are part of every shortest edit script
+	for len(a) > 0 && len(b) > 0 && a[0] == b[0] {
+		lines = append(lines, Line{Operation: Equal, Text: a[0]})
+		a, b = a[1:], b[1:]
+	}
+	suffix := 0
+	for suffix < len(a) && suffix < len(b) && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	suffixLines := a[len(a)-suffix:]
+	a, b = a[:len(a)-suffix], b[:len(b)-suffix]
+
+	if len(a) == 0 {
+		lines = appendLines(lines, Insert, b)
+	} else if len(b) == 0 {
+		lines = appendLines(lines, Delete, a)
+	} else {
+		x, y := middleSnake(a, b)
+		lines = diffLines(a[:x], b[:y], lines)
+		lines = diffLines(a[x:], b[y:], lines)
+	}
+
+	return appendLines(lines, Equal, suffixLines)
+}
+
+// middleSnake returns a point in the middle of a shortest edit script of a and b. It searches from the start
+// and the end at the same time until both searches meet. forward[k] and backward[k] hold the furthest line
+// of a that was reached on diagonal k, where the backward search counts from the end of a and b
+func middleSnake(a, b []string) (int, int) {
+	n, m := len(a), len(b)
+	delta := n - m
+	max := (n + m + 1) / 2
+	offset := max + 1
+	forward := make([]int, 2*max+3)
+	backward := make([]int, 2*max+3)
+
+	for d := 0; d <= max; d++ {
+		for k := -d; k <= d; k += 2 {
+			x := 0
+			if k == -d || (k != d && forward[offset+k-1] < forward[offset+k+1]) {
+				x = forward[offset+k+1]
+			} else {
+				x = forward[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			forward[offset+k] = x
+
+			if delta%2 != 0 && delta-k >= -(d-1) && delta-k <= d-1 && x+backward[offset+delta-k] >= n {
+				return x, y
+			}
+		}
+
+		for k := -d; k <= d; k += 2 {
+			x := 0
+			if k == -d || (k != d && backward[offset+k-1] < backward[offset+k+1]) {
+				x = backward[offset+k+1]
+			} else {
+				x = backward[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[n-1-x] == b[m-1-y] {
+				x++
+				y++
+			}
+			backward[offset+k] = x
+
+			if delta%2 == 0 && delta-k >= -d && delta-k <= d && x+forward[offset+delta-k] >= n {
+				return n - x, m - y
+			}
+		}
+	}
+
+	// not reachable, the searches always meet
+	return n, m
+}
+
+func appendLines(lines []Line, operation Operation, texts []string) []Line {
+	for _, text := range texts {
+		lines = append(lines, Line{Operation: operation, Text: text})
+	}
+
+	return lines
+}
+
+// HasChanges returns true if the diff contains inserted or deleted lines
+func HasChanges(lines []Line) bool {
+	for _, line := range lines {
+		if line.Operation != Equal {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Format returns the changed lines together with the given number of unchanged lines around them.
+// Skipped unchanged lines are replaced with '...'. If color is true, inserted lines are printed green
+// and deleted lines red
+func Format(lines []Line, context int, color bool) string {
+	// Find out which lines should be shown
+	show := make([]bool, len(lines))
+	for i, line := range lines {
+		if line.Operation == Equal {
+			continue
+		}
+
+		for k := i - context; k <= i+context; k++ {
+			if k >= 0 && k < len(lines) {
+				show[k] = true
+			}
+		}
+	}
+
+	out := &strings.Builder{}
+	skipped := false
+	for i, line := range lines {
+		if show[i] == false {
+			skipped = true
+			continue
+		}
+		if skipped {
+			out.WriteString("  ...\n")
+			skipped = false
+		}
+
+		switch line.Operation {
+		case Insert:
+			out.WriteString(colorize("+ "+line.Text, "green", color) + "\n")
+		case Delete:
+			out.WriteString(colorize("- "+line.Text, "red", color) + "\n")
+		default:
+			out.WriteString("  " + line.Text + "\n")
+		}
+	}
+	if skipped && out.Len() > 0 {
+		out.WriteString("  ...\n")
+	}
+
+	return out.String()
+}
+
+func colorize(text, color string, enabled bool) string {
+	if enabled == false {
+		return text
+	}
+
+	return ansi.Color(text, color)
+}
+
+func splitLines(text string) []string {
+	text = strings.TrimSuffix(text, "\n")
+	if text == "" {
+		return []string{}
+	}
+
+	return strings.Split(text, "\n")
+}
@@ -0,0 +1,98 @@
+package diff
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+type formatTestCase struct {
+	name string
+
+	oldText string
+	newText string
+	context int
+
+	expectedChanges bool
+	expectedOutput  string
+}
+
+func TestFormat(t *testing.T) {
+	testCases := []formatTestCase{
+		{
+			name:           "Equal",
+			oldText:        "a\nb\n",
+			newText:        "a\nb\n",
+			context:        1,
+			expectedOutput: "",
+		},
+		{
+			name:            "New text",
+			oldText:         "",
+			newText:         "a\nb\n",
+			context:         1,
+			expectedChanges: true,
+			expectedOutput:  "+ a\n+ b\n",
+		},
+		{
+			name:            "Changed line with context",
+			oldText:         "a\nb\nc\nd\ne\nf\n",
+			newText:         "a\nb\nc\nD\ne\nf\n",
+			context:         1,
+			expectedChanges: true,
+			expectedOutput:  "  ...\n  c\n- d\n+ D\n  e\n  ...\n",
+		},
+		{
+			name:            "Inserted and deleted lines",
+			oldText:         "a\nb\nc",
+			newText:         "b\nc\nd",
+			context:         0,
+			expectedChanges: true,
+			expectedOutput:  "- a\n  ...\n+ d\n",
+		},
+	}
+
+	for _, testCase := range testCases {
+		lines := Lines(testCase.oldText, testCase.newText)
+		assert.Equal(t, HasChanges(lines), testCase.expectedChanges, "Unexpected changes in testCase %s", testCase.name)
+		assert.Equal(t, Format(lines, testCase.context, false), testCase.expectedOutput, "Unexpected output in testCase %s", testCase.name)
+	}
+}
+
+func TestLines(t *testing.T) {
+	oldLines, newLines := []string{}, []string{}
+	for i := 0; i < 20000; i++ {
+		line := strconv.Itoa(i)
+		if i%1000 != 0 {
+			oldLines = append(oldLines, line)
+		}
+		if i%1000 != 500 {
+			newLines = append(newLines, line)
+		}
+	}
+	oldText, newText := strings.Join(oldLines, "\n"), strings.Join(newLines, "\n")
+
+	lines := Lines(oldText, newText)
+	inserted, deleted := 0, 0
+	oldResult, newResult := []string{}, []string{}
+	for _, line := range lines {
+		switch line.Operation {
+		case Insert:
+			inserted++
+			newResult = append(newResult, line.Text)
+		case Delete:
+			deleted++
+			oldResult = append(oldResult, line.Text)
+		default:
+			oldResult = append(oldResult, line.Text)
+			newResult = append(newResult, line.Text)
+		}
+	}
+
+	assert.Equal(t, strings.Join(oldResult, "\n"), oldText)
+	assert.Equal(t, strings.Join(newResult, "\n"), newText)
+	assert.Equal(t, inserted, 20)
+	assert.Equal(t, deleted, 20)
+}
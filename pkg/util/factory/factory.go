@@ -2,6 +2,7 @@ package factory
 
 import (
 	"github.com/loft-sh/devspace/pkg/devspace/analyze"
+	"github.com/loft-sh/devspace/pkg/devspace/bootstrap"
 	"github.com/loft-sh/devspace/pkg/devspace/build"
 	"github.com/loft-sh/devspace/pkg/devspace/config"
 	"github.com/loft-sh/devspace/pkg/devspace/config/generated"
@@ -18,6 +19,7 @@ import (
 	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
 	"github.com/loft-sh/devspace/pkg/devspace/plugin"
 	"github.com/loft-sh/devspace/pkg/devspace/pullsecrets"
+	"github.com/loft-sh/devspace/pkg/devspace/secrets"
 	"github.com/loft-sh/devspace/pkg/devspace/services"
 	"github.com/loft-sh/devspace/pkg/util/kubeconfig"
 	"github.com/loft-sh/devspace/pkg/util/log"
@@ -45,9 +47,15 @@ type Factory interface {
 	// NewHookExecutor creates a new hook executor
 	NewHookExecutor(config config.Config, dependencies []dependencytypes.Dependency) hook.Executer
 
+	// NewBootstrapClient creates a new namespace bootstrap client
+	NewBootstrapClient(config config.Config, dependencies []dependencytypes.Dependency, kubeClient kubectl.Client, log log.Logger) bootstrap.Client
+
 	// NewPullSecretClient creates a new pull secrets client
 	NewPullSecretClient(config config.Config, dependencies []dependencytypes.Dependency, kubeClient kubectl.Client, dockerClient docker.Client, log log.Logger) pullsecrets.Client
 
+	// NewSecretsClient creates a new client for encrypted secrets
+	NewSecretsClient(config config.Config, kubeClient kubectl.Client, log log.Logger) secrets.Client
+
 	// Docker
 	NewDockerClient(log log.Logger) (docker.Client, error)
 	NewDockerClientWithMinikube(currentKubeContext string, preferMinikube bool, log log.Logger) (docker.Client, error)
@@ -120,11 +128,21 @@ func (f *DefaultFactoryImpl) NewDependencyManager(config config.Config, client k
 	return dependency.NewManager(config, client, configOptions, logger)
 }
 
+// NewBootstrapClient implements interface
+func (f *DefaultFactoryImpl) NewBootstrapClient(config config.Config, dependencies []dependencytypes.Dependency, kubeClient kubectl.Client, log log.Logger) bootstrap.Client {
+	return bootstrap.NewClient(config, dependencies, kubeClient, log)
+}
+
 // NewPullSecretClient implements interface
 func (f *DefaultFactoryImpl) NewPullSecretClient(config config.Config, dependencies []dependencytypes.Dependency, kubeClient kubectl.Client, dockerClient docker.Client, log log.Logger) pullsecrets.Client {
 	return pullsecrets.NewClient(config, dependencies, kubeClient, dockerClient, log)
 }
 
+// NewSecretsClient implements interface
+func (f *DefaultFactoryImpl) NewSecretsClient(config config.Config, kubeClient kubectl.Client, log log.Logger) secrets.Client {
+	return secrets.NewClient(config, kubeClient, log)
+}
+
 // NewConfigLoader implements interface
 func (f *DefaultFactoryImpl) NewConfigLoader(configPath string) loader.ConfigLoader {
 	return loader.NewConfigLoader(configPath)
@@ -2,6 +2,7 @@ package testing
 
 import (
 	"github.com/loft-sh/devspace/pkg/devspace/analyze"
+	"github.com/loft-sh/devspace/pkg/devspace/bootstrap"
 	"github.com/loft-sh/devspace/pkg/devspace/build"
 	"github.com/loft-sh/devspace/pkg/devspace/config"
 	"github.com/loft-sh/devspace/pkg/devspace/config/generated"
@@ -17,6 +18,7 @@ import (
 	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
 	"github.com/loft-sh/devspace/pkg/devspace/plugin"
 	"github.com/loft-sh/devspace/pkg/devspace/pullsecrets"
+	"github.com/loft-sh/devspace/pkg/devspace/secrets"
 	"github.com/loft-sh/devspace/pkg/devspace/services"
 	"github.com/loft-sh/devspace/pkg/util/factory"
 	"github.com/loft-sh/devspace/pkg/util/kubeconfig"
@@ -35,7 +37,9 @@ type Factory struct {
 	Log               log.Logger
 	HookExecutor      hook.Executer
 	DependencyManager dependency.Manager
+	BootstrapClient   bootstrap.Client
 	PullSecretClient  pullsecrets.Client
+	SecretsClient     secrets.Client
 	ConfigLoader      loader.ConfigLoader
 	ConfigureManager  configure.Manager
 	DockerClient      docker.Client
@@ -85,11 +89,21 @@ func (f *Factory) NewDependencyManager(config config.Config, client kubectl.Clie
 	return f.DependencyManager
 }
 
+// NewBootstrapClient implements interface
+func (f *Factory) NewBootstrapClient(config config.Config, dependencies []dependencytypes.Dependency, kubeClient kubectl.Client, log log.Logger) bootstrap.Client {
+	return f.BootstrapClient
+}
+
 // NewPullSecretClient implements interface
 func (f *Factory) NewPullSecretClient(config config.Config, dependencies []dependencytypes.Dependency, kubeClient kubectl.Client, dockerClient docker.Client, log log.Logger) pullsecrets.Client {
 	return f.PullSecretClient
 }
 
+// NewSecretsClient implements interface
+func (f *Factory) NewSecretsClient(config config.Config, kubeClient kubectl.Client, log log.Logger) secrets.Client {
+	return f.SecretsClient
+}
+
 // NewConfigLoader implements interface
 func (f *Factory) NewConfigLoader(configPath string) loader.ConfigLoader {
 	return f.ConfigLoader
@@ -98,6 +98,11 @@ func CompareImageNames(selector ImageSelector, image2 string) bool {
 		tagStrippedImage2 = image2
 	}
 
+	// images that are pinned by digest can only be compared by name
+	if strings.Contains(image2, "@") {
+		return tagStrippedImage1 == tagStrippedImage2
+	}
+
 	if tagStrippedImage1 != image1 {
 		// In the case that the tag is latest and we find an image that has no tag
 		if tagStrippedImage1+":latest" == image1 && tagStrippedImage2 == image2 {